@@ -7,6 +7,9 @@ toolchain go1.23.2
 require (
 	github.com/shurcooL/githubv4 v0.0.0-20240727222349-48295856cce7
 	golang.org/x/oauth2 v0.28.0
+	jenkins.io/alpha-omega-stats/ratelimit v0.0.0-00010101000000-000000000000
 )
 
 require github.com/shurcooL/graphql v0.0.0-20230722043721-ed46e5a46466 // indirect
+
+replace jenkins.io/alpha-omega-stats/ratelimit => ../../ratelimit
@@ -5,8 +5,6 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"math"
-	"math/rand"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -15,6 +13,7 @@ import (
 
 	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
+	"jenkins.io/alpha-omega-stats/ratelimit"
 )
 
 // JUnit5PR represents a GitHub pull request related to JUnit 5 migration
@@ -64,14 +63,80 @@ type searchQuery struct {
 	} `graphql:"search(query: $query, type: ISSUE, first: $first, after: $after)"`
 }
 
+// Default search scope, targeting the JUnit 5 migration campaign in the Jenkins organization
+const (
+	defaultOrg         = "jenkinsci"
+	defaultSearchTerms = "junit5,junit 5,migrate tests to junit,junit jupiter,openrewrite junit"
+	defaultAuthors     = "strangelookingnerd"
+)
+
+// splitList splits a comma-separated flag value into trimmed, non-empty values
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// buildSearchQueries returns a title and a body query for each term, followed by one query per author
+func buildSearchQueries(org string, terms, authors []string) []string {
+	var queries []string
+	for _, term := range terms {
+		queries = append(queries,
+			fmt.Sprintf("org:%s is:pr in:title %s", org, term),
+			fmt.Sprintf("org:%s is:pr in:body %s", org, term))
+	}
+	for _, author := range authors {
+		queries = append(queries, fmt.Sprintf("org:%s is:pr author:%s", org, author))
+	}
+	return queries
+}
+
+// withCreatedRange adds a created: qualifier bounding query to startDate and endDate, by day, so
+// GitHub leaves out PRs outside the window. A zero date leaves that side open.
+func withCreatedRange(query string, startDate, endDate time.Time) string {
+	const day = "2006-01-02"
+	switch {
+	case !startDate.IsZero() && !endDate.IsZero():
+		return fmt.Sprintf("%s created:%s..%s", query, startDate.UTC().Format(day), endDate.UTC().Format(day))
+	case !startDate.IsZero():
+		return fmt.Sprintf("%s created:>=%s", query, startDate.UTC().Format(day))
+	case !endDate.IsZero():
+		return fmt.Sprintf("%s created:<=%s", query, endDate.UTC().Format(day))
+	}
+	return query
+}
+
 func main() {
 	// Parse command line flags
 	outputDir := flag.String("output-dir", "data/junit5", "Directory to store output files")
 	candidateFile := flag.String("candidate-file", "junit5_candidate_prs.txt", "File to store candidate PR URLs")
 	existingFile := flag.String("existing-file", "junit5_pr_urls.txt", "File containing existing PR URLs")
 	startDate := flag.String("start-date", "2024-07-01", "Start date for PR search (YYYY-MM-DD)")
+	endDate := flag.String("end-date", "", "End date for PR search (YYYY-MM-DD, inclusive; empty for no end)")
+	maxPages := flag.Int("max-pages", defaultMaxPages, "Maximum number of result pages fetched per search query")
+	org := flag.String("org", defaultOrg, "GitHub organization to search")
+	terms := flag.String("terms", defaultSearchTerms, "Comma-separated terms searched in PR titles and bodies")
+	authors := flag.String("authors", defaultAuthors, "Comma-separated authors known for migration PRs, searched and matched when their PR body mentions JUnit (empty to skip)")
+	telemetry := flag.String("telemetry-file", "", "File to append JSON lines of rate limit telemetry, one per search request")
+	patternsFile := flag.String("patterns-file", "", "JSON file overriding the classification patterns (titlePatterns, bodyPatterns, labelPatterns, excludePatterns, authorPatterns, bodyMatchThreshold)")
 	flag.Parse()
 
+	patterns := withAuthors(defaultPatterns, splitList(*authors))
+	if *patternsFile != "" {
+		c, err := loadPatternsFile(*patternsFile, patterns)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot load patterns file: %v\n", err)
+			os.Exit(1)
+		}
+		activeClassifier = c
+	} else {
+		activeClassifier = mustNewClassifier(patterns)
+	}
+
 	// Get GitHub token from environment
 	token := os.Getenv("GITHUB_TOKEN")
 	if token == "" {
@@ -87,6 +152,27 @@ func main() {
 	}
 	fmt.Printf("Searching for PRs created on or after: %s\n", startDateTime.Format("2006-01-02"))
 
+	// Parse optional end date, inclusive up to the end of that day
+	var endDateTime time.Time
+	if *endDate != "" {
+		endDay, err := time.Parse("2006-01-02", *endDate)
+		if err != nil {
+			fmt.Printf("Error parsing end date: %v\n", err)
+			os.Exit(1)
+		}
+		if endDay.Before(startDateTime) {
+			fmt.Println("End date must not be before the start date")
+			os.Exit(1)
+		}
+		endDateTime = endDay.Add(24*time.Hour - time.Second)
+		fmt.Printf("Searching for PRs created on or before: %s\n", endDay.Format("2006-01-02"))
+	}
+
+	if *maxPages < 1 {
+		fmt.Println("Maximum pages must be at least 1")
+		os.Exit(1)
+	}
+
 	// Create output directory if it doesn't exist
 	if err := os.MkdirAll(*outputDir, 0o755); err != nil {
 		fmt.Fprintf(os.Stderr, "cannot create output dir %s: %v\n", *outputDir, err)
@@ -98,43 +184,30 @@ func main() {
 		&oauth2.Token{AccessToken: token},
 	)
 	httpClient := oauth2.NewClient(context.Background(), src)
+	// Hold requests back while GitHub reports the rate limit budget as exhausted or nearly so
+	limiter := ratelimit.New(5000, "graphql")
+	httpClient.Transport = limiter.Transport(httpClient.Transport)
 	client := githubv4.NewClient(httpClient)
 
-	// Define search terms
-	searchTerms := []string{
-		"junit5",
-		"junit 5",
-		"migrate tests to junit",
-		"junit jupiter",
-		"openrewrite junit",
-	}
-
 	// Initialize result
 	result := SearchResult{
 		PRs: []JUnit5PR{},
 	}
 
-	// Search for each term
-	for _, term := range searchTerms {
-		fmt.Printf("Searching for: %s\n", term)
-
-		// Search in title
-		titleQuery := fmt.Sprintf("org:jenkinsci is:pr in:title %s", term)
-		prs := searchPRs(client, titleQuery, startDateTime)
-		result.PRs = append(result.PRs, prs...)
+	opts := searchOptions{
+		startDate:     startDateTime,
+		endDate:       endDateTime,
+		maxPages:      *maxPages,
+		telemetryFile: *telemetry,
+	}
 
-		// Search in body
-		bodyQuery := fmt.Sprintf("org:jenkinsci is:pr in:body %s", term)
-		prs = searchPRs(client, bodyQuery, startDateTime)
+	// Search each term in titles and bodies, then PRs by authors known for migrations
+	for _, query := range buildSearchQueries(*org, splitList(*terms), splitList(*authors)) {
+		fmt.Printf("Searching for: %s\n", query)
+		prs := searchPRs(client, limiter, query, opts)
 		result.PRs = append(result.PRs, prs...)
 	}
 
-	// Search for PRs by specific authors known for JUnit 5 migrations
-	fmt.Println("Searching for PRs by known JUnit 5 migration authors...")
-	authorQuery := "org:jenkinsci is:pr author:strangelookingnerd"
-	prs := searchPRs(client, authorQuery, startDateTime)
-	result.PRs = append(result.PRs, prs...)
-
 	// Remove duplicates
 	result.PRs = removeDuplicates(result.PRs)
 
@@ -164,8 +237,58 @@ func main() {
 	fmt.Printf("Results saved to %s and %s\n", outputFile, candidatePath)
 }
 
-// searchPRs performs a GitHub search and returns PRs matching the query
-func searchPRs(client *githubv4.Client, query string, startDate time.Time) []JUnit5PR {
+// TelemetryRecord describes one search request and the rate limit reported with its response
+type TelemetryRecord struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Query      string    `json:"query"`
+	Page       int       `json:"page"`
+	Remaining  int       `json:"remaining"`
+	Limit      int       `json:"limit"`
+	ResetAt    time.Time `json:"resetAt"`
+	DurationMs int64     `json:"durationMs"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// appendTelemetry appends record to filename as a single JSON line
+func appendTelemetry(filename string, record TelemetryRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// defaultMaxPages caps the pages fetched per query to prevent excessive API usage
+const defaultMaxPages = 100
+
+// pageDelay is the pause between requests to respect rate limits
+var pageDelay = 1 * time.Second
+
+// searchOptions bound a search and say where its telemetry goes
+type searchOptions struct {
+	startDate     time.Time // PRs created earlier are left out
+	endDate       time.Time // PRs created later are left out; zero leaves the window open
+	maxPages      int       // result pages fetched at most
+	telemetryFile string    // receives one TelemetryRecord per search request when set
+}
+
+// searchPRs performs a GitHub search and returns PRs matching the query created within the
+// window of opts, fetching at most opts.maxPages pages. The window is added to the query and
+// checked again on every result. limiter is the one whose transport client sends requests
+// through, and holds the rate limit reported by GitHub.
+func searchPRs(client *githubv4.Client, limiter *ratelimit.Limiter, query string, opts searchOptions) []JUnit5PR {
+	startDate, endDate := opts.startDate, opts.endDate
+	query = withCreatedRange(query, startDate, endDate)
+
 	var q searchQuery
 	variables := map[string]interface{}{
 		"query": githubv4.String(query),
@@ -184,11 +307,10 @@ func searchPRs(client *githubv4.Client, query string, startDate time.Time) []JUn
 
 	pageCount := 0
 	totalAttempts := 0
-	maxPages := 100 // Set a maximum page limit to prevent infinite loops
 
-	for pageCount < maxPages {
+	for pageCount < opts.maxPages {
 		// Add a small delay between requests to respect rate limits
-		time.Sleep(1 * time.Second)
+		time.Sleep(pageDelay)
 
 		// Create a context with timeout
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -200,44 +322,35 @@ func searchPRs(client *githubv4.Client, query string, startDate time.Time) []JUn
 		for attempt := 0; attempt < maxRetries && !success; attempt++ {
 			totalAttempts++
 
-			// Get current rate limit information before making the request
-			var rateLimit struct {
-				RateLimit struct {
-					Limit     githubv4.Int
-					Remaining githubv4.Int
-					ResetAt   githubv4.DateTime
-				} `graphql:"rateLimit"`
-			}
-
-			rateLimitErr := client.Query(context.Background(), &rateLimit, nil)
-			if rateLimitErr == nil {
-				fmt.Printf("GitHub API rate limit: %d/%d remaining, resets at %s\n",
-					rateLimit.RateLimit.Remaining,
-					rateLimit.RateLimit.Limit,
-					rateLimit.RateLimit.ResetAt.Format(time.RFC3339))
-
-				// Check if we're close to hitting the rate limit
-				if rateLimit.RateLimit.Remaining < 100 {
-					resetTime := time.Until(rateLimit.RateLimit.ResetAt.Time)
-					fmt.Printf("WARNING: Rate limit is low (%d remaining). Limit resets in %s\n",
-						rateLimit.RateLimit.Remaining,
-						resetTime.Round(time.Second))
-
-					if rateLimit.RateLimit.Remaining < 10 {
-						fmt.Println("Rate limit critically low, waiting until reset...")
-						waitTime := time.Until(rateLimit.RateLimit.ResetAt.Time) + 10*time.Second
-						time.Sleep(waitTime)
-					}
-				}
-			} else {
-				fmt.Printf("Could not fetch rate limit info: %v\n", rateLimitErr)
-			}
-
 			// Execute the actual query
 			startTime := time.Now()
 			err = client.Query(ctx, &q, variables)
 			queryDuration := time.Since(startTime)
 
+			rateLimit := limiter.Status()
+			if rateLimit.Updated {
+				fmt.Printf("GitHub API rate limit: %d/%d remaining, resets at %s\n",
+					rateLimit.Remaining, rateLimit.Limit, rateLimit.ResetTime.Format(time.RFC3339))
+			}
+
+			if opts.telemetryFile != "" {
+				record := TelemetryRecord{
+					Timestamp:  startTime,
+					Query:      query,
+					Page:       pageCount + 1,
+					Remaining:  rateLimit.Remaining,
+					Limit:      rateLimit.Limit,
+					ResetAt:    rateLimit.ResetTime,
+					DurationMs: queryDuration.Milliseconds(),
+				}
+				if err != nil {
+					record.Error = err.Error()
+				}
+				if telemetryErr := appendTelemetry(opts.telemetryFile, record); telemetryErr != nil {
+					fmt.Printf("Could not write telemetry: %v\n", telemetryErr)
+				}
+			}
+
 			if err == nil {
 				success = true
 				fmt.Printf("Query succeeded in %s\n", queryDuration.Round(time.Millisecond))
@@ -268,11 +381,16 @@ func searchPRs(client *githubv4.Client, query string, startDate time.Time) []JUn
 						CreatedAt:  pr.CreatedAt.Format(time.RFC3339),
 					}
 
-					// Only include PRs created on or after the start date
+					// Only include PRs created on or after the start date, should the search return others
 					if pr.CreatedAt.Before(startDate) {
 						continue
 					}
 
+					// Only include PRs created on or before the end date, when one is set
+					if !endDate.IsZero() && pr.CreatedAt.After(endDate) {
+						continue
+					}
+
 					// Only include PRs that are likely related to JUnit 5 migration
 					if isJUnit5MigrationPR(newPR) {
 						allPRs = append(allPRs, newPR)
@@ -297,7 +415,7 @@ func searchPRs(client *githubv4.Client, query string, startDate time.Time) []JUn
 				pageCount++
 
 				// Add a small delay before the next page to avoid overwhelming GitHub
-				time.Sleep(1 * time.Second)
+				time.Sleep(pageDelay)
 				break
 			}
 
@@ -332,17 +450,8 @@ func searchPRs(client *githubv4.Client, query string, startDate time.Time) []JUn
 				strings.Contains(err.Error(), "timeout") ||
 				strings.Contains(err.Error(), "Something went wrong") {
 
-				// Calculate backoff duration with exponential increase and jitter
-				backoffTime := float64(initialBackoff) * math.Pow(2, float64(attempt))
-				if backoffTime > float64(maxBackoff) {
-					backoffTime = float64(maxBackoff)
-				}
-
-				// Add jitter (±20%)
-				jitter := (rand.Float64() * 0.4) - 0.2 // -20% to +20%
-				backoffTime = backoffTime * (1 + jitter)
-
-				sleepDuration := time.Duration(backoffTime)
+				// Calculate backoff duration with exponential increase and up to 20% jitter
+				sleepDuration := ratelimit.Backoff(initialBackoff, maxBackoff, 0.2, attempt)
 				fmt.Printf("Retrying in %v...\n", sleepDuration)
 				time.Sleep(sleepDuration)
 			} else {
@@ -364,99 +473,197 @@ func searchPRs(client *githubv4.Client, query string, startDate time.Time) []JUn
 	}
 
 	// If we've reached the maximum number of pages, log a message
-	fmt.Printf("Reached maximum page limit (%d). Stopping to prevent excessive API usage.\n", maxPages)
+	fmt.Printf("Reached maximum page limit (%d). Stopping to prevent excessive API usage.\n", opts.maxPages)
 	return allPRs
 }
 
-// isJUnit5MigrationPR checks if a PR is likely related to JUnit 5 migration
-func isJUnit5MigrationPR(pr JUnit5PR) bool {
-	// Exclude dependency bumps
-	if strings.HasPrefix(pr.Title, "Bump") || strings.HasPrefix(pr.Title, "bump") {
-		return false
-	}
+// ClassificationPatterns are the heuristics isJUnit5MigrationPR applies to search results
+type ClassificationPatterns struct {
+	TitlePatterns      []string `json:"titlePatterns"`
+	BodyPatterns       []string `json:"bodyPatterns"`
+	LabelPatterns      []string `json:"labelPatterns"`
+	ExcludePatterns    []string `json:"excludePatterns"`
+	AuthorPatterns     []string `json:"authorPatterns"`     // authors whose PRs match when their body mentions JUnit
+	BodyMatchThreshold int      `json:"bodyMatchThreshold"` // body patterns that must match for a positive identification
+}
 
+// defaultPatterns identify JUnit 5 migration PRs
+var defaultPatterns = ClassificationPatterns{
+	// Define more specific patterns for JUnit 5 migration PRs
+	TitlePatterns: []string{
+		`(?i)migrate tests? to junit ?5`,
+		`(?i)\bjunit ?5\b`, // Word boundary to ensure "junit5" is a standalone term
+		`(?i)migrate to junit ?5`,
+		`(?i)junit.*(4|four).*(5|five)`,
+		`(?i)junit ?5.*(migration|upgrade)`,
+		`(?i)openrewrite.*junit ?5`,
+	},
+	BodyPatterns: []string{
+		`(?i)migrate (all )?tests? to junit ?5`,
+		`(?i)\bjunit ?5\b`, // Word boundary to ensure "junit5" is a standalone term
+		`(?i)migrate to junit ?5`,
+		`(?i)junit.*(4|four).*(5|five)`,
+		`(?i)junit ?5.*(migration|upgrade)`,
+		`(?i)openrewrite.*junit ?5`,
+		`(?i)org\.junit\.jupiter`,
+		`(?i)junit-jupiter`,
+	},
+	LabelPatterns: []string{
+		`(?i)\bjunit ?5\b`,
+		`(?i)junit-5`,
+		`(?i)junit-migration`,
+	},
 	// Exclude PRs with specific JIRA ticket prefixes that are known not to be JUnit 5 related
 	// These are examples of tickets that were incorrectly included
-	excludePatterns := []string{
+	ExcludePatterns: []string{
 		`(?i)JENKINS-70560`,         // Improve test coverage
 		`(?i)JENKINS-75447`,         // Fix Snippetizer rendering
 		`(?i)JENKINS-\d+.*fix`,      // General fixes
 		`(?i)fix`,                   // General fixes
 		`(?i)improve test coverage`, // Test coverage improvements not related to JUnit 5
-	}
+	},
+	// Require at least 2 body pattern matches for a positive identification
+	// This helps avoid false positives from PRs that mention JUnit in passing
+	BodyMatchThreshold: 2,
+}
 
-	// Pre-compile exclude patterns
-	excludeRegexps := make([]*regexp.Regexp, len(excludePatterns))
-	for i, pattern := range excludePatterns {
-		excludeRegexps[i] = regexp.MustCompile(pattern)
-	}
+// classifier holds the compiled classification patterns
+type classifier struct {
+	title              []*regexp.Regexp
+	body               []*regexp.Regexp
+	label              []*regexp.Regexp
+	exclude            []*regexp.Regexp
+	author             []*regexp.Regexp
+	junit              *regexp.Regexp
+	bodyMatchThreshold int
+}
 
-	for _, re := range excludeRegexps {
-		if re.MatchString(pr.Title) {
-			return false
+// activeClassifier is compiled once from defaultPatterns and -authors, or from -patterns-file
+var activeClassifier = mustNewClassifier(withAuthors(defaultPatterns, splitList(defaultAuthors)))
+
+// compilePatterns compiles each pattern, naming the first invalid one
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	regexps := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
 		}
+		regexps[i] = re
 	}
+	return regexps, nil
+}
 
-	// Define more specific patterns for JUnit 5 migration PRs
-	titlePatterns := []string{
-		`(?i)migrate tests? to junit ?5`,
-		`(?i)\bjunit ?5\b`, // Word boundary to ensure "junit5" is a standalone term
-		`(?i)migrate to junit ?5`,
-		`(?i)junit.*(4|four).*(5|five)`,
-		`(?i)junit ?5.*(migration|upgrade)`,
-		`(?i)openrewrite.*junit ?5`,
+// authorPatterns match each login exactly, ignoring case as GitHub does
+func authorPatterns(authors []string) []string {
+	patterns := make([]string, len(authors))
+	for i, author := range authors {
+		patterns[i] = `(?i)^` + regexp.QuoteMeta(author) + `$`
 	}
+	return patterns
+}
+
+// withAuthors returns patterns whose author rule matches the given authors
+func withAuthors(patterns ClassificationPatterns, authors []string) ClassificationPatterns {
+	patterns.AuthorPatterns = authorPatterns(authors)
+	return patterns
+}
 
-	// Pre-compile title patterns
-	titleRegexps := make([]*regexp.Regexp, len(titlePatterns))
-	for i, pattern := range titlePatterns {
-		titleRegexps[i] = regexp.MustCompile(pattern)
+// newClassifier compiles patterns into a classifier
+func newClassifier(patterns ClassificationPatterns) (*classifier, error) {
+	c := &classifier{
+		junit:              regexp.MustCompile(`(?i)junit`),
+		bodyMatchThreshold: patterns.BodyMatchThreshold,
 	}
 
-	bodyPatterns := []string{
-		`(?i)migrate (all )?tests? to junit ?5`,
-		`(?i)\bjunit ?5\b`, // Word boundary to ensure "junit5" is a standalone term
-		`(?i)migrate to junit ?5`,
-		`(?i)junit.*(4|four).*(5|five)`,
-		`(?i)junit ?5.*(migration|upgrade)`,
-		`(?i)openrewrite.*junit ?5`,
-		`(?i)org\.junit\.jupiter`,
-		`(?i)junit-jupiter`,
+	var err error
+	if c.title, err = compilePatterns(patterns.TitlePatterns); err != nil {
+		return nil, err
+	}
+	if c.body, err = compilePatterns(patterns.BodyPatterns); err != nil {
+		return nil, err
 	}
+	if c.label, err = compilePatterns(patterns.LabelPatterns); err != nil {
+		return nil, err
+	}
+	if c.exclude, err = compilePatterns(patterns.ExcludePatterns); err != nil {
+		return nil, err
+	}
+	if c.author, err = compilePatterns(patterns.AuthorPatterns); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
 
-	// Pre-compile body patterns
-	bodyRegexps := make([]*regexp.Regexp, len(bodyPatterns))
-	for i, pattern := range bodyPatterns {
-		bodyRegexps[i] = regexp.MustCompile(pattern)
+// mustNewClassifier compiles built-in patterns, panicking if they are invalid
+func mustNewClassifier(patterns ClassificationPatterns) *classifier {
+	c, err := newClassifier(patterns)
+	if err != nil {
+		panic(err)
 	}
+	return c
+}
 
-	labelPatterns := []string{
-		`(?i)\bjunit ?5\b`,
-		`(?i)junit-5`,
-		`(?i)junit-migration`,
+// loadPatternsFile reads classification patterns from a JSON file over base. Lists absent from
+// the file, and a missing or zero bodyMatchThreshold, keep their base values.
+func loadPatternsFile(filename string, base ClassificationPatterns) (*classifier, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
 	}
 
-	// Pre-compile label patterns
-	labelRegexps := make([]*regexp.Regexp, len(labelPatterns))
-	for i, pattern := range labelPatterns {
-		labelRegexps[i] = regexp.MustCompile(pattern)
+	var override ClassificationPatterns
+	if err := json.Unmarshal(data, &override); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", filename, err)
 	}
 
-	authorPatterns := []string{
-		`strangelookingnerd`,
+	patterns := base
+	if override.TitlePatterns != nil {
+		patterns.TitlePatterns = override.TitlePatterns
+	}
+	if override.BodyPatterns != nil {
+		patterns.BodyPatterns = override.BodyPatterns
+	}
+	if override.LabelPatterns != nil {
+		patterns.LabelPatterns = override.LabelPatterns
+	}
+	if override.ExcludePatterns != nil {
+		patterns.ExcludePatterns = override.ExcludePatterns
+	}
+	if override.AuthorPatterns != nil {
+		patterns.AuthorPatterns = override.AuthorPatterns
 	}
+	if override.BodyMatchThreshold > 0 {
+		patterns.BodyMatchThreshold = override.BodyMatchThreshold
+	}
+
+	c, err := newClassifier(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("error in %s: %w", filename, err)
+	}
+	return c, nil
+}
+
+// isJUnit5MigrationPR checks if a PR is likely related to JUnit 5 migration
+func isJUnit5MigrationPR(pr JUnit5PR) bool {
+	return activeClassifier.matches(pr)
+}
 
-	// Pre-compile author patterns
-	authorRegexps := make([]*regexp.Regexp, len(authorPatterns))
-	for i, pattern := range authorPatterns {
-		authorRegexps[i] = regexp.MustCompile(pattern)
+// matches checks if a PR matches the classification patterns
+func (c *classifier) matches(pr JUnit5PR) bool {
+	// Exclude dependency bumps
+	if strings.HasPrefix(pr.Title, "Bump") || strings.HasPrefix(pr.Title, "bump") {
+		return false
 	}
 
-	// Pre-compile the JUnit pattern used in author check
-	junitRegexp := regexp.MustCompile(`(?i)junit`)
+	for _, re := range c.exclude {
+		if re.MatchString(pr.Title) {
+			return false
+		}
+	}
 
 	// Check title with more specific matching
-	for _, re := range titleRegexps {
+	for _, re := range c.title {
 		if re.MatchString(pr.Title) {
 			return true
 		}
@@ -465,21 +672,19 @@ func isJUnit5MigrationPR(pr JUnit5PR) bool {
 	// For body matches, require stronger evidence
 	// Count how many patterns match in the body
 	bodyMatchCount := 0
-	for _, re := range bodyRegexps {
+	for _, re := range c.body {
 		if re.MatchString(pr.Body) {
 			bodyMatchCount++
 		}
 	}
 
-	// Require at least 2 body pattern matches for a positive identification
-	// This helps avoid false positives from PRs that mention JUnit in passing
-	if bodyMatchCount >= 2 {
+	if bodyMatchCount >= c.bodyMatchThreshold {
 		return true
 	}
 
 	// Check labels
 	for _, label := range pr.Labels {
-		for _, re := range labelRegexps {
+		for _, re := range c.label {
 			if re.MatchString(label) {
 				return true
 			}
@@ -488,10 +693,10 @@ func isJUnit5MigrationPR(pr JUnit5PR) bool {
 
 	// Check author - but only if there's at least some mention of JUnit in the body
 	// This avoids including all PRs from certain authors
-	for _, re := range authorRegexps {
+	for _, re := range c.author {
 		if re.MatchString(pr.Author) {
 			// Check if there's at least some mention of JUnit in the body
-			if junitRegexp.MatchString(pr.Body) {
+			if c.junit.MatchString(pr.Body) {
 				return true
 			}
 		}
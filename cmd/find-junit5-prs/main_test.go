@@ -0,0 +1,311 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"jenkins.io/alpha-omega-stats/ratelimit"
+)
+
+// TestBuildSearchQueries verifies the title, body and author queries reflect the configured org, terms and authors
+func TestBuildSearchQueries(t *testing.T) {
+	queries := buildSearchQueries("example-org", splitList(" assertj , junit 5"), splitList("alice,bob"))
+
+	expected := []string{
+		"org:example-org is:pr in:title assertj",
+		"org:example-org is:pr in:body assertj",
+		"org:example-org is:pr in:title junit 5",
+		"org:example-org is:pr in:body junit 5",
+		"org:example-org is:pr author:alice",
+		"org:example-org is:pr author:bob",
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("Expected %v, got %v", expected, queries)
+	}
+}
+
+// TestBuildSearchQueriesDefaults verifies the defaults reproduce the original JUnit 5 search
+func TestBuildSearchQueriesDefaults(t *testing.T) {
+	queries := buildSearchQueries(defaultOrg, splitList(defaultSearchTerms), splitList(defaultAuthors))
+
+	if len(queries) != 11 {
+		t.Fatalf("Expected 11 queries for 5 terms and 1 author, got %d", len(queries))
+	}
+	if queries[0] != "org:jenkinsci is:pr in:title junit5" {
+		t.Errorf("Unexpected first query %q", queries[0])
+	}
+	if queries[10] != "org:jenkinsci is:pr author:strangelookingnerd" {
+		t.Errorf("Unexpected author query %q", queries[10])
+	}
+	if authors := splitList(""); len(authors) != 0 {
+		t.Errorf("Expected no authors for an empty flag, got %v", authors)
+	}
+}
+
+// TestWithCreatedRange verifies the search query is bounded by the start and end dates, by day
+func TestWithCreatedRange(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+	query := "org:jenkinsci is:pr junit5"
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		expected   string
+	}{
+		{"both bounds", start, end, query + " created:2025-01-01..2025-01-31"},
+		{"start only", start, time.Time{}, query + " created:>=2025-01-01"},
+		{"end only", time.Time{}, end, query + " created:<=2025-01-31"},
+		{"no bounds", time.Time{}, time.Time{}, query},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withCreatedRange(query, tt.start, tt.end); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestLoadPatternsFile verifies a custom pattern file changes the classification of a sample PR
+func TestLoadPatternsFile(t *testing.T) {
+	pr := JUnit5PR{
+		Title: "Migrate assertions to AssertJ",
+		Body:  "Replaces Hamcrest matchers with AssertJ assertions",
+	}
+	if activeClassifier.matches(pr) {
+		t.Fatal("Expected the default patterns not to classify an AssertJ PR as a migration")
+	}
+
+	filename := filepath.Join(t.TempDir(), "patterns.json")
+	patterns := `{"titlePatterns": ["(?i)assertj"], "bodyMatchThreshold": 1}`
+	if err := os.WriteFile(filename, []byte(patterns), 0o644); err != nil {
+		t.Fatalf("Failed to write patterns file: %v", err)
+	}
+
+	c, err := loadPatternsFile(filename, defaultPatterns)
+	if err != nil {
+		t.Fatalf("loadPatternsFile failed: %v", err)
+	}
+	if !c.matches(pr) {
+		t.Error("Expected the custom title pattern to classify the AssertJ PR as a migration")
+	}
+	if c.bodyMatchThreshold != 1 || len(c.body) != len(defaultPatterns.BodyPatterns) {
+		t.Errorf("Expected the threshold to be overridden and default body patterns kept")
+	}
+
+	// Default exclusions still apply
+	if c.matches(JUnit5PR{Title: "Fix AssertJ usage"}) {
+		t.Error("Expected the default exclude patterns to still reject fixes")
+	}
+}
+
+// TestClassifierAuthors verifies the author rule follows the configured authors
+func TestClassifierAuthors(t *testing.T) {
+	pr := JUnit5PR{
+		Title:  "Modernize tests",
+		Body:   "Uses JUnit Jupiter",
+		Author: "alice",
+	}
+
+	if activeClassifier.matches(pr) {
+		t.Error("Expected a PR by an author outside the defaults not to match")
+	}
+
+	c, err := newClassifier(withAuthors(defaultPatterns, []string{"Alice", "bob"}))
+	if err != nil {
+		t.Fatalf("newClassifier failed: %v", err)
+	}
+	if !c.matches(pr) {
+		t.Error("Expected a PR by a configured author mentioning JUnit to match")
+	}
+	if c.matches(JUnit5PR{Title: "Modernize tests", Body: "Uses JUnit Jupiter", Author: "alice-bot"}) {
+		t.Error("Expected the author rule to match whole logins only")
+	}
+
+	c, err = newClassifier(defaultPatterns)
+	if err != nil {
+		t.Fatalf("newClassifier failed: %v", err)
+	}
+	if c.matches(JUnit5PR{Title: "Modernize tests", Body: "Uses JUnit Jupiter", Author: "strangelookingnerd"}) {
+		t.Error("Expected no author rule without authors")
+	}
+}
+
+// TestLoadPatternsFileAuthors verifies the author rule can be overridden from a patterns file
+func TestLoadPatternsFileAuthors(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "patterns.json")
+	if err := os.WriteFile(filename, []byte(`{"authorPatterns": ["^carol$"]}`), 0o644); err != nil {
+		t.Fatalf("Failed to write patterns file: %v", err)
+	}
+
+	c, err := loadPatternsFile(filename, withAuthors(defaultPatterns, []string{"alice"}))
+	if err != nil {
+		t.Fatalf("loadPatternsFile failed: %v", err)
+	}
+	if !c.matches(JUnit5PR{Title: "Modernize tests", Body: "Uses JUnit Jupiter", Author: "carol"}) {
+		t.Error("Expected the file's author pattern to match")
+	}
+	if c.matches(JUnit5PR{Title: "Modernize tests", Body: "Uses JUnit Jupiter", Author: "alice"}) {
+		t.Error("Expected the file's author patterns to replace those from -authors")
+	}
+}
+
+// TestLoadPatternsFileInvalid verifies an invalid regular expression is reported
+func TestLoadPatternsFileInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "patterns.json")
+	if err := os.WriteFile(filename, []byte(`{"labelPatterns": ["("]}`), 0o644); err != nil {
+		t.Fatalf("Failed to write patterns file: %v", err)
+	}
+
+	if _, err := loadPatternsFile(filename, defaultPatterns); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
+
+// searchServer serves the rate limit query and a search page of migration PRs created on the given dates,
+// always announcing a next page. It counts the search requests.
+func searchServer(createdAt []string, searches *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), 10))
+
+		*searches++
+		var nodes []map[string]interface{}
+		for i, created := range createdAt {
+			nodes = append(nodes, map[string]interface{}{
+				"title":      "Migrate tests to JUnit 5",
+				"url":        fmt.Sprintf("https://github.com/jenkinsci/example-plugin/pull/%d%d", *searches, i),
+				"state":      "MERGED",
+				"createdAt":  created,
+				"author":     map[string]string{"login": "contributor"},
+				"repository": map[string]string{"nameWithOwner": "jenkinsci/example-plugin"},
+				"labels":     map[string]interface{}{"nodes": []interface{}{}},
+				"bodyText":   "",
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"search": map[string]interface{}{
+					"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": fmt.Sprintf("cursor-%d", *searches)},
+					"nodes":    nodes,
+				},
+			},
+		})
+	}))
+}
+
+// newSearchClient creates a client of server sending its requests through a rate limiter, as main does
+func newSearchClient(server *httptest.Server) (*githubv4.Client, *ratelimit.Limiter) {
+	limiter := ratelimit.New(5000, "graphql")
+	httpClient := &http.Client{Transport: limiter.Transport(server.Client().Transport)}
+	return githubv4.NewEnterpriseClient(server.URL, httpClient), limiter
+}
+
+// TestSearchPRsDateWindow verifies PRs created outside the start and end dates are excluded
+func TestSearchPRsDateWindow(t *testing.T) {
+	pageDelay = 0
+	defer func() { pageDelay = time.Second }()
+
+	searches := 0
+	server := searchServer([]string{"2024-12-31T23:00:00Z", "2025-01-15T10:00:00Z", "2025-01-31T22:00:00Z", "2025-02-01T00:30:00Z"}, &searches)
+	defer server.Close()
+	client, limiter := newSearchClient(server)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+	prs := searchPRs(client, limiter, "org:jenkinsci is:pr junit5", searchOptions{startDate: start, endDate: end, maxPages: 1})
+
+	var created []string
+	for _, pr := range prs {
+		created = append(created, pr.CreatedAt)
+	}
+	expected := []string{"2025-01-15T10:00:00Z", "2025-01-31T22:00:00Z"}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("Expected PRs created %v, got %v", expected, created)
+	}
+
+	// Without an end date only the start bound applies
+	if prs := searchPRs(client, limiter, "org:jenkinsci is:pr junit5", searchOptions{startDate: start, maxPages: 1}); len(prs) != 3 {
+		t.Errorf("Expected 3 PRs without an end date, got %d", len(prs))
+	}
+}
+
+// TestSearchPRsMaxPages verifies no more than maxPages search pages are fetched
+func TestSearchPRsMaxPages(t *testing.T) {
+	pageDelay = 0
+	defer func() { pageDelay = time.Second }()
+
+	searches := 0
+	server := searchServer([]string{"2025-01-15T10:00:00Z"}, &searches)
+	defer server.Close()
+	client, limiter := newSearchClient(server)
+
+	prs := searchPRs(client, limiter, "org:jenkinsci is:pr junit5", searchOptions{maxPages: 3})
+	if searches != 3 {
+		t.Errorf("Expected 3 search pages, got %d", searches)
+	}
+	if len(prs) != 3 {
+		t.Errorf("Expected one PR per page, got %d", len(prs))
+	}
+}
+
+// TestSearchPRsTelemetry verifies a telemetry record with the rate limit is appended per search request
+func TestSearchPRsTelemetry(t *testing.T) {
+	pageDelay = 0
+	defer func() { pageDelay = time.Second }()
+	telemetryFile := filepath.Join(t.TempDir(), "telemetry.jsonl")
+
+	searches := 0
+	server := searchServer([]string{"2025-01-15T10:00:00Z"}, &searches)
+	defer server.Close()
+	client, limiter := newSearchClient(server)
+
+	searchPRs(client, limiter, "org:jenkinsci is:pr junit5", searchOptions{maxPages: 2, telemetryFile: telemetryFile})
+
+	data, err := os.ReadFile(telemetryFile)
+	if err != nil {
+		t.Fatalf("Failed to read telemetry: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one telemetry record per search request, got %d", len(lines))
+	}
+
+	for i, line := range lines {
+		var record TelemetryRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid telemetry record %q: %v", line, err)
+		}
+		if record.Query != "org:jenkinsci is:pr junit5" || record.Page != i+1 {
+			t.Errorf("Unexpected query or page in record %+v", record)
+		}
+		if record.Remaining != 4999 || record.Limit != 5000 || record.ResetAt.Year() != 2030 {
+			t.Errorf("Expected the rate limit in record %+v", record)
+		}
+		if record.Timestamp.IsZero() || record.DurationMs < 0 {
+			t.Errorf("Expected a timestamp and duration in record %+v", record)
+		}
+	}
+
+	// Records carry the documented JSON field names
+	var fields map[string]interface{}
+	json.Unmarshal([]byte(lines[0]), &fields)
+	for _, field := range []string{"timestamp", "query", "remaining", "limit", "resetAt", "page", "durationMs"} {
+		if _, ok := fields[field]; !ok {
+			t.Errorf("Expected field %q in telemetry record", field)
+		}
+	}
+}
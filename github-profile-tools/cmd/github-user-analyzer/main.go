@@ -3,17 +3,29 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"text/template"
 	"time"
 
+	"github.com/jenkins/github-profile-tools/internal/cache"
+	"github.com/jenkins/github-profile-tools/internal/discourse"
 	"github.com/jenkins/github-profile-tools/internal/docker"
+	"github.com/jenkins/github-profile-tools/internal/github"
+	"github.com/jenkins/github-profile-tools/internal/logging"
 	"github.com/jenkins/github-profile-tools/internal/markdown"
+	"github.com/jenkins/github-profile-tools/internal/metrics"
 	"github.com/jenkins/github-profile-tools/internal/profile"
 	"github.com/joho/godotenv"
 )
@@ -27,36 +39,115 @@ var (
 // Config holds command line configuration
 type Config struct {
 	Username         string
+	Org              string
 	DockerUsername   string
 	DiscourseUsername string
 	Token            string
+	EnvFile          string // dotenv file loaded instead of ../.env or .env
+	MetricsAddr      string // serve Prometheus metrics on this address during the analysis
 	OutputDir        string
+	OutputPerUser    bool // write into <OutputDir>/<username>/
+	FilenameTemplate string // Go template naming profile files; empty keeps defaultFilenameTemplate
 	Template         string
 	Format           string
 	Verbose          bool
 	SaveJSON         bool
+	SkillsJSON       bool // also write <username>_skills.json
+	Badges           bool // also write <username>_badges.md
 	ShowVersion      bool
 	Timeout          time.Duration
 	DebugLogFile     string
 	CacheDir         string
 	CacheTTL         time.Duration
+	CacheNamespace   string
+	CacheBackend     string
 	ForceRefresh     bool
 	CacheStats       bool
 	ClearCache       bool
 	DockerOnly       bool
+	DiscourseAPIKey  string
+	DiscourseAPIUser string
+	DiscourseMaxPosts int
+	StackOverflowUser string
+	ContribSince     string // YYYY-MM-DD, inclusive
+	ContribUntil     string // YYYY-MM-DD, inclusive
+	WithLOC          bool
+	LanguageFallback bool
+	WithSecurityScan bool // detect security practices from repository files
+	WithContributors bool
+	WithReviews      bool // fetch recent pull request reviews for mentorship signals
+	ExcludeBots      string // comma-separated bot logins left out of contributor and review counts
+	MonorepoAware    bool
+	Combined         bool
+	NoEmoji          bool
+	Mermaid          bool
+	PrivateAggregateOnly bool // keep private repositories out of named sections
+	SummaryJSON      bool
+	ListProgress     bool
+	ClearProgress    string // username or "all"
+	ValidateFile     string // profile JSON to check for completeness
+	ProgressMaxAge   time.Duration
+	AnalysisMaxAge   time.Duration
+	Incremental      bool
+	DryRun           bool
+	RepoPageSize     int
+	MaxRepos         int // zero means unlimited
+	ActiveSince      string // e.g. "2y"; repositories without pushes since are left out of templates
+	MinLanguagePercent float64
+	MinSkillConfidence float64
+	MinForks         int // repositories with fewer forks are left out of project listings
+	RecencyHalfLife  float64 // years
+	StarDecay        float64 // years since last push at which a repository's stars count half; zero disables
+	ProfileURLPrefix string // GitHub web host of profile links, e.g. a GitHub Enterprise server
+	DockerHubURLPrefix string // web host of the Docker Hub profile link
+	Anonymize        bool
+	Lang             string
+	GitHubRPS        float64
+	RequestTimeout   time.Duration
+	WithAvatar       bool
+	EnrichDescriptions bool
+	GroupBy          string
+	SortLanguages    string // by-percentage (default when empty) or by-proficiency
+	RolesConfig      string
+	ImpactConfig     string // JSON file with the impact score formula
+	Stdout           bool
+	Diff             bool
 }
 
+// outputDir returns the directory generated files are written to: OutputDir itself, or with
+// -output-per-user a subdirectory named after the analyzed user or organization
+func outputDir(config Config) string {
+	if !config.OutputPerUser {
+		return config.OutputDir
+	}
+
+	subject := config.Username
+	switch {
+	case config.Org != "":
+		subject = config.Org
+	case config.DockerOnly:
+		subject = config.DockerUsername
+	}
+	if subject == "" {
+		return config.OutputDir
+	}
+	return filepath.Join(config.OutputDir, subject)
+}
+
+// metricsShutdownTimeout bounds how long in-flight scrapes may delay exit once the analysis is done
+const metricsShutdownTimeout = 5 * time.Second
+
+// avatarFetchTimeout bounds the avatar download so a slow image host cannot stall output generation
+const avatarFetchTimeout = 10 * time.Second
+
 // main is the entry point for the GitHub User Analyzer CLI.
-// It loads environment variables from ../.env or .env, parses and validates command-line flags,
+// It loads environment variables from -env-file, ENV_FILE, ../.env or .env, parses and validates command-line flags,
 // optionally prints the tool version and exits, configures dual debug logging, creates a context
 // with the configured timeout, and runs the profile analysis, terminating the program on fatal errors.
 func main() {
-	// Load .env file if it exists
-	if err := godotenv.Load("../.env"); err != nil {
-		// Try loading from current directory
-		if err := godotenv.Load(".env"); err != nil {
-			// .env file not found, continue without it
-		}
+	// Load the requested dotenv file, or ../.env or .env if they exist
+	if err := loadEnvFile(envFileFromArgs(os.Args[1:])); err != nil {
+		log.Fatal(err)
 	}
 
 	config := parseFlags()
@@ -70,6 +161,12 @@ func main() {
 	if err := validateConfig(config); err != nil {
 		log.Fatal(err)
 	}
+	config.OutputDir = outputDir(config)
+
+	// Per-request details are logged at debug level, only shown with -verbose
+	if config.Verbose {
+		logging.SetLevel(logging.LevelDebug)
+	}
 
 	// Set up dual logging (console + file)
 	logFile, err := setupDebugLogging(config.DebugLogFile, config.Verbose)
@@ -84,14 +181,63 @@ func main() {
 		log.Printf("Using timeout: %v", config.Timeout)
 	}
 
+	var metricsServer *metrics.Server
+	if config.MetricsAddr != "" {
+		metricsServer, err = metrics.Start(config.MetricsAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Serving metrics on http://%s/metrics", metricsServer.Addr())
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
 	defer cancel()
 
-	if err := runAnalysis(ctx, config); err != nil {
+	// Ctrl-C cancels the analysis like a timeout, so that progress is saved and partial outputs written
+	ctx, stopInterrupt := withInterrupt(ctx)
+	defer stopInterrupt()
+
+	err = runAnalysis(ctx, config)
+
+	if metricsServer != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		if shutdownErr := metricsServer.Shutdown(shutdownCtx); shutdownErr != nil {
+			log.Printf("Warning: Failed to shut down metrics server: %v", shutdownErr)
+		}
+		shutdownCancel()
+	}
+
+	if err != nil {
 		log.Fatal(err)
 	}
 }
 
+// withInterrupt returns a context cancelled on SIGINT or SIGTERM, letting the analysis save its
+// progress before exiting. A second signal exits immediately. The returned function stops listening.
+func withInterrupt(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			log.Printf("Received %v, saving progress before exiting (interrupt again to exit immediately)", sig)
+			cancel()
+		case <-ctx.Done():
+			return
+		}
+		<-signals
+		log.Printf("Interrupted again, exiting without saving")
+		os.Exit(130)
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
 // parseFlags parses command-line flags and returns a Config populated from flag values and environment variables.
 // The function registers flags for username, token, output directory, template, format, verbosity, JSON saving, version, timeout, and debug log file;
 // it provides a custom usage message, resolves the timeout via parseTimeout, and selects DebugLogFile from the flag, the DEBUG_LOG_FILE environment variable, or a sensible default before returning the populated Config.
@@ -101,24 +247,80 @@ func parseFlags() Config {
 	var timeoutStr string
 	var cacheTTLStr string
 
-	flag.StringVar(&config.Username, "user", "", "GitHub username to analyze (required)")
+	flag.StringVar(&config.Username, "user", "", "GitHub username to analyze (required unless -org is set)")
+	flag.StringVar(&config.Org, "org", "", "GitHub organization to analyze instead of a user")
+	flag.BoolVar(&config.Diff, "diff", false, "Compare the cached analysis with the previous one instead of analyzing")
 	flag.StringVar(&config.DockerUsername, "docker-user", "", "Docker Hub username (defaults to GitHub username if not specified)")
 	flag.StringVar(&config.DiscourseUsername, "discourse-user", "", "Discourse username (defaults to GitHub username if not specified)")
 	flag.StringVar(&config.Token, "token", os.Getenv("GITHUB_TOKEN"), "GitHub API token (or set GITHUB_TOKEN env var)")
+	flag.StringVar(&config.EnvFile, "env-file", os.Getenv("ENV_FILE"), "Dotenv file to load instead of ../.env or .env (or set ENV_FILE env var)")
 	flag.StringVar(&config.OutputDir, "output", "./data/profiles", "Output directory for generated files")
-	flag.StringVar(&config.Template, "template", "all", "Template type: resume, technical, executive, ats, all (default: all)")
-	flag.StringVar(&config.Format, "format", "both", "Output format: markdown, json, both")
-	flag.BoolVar(&config.Verbose, "verbose", false, "Enable verbose logging")
+	flag.BoolVar(&config.OutputPerUser, "output-per-user", false, "Write generated files into a <output>/<username>/ subdirectory")
+	flag.StringVar(&config.FilenameTemplate, "filename-template", defaultFilenameTemplate, "Go template naming profile files, with {{.Username}}, {{.Template}}, {{.Date}} and {{.Format}}")
+	flag.StringVar(&config.Template, "template", "all", "Template type: resume, technical, executive, ats, organization, all (default: all)")
+	flag.StringVar(&config.Format, "format", "both", "Output format: markdown, json, both, all")
+	flag.StringVar(&config.MetricsAddr, "metrics-addr", "", "Serve Prometheus metrics on this address (e.g. ':9090') while the analysis runs")
+	flag.BoolVar(&config.Verbose, "verbose", false, "Enable verbose logging, including per-request debug details")
 	flag.BoolVar(&config.SaveJSON, "save-json", true, "Save raw JSON profile data")
+	flag.BoolVar(&config.Badges, "badges", false, "Also write shields.io badges for a profile README to <username>_badges.md")
+	flag.BoolVar(&config.SkillsJSON, "skills-json", false, "Also write the complete skills taxonomy to <username>_skills.json")
 	flag.BoolVar(&config.ShowVersion, "version", false, "Show version and exit")
 	flag.StringVar(&timeoutStr, "timeout", "", "Analysis timeout (e.g., '30m', '2h', '6h'). Default: 6h, or set ANALYSIS_TIMEOUT env var")
 	flag.StringVar(&config.DebugLogFile, "debug-log", "", "Debug log file path (default: github-user-analyzer-debug.log, or set DEBUG_LOG_FILE env var)")
 	flag.StringVar(&config.CacheDir, "cache-dir", "./data/cache", "Cache directory for storing analysis results")
+	flag.StringVar(&config.CacheNamespace, "cache-namespace", "", "Keep cache entries apart from other namespaces sharing -cache-dir")
+	flag.StringVar(&config.CacheBackend, "cache-backend", cache.BackendFile, "Cache storage: file, or memory to keep entries for this run only (e.g. in CI)")
 	flag.StringVar(&cacheTTLStr, "cache-ttl", "24h", "Cache time-to-live (e.g., '1h', '6h', '24h', '7d')")
 	flag.BoolVar(&config.ForceRefresh, "force-refresh", false, "Bypass cache and force fresh analysis")
 	flag.BoolVar(&config.CacheStats, "cache-stats", false, "Show cache statistics and exit")
 	flag.BoolVar(&config.ClearCache, "clear-cache", false, "Clear all cache entries and exit")
+	flag.DurationVar(&config.ProgressMaxAge, "progress-max-age", profile.DefaultProgressMaxAge, "Resume interrupted analyses saved within this duration (e.g., '24h', '72h')")
+	flag.DurationVar(&config.AnalysisMaxAge, "analysis-max-age", profile.DefaultAnalysisMaxAge, "Reuse completed analyses younger than this duration (e.g., '168h')")
+	flag.BoolVar(&config.Incremental, "incremental", false, "Only re-analyze repositories pushed to since the previous analysis")
+	flag.Float64Var(&config.RecencyHalfLife, "recency-halflife", profile.DefaultRecencyHalfLife, "Years after which an unused technology's weight halves in skill scoring (0 = disabled)")
+	flag.Float64Var(&config.StarDecay, "star-decay", 0, "Years since a repository's last push after which its stars count half in impact and notable projects (0 = disabled)")
+	flag.StringVar(&config.ProfileURLPrefix, "profile-url-prefix", markdown.DefaultGitHubURL, "GitHub host of the profile and repository links in generated templates, e.g. for GitHub Enterprise")
+	flag.StringVar(&config.DockerHubURLPrefix, "docker-hub-url-prefix", markdown.DefaultDockerHubURL, "Host of the Docker Hub profile link in generated templates")
+	flag.IntVar(&config.RepoPageSize, "repo-page-size", profile.DefaultRepoPageSize, "Repositories fetched per GraphQL page (1-100); smaller pages save progress more often")
+	flag.StringVar(&config.ActiveSince, "active-since", "", "Leave repositories not pushed to within this age out of templates, e.g. 2y, 18w, 90d (kept in JSON)")
+	flag.IntVar(&config.MaxRepos, "max-repos", 0, "Analyze at most this many repositories, in GitHub's default order (0 = unlimited)")
+	flag.Float64Var(&config.GitHubRPS, "github-rps", github.DefaultRequestsPerSecond, "Maximum GitHub API requests per second (raise for GitHub Apps with higher quotas)")
+	flag.DurationVar(&config.RequestTimeout, "request-timeout", github.DefaultRequestTimeout, "Abort and retry a single GitHub API request after this duration (e.g., '30s', '2m')")
+	flag.BoolVar(&config.DryRun, "dry-run", false, "Estimate the GitHub API calls an analysis would need and exit without fetching")
+	flag.BoolVar(&config.ListProgress, "list-progress", false, "List saved progress files of interrupted analyses and exit")
+	flag.StringVar(&config.ClearProgress, "clear-progress", "", "Remove saved progress for a username (or 'all') and exit")
+	flag.StringVar(&config.ValidateFile, "validate", "", "Check a generated <username>_profile.json for missing analyses and exit (non-zero if incomplete)")
 	flag.BoolVar(&config.DockerOnly, "docker-only", false, "Analyze only Docker Hub profile (skip GitHub analysis)")
+	flag.StringVar(&config.DiscourseAPIKey, "discourse-api-key", os.Getenv("DISCOURSE_API_KEY"), "Discourse API key for private forums (or set DISCOURSE_API_KEY env var)")
+	flag.StringVar(&config.DiscourseAPIUser, "discourse-api-user", os.Getenv("DISCOURSE_API_USERNAME"), "Discourse username the API key acts as (or set DISCOURSE_API_USERNAME env var)")
+	flag.IntVar(&config.DiscourseMaxPosts, "discourse-max-posts", discourse.DefaultMaxPosts, "Maximum number of Discourse posts to fetch per user (0 = no limit)")
+	flag.StringVar(&config.ContribSince, "contrib-since", "", "Start date for contribution analysis, YYYY-MM-DD (default: one year before -contrib-until)")
+	flag.StringVar(&config.ContribUntil, "contrib-until", "", "End date for contribution analysis, YYYY-MM-DD, inclusive (default: today)")
+	flag.BoolVar(&config.Stdout, "stdout", false, "Write the selected template (or the JSON profile with -format json) to stdout instead of files, for piping")
+	flag.BoolVar(&config.SummaryJSON, "summary-json", false, "Print a compact JSON summary to stdout instead of the decorated summary")
+	flag.Float64Var(&config.MinLanguagePercent, "min-language-percent", markdown.DefaultMinLanguagePercent, "Omit languages below this share of the codebase from generated templates (0-100)")
+	flag.Float64Var(&config.MinSkillConfidence, "min-skill-confidence", 0, "Omit frameworks, databases, cloud and DevOps skills below this confidence from generated templates (0-1)")
+	flag.IntVar(&config.MinForks, "min-forks", 0, "List only repositories with at least this many forks in notable and project sections")
+	flag.StringVar(&config.ImpactConfig, "impact-config", "", "JSON file with impact score divisors, weights and logScale (see profile.ImpactConfig)")
+	flag.StringVar(&config.RolesConfig, "roles-config", "", "JSON file replacing the built-in role recommendation rules (see internal/profile/roles.json)")
+	flag.StringVar(&config.GroupBy, "group-by", string(markdown.GroupByLanguage), "Grouping of the technical template's project portfolio: language, topic")
+	flag.StringVar(&config.SortLanguages, "sort-languages", string(markdown.SortByPercentage), "Order of the programming language sections: by-percentage, by-proficiency")
+	flag.StringVar(&config.Lang, "lang", markdown.DefaultLanguage, "Language of template section headers: "+strings.Join(markdown.SupportedLanguages(), ", "))
+	flag.BoolVar(&config.EnrichDescriptions, "enrich-descriptions", false, "Describe notable repositories without a description using the first paragraph of their README")
+	flag.BoolVar(&config.WithAvatar, "with-avatar", false, "Embed the GitHub avatar in the resume and executive template headers")
+	flag.BoolVar(&config.Anonymize, "anonymize", false, "Strip name, email, Twitter username and location from all outputs (for sharing sample profiles)")
+	flag.BoolVar(&config.Combined, "combined", false, "Write all selected templates into a single <user>_profile_combined.md file")
+	flag.BoolVar(&config.NoEmoji, "no-emoji", false, "Leave emoji out of template section headers")
+	flag.BoolVar(&config.Mermaid, "mermaid", false, "Add a Mermaid diagram of technical areas and their technologies to the technical template")
+	flag.BoolVar(&config.PrivateAggregateOnly, "private-aggregate-only", false, "Count private repositories in language statistics and totals but never list them by name in rendered profiles")
+	flag.BoolVar(&config.WithLOC, "with-loc", false, "Fetch commit additions/deletions for top repositories (API-expensive)")
+	flag.BoolVar(&config.WithSecurityScan, "with-security-scan", false, "Detect security practices such as SECURITY.md and Dependabot (one more REST call per repository)")
+	flag.BoolVar(&config.WithContributors, "with-contributors", false, "Count contributors to top owned repositories for a community section (one query per repository)")
+	flag.BoolVar(&config.WithReviews, "with-reviews", false, "Derive mentorship signals from the pull requests reviewed for others (one extra query)")
+	flag.StringVar(&config.ExcludeBots, "exclude-bots", strings.Join(profile.DefaultBotLogins, ","), "Comma-separated bot logins left out of contributor and review counts; logins ending in [bot] always are")
+	flag.BoolVar(&config.MonorepoAware, "monorepo-aware", false, "Down-weight detected monorepos so they do not dominate language percentages")
+	flag.BoolVar(&config.LanguageFallback, "language-fallback", false, "Fetch languages over REST for repositories GraphQL reports none for (one call per such repository)")
+	flag.StringVar(&config.StackOverflowUser, "stackoverflow-user", "", "Stack Overflow user ID, profile URL or display name (skipped if not specified)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "GitHub User Analyzer v%s\n\n", version)
@@ -130,16 +332,68 @@ func parseFlags() Config {
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -template resume          # Generate only resume template\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -template technical       # Generate only technical template\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -format markdown          # Generate all templates in markdown only\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -format all               # Write every output format in one run\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -env-file ~/profile.env   # Load the token from a specific dotenv file\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -output ./resumes         # Generate all templates in custom directory\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -timeout 2h -verbose      # Generate all templates with extended timeout\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -force-refresh             # Force fresh analysis, bypass cache\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -cache-ttl 7d              # Cache results for 7 days\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -cache-dir ./my-cache      # Use custom cache directory\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -cache-namespace team-a    # Separate cache entries per tenant\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -cache-backend memory      # Cache in memory only, no files written\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -docker-user dockercat     # Use different Docker Hub username\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -user octocat -discourse-user octodisco  # Use different Discourse username\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -discourse-api-key KEY -discourse-api-user system  # Authenticate Discourse requests\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -stackoverflow-user 1288478  # Include Stack Overflow reputation\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -contrib-since 2019-04-01 -contrib-until 2022-09-30  # Analyze contributions during a specific tenure\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -min-language-percent 5   # List only languages with at least 5%% of the code\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -min-skill-confidence 0.3 # Hide weakly evidenced frameworks and tools\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -min-forks 5              # Highlight libraries others have forked\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -lang fr                  # Render section headers in French\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -anonymize                # Share a sample profile without personal details\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -with-avatar              # Show the user's avatar in rendered profiles\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -enrich-descriptions      # Fill empty project descriptions from READMEs\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -template technical -group-by topic  # Group projects by GitHub topic\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -sort-languages by-proficiency  # List the best mastered languages first\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -roles-config roles.json  # Recommend your organization's job titles\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -impact-config impact.json  # Tune the impact score, e.g. on a log scale\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -template resume -stdout | pandoc -o resume.pdf  # Pipe a profile\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -combined                 # Generate all templates into one document\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -no-emoji                 # Plain section headers, keeping the layout\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -private-aggregate-only   # Keep private repository names out of profiles\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -template technical -mermaid  # Include a visual skills map\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -badges                   # Write badges to paste into your profile README\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -output-per-user          # Write files to ./data/profiles/octocat/\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -filename-template '{{.Date}}_{{.Username}}_{{.Template}}.{{.Format}}'  # Date-stamp the files\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -skills-json              # Write the skills taxonomy for dashboards\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -summary-json             # Print a machine-readable summary for scripts\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -with-loc                 # Include lines added/removed (slower, more API calls)\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -with-security-scan       # Report security practices across repositories\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -with-contributors        # Show the community around owned projects\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -with-reviews             # Credit reviews of other contributors' pull requests\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -exclude-bots ci-robot    # Count only people as contributors\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -language-fallback        # Fill in languages GraphQL did not return\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -monorepo-aware           # Keep a monorepo from skewing language stats\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -diff                     # Show what changed since the previous analysis\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -org jenkinsci                          # Summarize an organization's public repositories\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -docker-user dockercat -docker-only      # Analyze only Docker Hub profile\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -cache-stats                             # Show cache statistics\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s -clear-cache                             # Clear all cached data\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -clear-cache                             # Clear all cached data\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -incremental              # Skip repositories unchanged since last run\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -progress-max-age 72h     # Resume analyses interrupted up to 3 days ago\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -recency-halflife 1.5      # Favor recently used technologies more strongly\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -star-decay 2             # Rank active popular projects above abandoned ones\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -profile-url-prefix https://github.example.com  # Link to GitHub Enterprise\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -repo-page-size 100       # Fewer requests for users with many repositories\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -active-since 2y          # Leave abandoned projects out of the resume\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -max-repos 200            # Bound runtime for users with thousands of repositories\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -github-rps 5             # Send requests faster with a higher API quota\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -request-timeout 2m       # Allow slow GitHub responses more time\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -metrics-addr :9090       # Expose progress metrics for Prometheus\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -user octocat -dry-run                  # Estimate API usage before a long analysis\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -list-progress                           # Show interrupted analyses that can be resumed\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -clear-progress octocat                  # Discard saved progress for a user\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -validate data/profiles/octocat_profile.json  # List the analyses a profile is missing\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 	}
@@ -209,6 +463,46 @@ func setupDebugLogging(debugLogFile string, verbose bool) (*os.File, error) {
 	return logFile, nil
 }
 
+// envFileFromArgs returns the -env-file value from args, or ENV_FILE when the flag is not given.
+// It runs before flag parsing so that the file can provide the defaults of other flags.
+func envFileFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			continue
+		}
+		if value, ok := strings.CutPrefix(name, "env-file="); ok {
+			return value
+		}
+		if name == "env-file" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return os.Getenv("ENV_FILE")
+}
+
+// loadEnvFile loads path into the environment, failing if it cannot be read.
+// Without a path, ../.env then .env are tried and a missing file is not an error.
+func loadEnvFile(path string) error {
+	if path != "" {
+		if err := godotenv.Load(path); err != nil {
+			return fmt.Errorf("failed to load env file %s: %w", path, err)
+		}
+		return nil
+	}
+
+	if err := godotenv.Load("../.env"); err != nil {
+		// Try loading from current directory
+		if err := godotenv.Load(".env"); err != nil {
+			// .env file not found, continue without it
+		}
+	}
+	return nil
+}
+
 // parseTimeout parses timeout from command line flag, environment variable, or returns default
 func parseTimeout(flagValue string) time.Duration {
 	// Default timeout is 6 hours
@@ -273,15 +567,37 @@ func parseCacheTTL(flagValue string) time.Duration {
 	return ttl
 }
 
+// parseAge parses an age such as "2y", "18w" or "90d", or any Go duration like "720h".
+// A year counts 365 days.
+func parseAge(value string) (time.Duration, error) {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour, "y": 365 * 24 * time.Hour}
+
+	age, err := time.ParseDuration(value)
+	if unit, ok := units[value[len(value)-1:]]; err != nil && ok {
+		var n float64
+		n, err = strconv.ParseFloat(value[:len(value)-1], 64)
+		age = time.Duration(n * float64(unit))
+	}
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 2y, 18w, 90d or 720h)", value)
+	}
+	return age, nil
+}
+
 // validateConfig validates the configuration
 func validateConfig(config Config) error {
-	// Skip username validation for cache-only operations and Docker-only mode
-	if !config.CacheStats && !config.ClearCache && !config.DockerOnly && config.Username == "" {
-		return fmt.Errorf("username is required (use -user flag)")
+	// Skip username validation for cache-only, progress-only and profile validation operations and Docker-only mode
+	maintenanceOnly := config.CacheStats || config.ClearCache || config.ListProgress || config.ClearProgress != "" ||
+		config.ValidateFile != ""
+	if config.Org != "" && config.Username != "" {
+		return fmt.Errorf("-org and -user are mutually exclusive")
+	}
+	if !maintenanceOnly && !config.DockerOnly && config.Username == "" && config.Org == "" {
+		return fmt.Errorf("username is required (use -user flag, or -org for an organization)")
 	}
 
-	// Skip GitHub token validation for Docker-only operations
-	if !config.CacheStats && !config.ClearCache && !config.DockerOnly && config.Token == "" {
+	// Skip GitHub token validation for Docker-only operations and diffs of cached analyses
+	if !maintenanceOnly && !config.DockerOnly && !config.Diff && config.Token == "" {
 		return fmt.Errorf("GitHub token is required (use -token flag or set GITHUB_TOKEN environment variable)")
 	}
 
@@ -290,19 +606,154 @@ func validateConfig(config Config) error {
 		return fmt.Errorf("Docker-only mode requires a Docker username (use -docker-user flag)")
 	}
 
-	validTemplates := []string{"resume", "technical", "executive", "ats", "all"}
+	validTemplates := []string{"resume", "technical", "executive", "ats", "organization", "all"}
 	if !contains(validTemplates, config.Template) {
 		return fmt.Errorf("invalid template: %s (valid options: %s)", config.Template, strings.Join(validTemplates, ", "))
 	}
 
-	validFormats := []string{"markdown", "json", "both"}
+	// Organizations only have the organization template, which is for organizations only
+	if config.Org != "" && config.Template != "organization" && config.Template != "all" {
+		return fmt.Errorf("-org only supports -template organization")
+	}
+	if config.Org == "" && config.Template == "organization" {
+		return fmt.Errorf("-template organization requires -org")
+	}
+
+	validFormats := []string{"markdown", "json", "both", "all"}
 	if !contains(validFormats, config.Format) {
 		return fmt.Errorf("invalid format: %s (valid options: %s)", config.Format, strings.Join(validFormats, ", "))
 	}
 
+	if config.Stdout {
+		if config.Template == "all" && config.Org == "" && !config.Diff {
+			return fmt.Errorf("-stdout writes a single document: select one -template (resume, technical, executive, ats)")
+		}
+		if config.SummaryJSON {
+			return fmt.Errorf("-stdout cannot be combined with -summary-json")
+		}
+	}
+
+	if _, err := renderFilename(config.FilenameTemplate, filenameData{Username: "octocat", Template: "resume", Date: "2006-01-02", Format: "md"}); err != nil {
+		return fmt.Errorf("invalid -filename-template: %w", err)
+	}
+
+	// Empty keeps the file backend
+	validCacheBackends := []string{cache.BackendFile, cache.BackendMemory}
+	if config.CacheBackend != "" && !contains(validCacheBackends, config.CacheBackend) {
+		return fmt.Errorf("invalid -cache-backend: %s (valid options: %s)", config.CacheBackend, strings.Join(validCacheBackends, ", "))
+	}
+
+	validGroupings := []string{string(markdown.GroupByLanguage), string(markdown.GroupByTopic)}
+	if !contains(validGroupings, config.GroupBy) {
+		return fmt.Errorf("invalid -group-by: %s (valid options: %s)", config.GroupBy, strings.Join(validGroupings, ", "))
+	}
+
+	validLanguageOrders := []string{string(markdown.SortByPercentage), string(markdown.SortByProficiency)}
+	if config.SortLanguages != "" && !contains(validLanguageOrders, config.SortLanguages) {
+		return fmt.Errorf("invalid -sort-languages: %s (valid options: %s)", config.SortLanguages, strings.Join(validLanguageOrders, ", "))
+	}
+
+	if !contains(markdown.SupportedLanguages(), config.Lang) {
+		return fmt.Errorf("invalid language: %s (valid options: %s)", config.Lang, strings.Join(markdown.SupportedLanguages(), ", "))
+	}
+
+	if config.MinLanguagePercent < 0 || config.MinLanguagePercent > 100 {
+		return fmt.Errorf("invalid -min-language-percent: %v (must be between 0 and 100)", config.MinLanguagePercent)
+	}
+
+	if config.MinSkillConfidence < 0 || config.MinSkillConfidence > 1 {
+		return fmt.Errorf("invalid -min-skill-confidence: %v (must be between 0 and 1)", config.MinSkillConfidence)
+	}
+
+	if config.MinForks < 0 {
+		return fmt.Errorf("invalid -min-forks: %d (must not be negative)", config.MinForks)
+	}
+
+	if config.RecencyHalfLife < 0 {
+		return fmt.Errorf("invalid -recency-halflife: %v (must be 0 or more years)", config.RecencyHalfLife)
+	}
+
+	if config.StarDecay < 0 {
+		return fmt.Errorf("invalid -star-decay: %v (must be 0 or more years)", config.StarDecay)
+	}
+
+	if err := validateURLPrefix("-profile-url-prefix", config.ProfileURLPrefix); err != nil {
+		return err
+	}
+	if err := validateURLPrefix("-docker-hub-url-prefix", config.DockerHubURLPrefix); err != nil {
+		return err
+	}
+
+	if config.GitHubRPS <= 0 {
+		return fmt.Errorf("invalid -github-rps: %v (must be greater than 0)", config.GitHubRPS)
+	}
+
+	if config.RequestTimeout <= 0 {
+		return fmt.Errorf("invalid -request-timeout: %v (must be greater than 0)", config.RequestTimeout)
+	}
+
+	// GitHub accepts at most 100 nodes per page
+	if config.RepoPageSize < 1 || config.RepoPageSize > profile.MaxRepoPageSize {
+		return fmt.Errorf("invalid -repo-page-size: %d (must be between 1 and %d)", config.RepoPageSize, profile.MaxRepoPageSize)
+	}
+
+	if config.ActiveSince != "" {
+		if _, err := parseAge(config.ActiveSince); err != nil {
+			return err
+		}
+	}
+
+	if config.MaxRepos < 0 {
+		return fmt.Errorf("invalid -max-repos: %d (must be 0 for unlimited or more)", config.MaxRepos)
+	}
+
+	// Validate reasonable bounds (1 minute to 30 days for progress, 1 minute to 365 days for analyses)
+	if config.ProgressMaxAge < time.Minute || config.ProgressMaxAge > 30*24*time.Hour {
+		return fmt.Errorf("invalid -progress-max-age: %v (must be between 1m and 720h)", config.ProgressMaxAge)
+	}
+	if config.AnalysisMaxAge < time.Minute || config.AnalysisMaxAge > 365*24*time.Hour {
+		return fmt.Errorf("invalid -analysis-max-age: %v (must be between 1m and 8760h)", config.AnalysisMaxAge)
+	}
+
+	if _, _, err := parseContributionWindow(config); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// parseContributionWindow converts -contrib-since/-contrib-until into a half-open [since, until) range.
+// Zero values are returned for unset flags so the analyzer falls back to its defaults.
+func parseContributionWindow(config Config) (time.Time, time.Time, error) {
+	var since, until time.Time
+
+	if config.ContribSince != "" {
+		t, err := time.Parse("2006-01-02", config.ContribSince)
+		if err != nil {
+			return since, until, fmt.Errorf("invalid -contrib-since date %q (expected YYYY-MM-DD)", config.ContribSince)
+		}
+		since = t
+	}
+
+	if config.ContribUntil != "" {
+		t, err := time.Parse("2006-01-02", config.ContribUntil)
+		if err != nil {
+			return since, until, fmt.Errorf("invalid -contrib-until date %q (expected YYYY-MM-DD)", config.ContribUntil)
+		}
+		// The end date is inclusive, so the window runs until the start of the following day
+		until = t.AddDate(0, 0, 1)
+	}
+
+	if !since.IsZero() && !until.IsZero() && !since.Before(until.AddDate(0, 0, -1)) {
+		return since, until, fmt.Errorf("-contrib-since (%s) must be before -contrib-until (%s)", config.ContribSince, config.ContribUntil)
+	}
+	if !since.IsZero() && until.IsZero() && !since.Before(time.Now()) {
+		return since, until, fmt.Errorf("-contrib-since (%s) must be in the past", config.ContribSince)
+	}
+
+	return since, until, nil
+}
+
 // runAnalysis performs the GitHub user analysis
 func runAnalysis(ctx context.Context, config Config) error {
 	if config.Verbose {
@@ -325,6 +776,26 @@ func runAnalysis(ctx context.Context, config Config) error {
 		return clearCache(config)
 	}
 
+	// Handle progress listing command
+	if config.ListProgress {
+		return listProgress(config)
+	}
+
+	// Handle clear progress command
+	if config.ClearProgress != "" {
+		return clearProgress(config)
+	}
+
+	// Handle profile completeness check
+	if config.ValidateFile != "" {
+		return validateProfile(os.Stdout, config.ValidateFile)
+	}
+
+	// Handle diff of cached analyses
+	if config.Diff {
+		return runDiff(config)
+	}
+
 	// Handle Docker-only mode
 	if config.DockerOnly {
 		return runDockerOnlyAnalysis(ctx, config)
@@ -332,10 +803,67 @@ func runAnalysis(ctx context.Context, config Config) error {
 
 	// Create base analyzer
 	analyzer := profile.NewAnalyzer(config.Token)
+	analyzer.SetGitHubClient(github.NewClientWithRateLimit(config.Token, config.GitHubRPS, 1).WithRequestTimeout(config.RequestTimeout))
+
+	// Configure the Discourse client (anonymous unless an API key was provided)
+	discourseClient := discourse.NewClientWithAuth(discourse.DefaultBaseURL, config.DiscourseAPIKey, config.DiscourseAPIUser).
+		WithMaxPosts(config.DiscourseMaxPosts)
+	if config.CacheDir != "" {
+		discourseClient.WithCategoryCacheFile(filepath.Join(config.CacheDir, "discourse", "categories.json"))
+	}
+	analyzer.SetDiscourseClient(discourseClient)
+	if config.Verbose && config.DiscourseAPIKey != "" {
+		log.Printf("Using authenticated Discourse requests (API user: %s)", config.DiscourseAPIUser)
+	}
+	analyzer.SetStackOverflowUser(config.StackOverflowUser)
+
+	// Restrict contribution analysis to the requested window (already validated)
+	contribSince, contribUntil, _ := parseContributionWindow(config)
+	analyzer.SetContributionWindow(contribSince, contribUntil)
+	analyzer.SetLineStatsEnabled(config.WithLOC)
+	analyzer.SetLanguageFallback(config.LanguageFallback)
+	analyzer.SetContributorsEnabled(config.WithContributors)
+	analyzer.SetReviewsEnabled(config.WithReviews)
+	analyzer.SetBotLogins(parseList(config.ExcludeBots))
+	analyzer.SetSecurityScan(config.WithSecurityScan)
+	analyzer.SetMonorepoAware(config.MonorepoAware)
+	analyzer.SetProgressMaxAge(config.ProgressMaxAge)
+	analyzer.SetAnalysisMaxAge(config.AnalysisMaxAge)
+	analyzer.SetIncremental(config.Incremental)
+	analyzer.SetRepoPageSize(config.RepoPageSize)
+	analyzer.SetMaxRepos(config.MaxRepos)
+	analyzer.SetRecencyHalfLife(config.RecencyHalfLife)
+	analyzer.SetStarDecay(config.StarDecay)
+	analyzer.SetAnonymize(config.Anonymize)
+
+	if config.RolesConfig != "" {
+		rules, err := profile.LoadRoleRules(config.RolesConfig)
+		if err != nil {
+			return err
+		}
+		analyzer.SetRoleRules(rules)
+	}
+
+	if config.ImpactConfig != "" {
+		impact, err := profile.LoadImpactConfig(config.ImpactConfig)
+		if err != nil {
+			return err
+		}
+		analyzer.SetImpactConfig(impact)
+	}
+
+	if config.Org != "" {
+		return runOrganizationAnalysis(ctx, config, analyzer)
+	}
+
+	// Handle dry run: estimate API usage without analyzing
+	if config.DryRun {
+		return dryRun(ctx, config, analyzer)
+	}
 
 	// Wrap with cache if cache directory is specified
 	if config.CacheDir != "" {
-		cacheAwareAnalyzer, err := profile.WrapWithCache(analyzer, config.CacheDir, config.ForceRefresh)
+		cacheAwareAnalyzer, err := profile.WrapWithCacheBackend(analyzer, config.CacheDir, config.CacheNamespace, config.CacheBackend, config.ForceRefresh)
 		if err != nil {
 			log.Printf("Warning: Failed to initialize cache, proceeding without caching: %v", err)
 		} else {
@@ -356,43 +884,67 @@ func runAnalysis(ctx context.Context, config Config) error {
 		log.Printf("Using Discourse username: %s", config.DiscourseUsername)
 	}
 	prof, err := analyzer.AnalyzeUserWithCustomUsernames(ctx, config.Username, config.DockerUsername, config.DiscourseUsername)
+	// An interrupted analysis still writes what it gathered, flagged as partial
+	var partialErr error
+	if errors.Is(err, profile.ErrPartialAnalysis) && prof != nil {
+		log.Printf("Warning: %v; writing partial outputs", err)
+		partialErr = fmt.Errorf("outputs for %s are partial: %w", config.Username, err)
+		err = nil
+	}
 	if err != nil {
+		// A mistyped username is not a failure of the tool
+		if errors.Is(err, github.ErrUserNotFound) {
+			fmt.Fprintf(os.Stderr, "❌ No such GitHub user: %s\n", config.Username)
+			return nil
+		}
 		return fmt.Errorf("failed to analyze user %s: %w", config.Username, err)
 	}
 
-	// Create output directory
-	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+	// Also covers profiles loaded from a cache written before -anonymize was used
+	if config.Anonymize {
+		profile.Anonymize(prof)
 	}
 
-	// Generate outputs based on format
-	if config.Format == "json" || config.Format == "both" {
-		if err := saveJSONProfile(prof, config); err != nil {
-			return fmt.Errorf("failed to save JSON profile: %w", err)
-		}
+	if config.EnrichDescriptions && partialErr == nil {
+		analyzer.EnrichDescriptions(ctx, prof, profile.DefaultEnrichLimit)
 	}
 
-	if config.Format == "markdown" || config.Format == "both" {
-		// Determine which templates to generate
-		var templatesToGenerate []string
-		if config.Template == "all" {
-			templatesToGenerate = []string{"resume", "technical", "executive", "ats"}
-		} else {
-			templatesToGenerate = []string{config.Template}
-		}
+	// Stale repositories are left out of the templates but kept in the JSON profile
+	view := prof
+	if config.ActiveSince != "" {
+		age, _ := parseAge(config.ActiveSince) // already validated
+		view = analyzer.ActiveView(prof, time.Now().Add(-age))
+	}
 
-		// Generate each template
-		for _, template := range templatesToGenerate {
-			templateConfig := config
-			templateConfig.Template = template
-			if err := generateMarkdownProfile(prof, templateConfig); err != nil {
-				return fmt.Errorf("failed to generate %s markdown profile: %w", template, err)
-			}
+	// Nothing but the document goes to stdout so it can be piped
+	if config.Stdout {
+		doc := view
+		if config.Format == "json" {
+			doc = prof
+		}
+		if err := writeStdout(os.Stdout, doc, config); err != nil {
+			return err
 		}
+		return partialErr
+	}
+
+	// Create output directory
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := writeProfileFiles(prof, view, config); err != nil {
+		return err
 	}
 
 	// Print summary
-	printSummary(prof, config)
+	if config.SummaryJSON {
+		if err := printSummaryJSON(os.Stdout, prof, config); err != nil {
+			return fmt.Errorf("failed to print JSON summary: %w", err)
+		}
+	} else {
+		printSummary(prof, config)
+	}
 
 	// Print final rate limit status
 	if config.Verbose {
@@ -407,12 +959,79 @@ func runAnalysis(ctx context.Context, config Config) error {
 		log.Printf("  Usage: %.1f%% of hourly quota", percentUsed)
 	}
 
-	return nil
+	return partialErr
+}
+
+// defaultFilenameTemplate reproduces the <username>_profile[_<template>].<format> names
+const defaultFilenameTemplate = "{{.Username}}_profile{{if .Template}}_{{.Template}}{{end}}.{{.Format}}"
+
+// filenameData is the data available to -filename-template
+type filenameData struct {
+	Username string
+	Template string // resume, technical, executive, ats, combined, skills or badges; empty for the JSON profile
+	Date     string // day of the analysis, YYYY-MM-DD
+	Format   string // file extension: md or json
+}
+
+// profileFilename names a profile output file with -filename-template
+func profileFilename(config Config, prof *profile.UserProfile, templateName, format string) string {
+	analyzed := prof.LastAnalyzed
+	if analyzed.IsZero() {
+		analyzed = time.Now()
+	}
+	data := filenameData{
+		Username: prof.Username,
+		Template: templateName,
+		Date:     analyzed.Format("2006-01-02"),
+		Format:   format,
+	}
+
+	name, err := renderFilename(config.FilenameTemplate, data)
+	if err != nil {
+		// validateConfig rejects unusable templates up front
+		log.Printf("Warning: %v, using the default file name", err)
+		name, _ = renderFilename(defaultFilenameTemplate, data)
+	}
+	return name
+}
+
+// extraFilename names an output file written next to the profile, such as the skills taxonomy:
+// <username>_<kind>.<format> with the default -filename-template, otherwise the template rendered
+// with kind as {{.Template}}
+func extraFilename(config Config, prof *profile.UserProfile, kind, format string) string {
+	if config.FilenameTemplate == "" || config.FilenameTemplate == defaultFilenameTemplate {
+		return fmt.Sprintf("%s_%s.%s", prof.Username, kind, format)
+	}
+	return profileFilename(config, prof, kind, format)
+}
+
+// renderFilename executes a filename template and sanitizes the result so that it cannot leave
+// the output directory: path separators become underscores and "." or ".." are rejected
+func renderFilename(text string, data filenameData) (string, error) {
+	if text == "" {
+		text = defaultFilenameTemplate
+	}
+
+	tmpl, err := template.New("filename").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var name strings.Builder
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", err
+	}
+
+	sanitized := strings.NewReplacer("/", "_", "\\", "_", "\x00", "").Replace(strings.TrimSpace(name.String()))
+	if sanitized == "" || sanitized == "." || sanitized == ".." {
+		return "", fmt.Errorf("filename template produced the unusable name %q", name.String())
+	}
+	return sanitized, nil
 }
 
 // saveJSONProfile saves the profile data as JSON
 func saveJSONProfile(prof *profile.UserProfile, config Config) error {
-	filename := fmt.Sprintf("%s_profile.json", prof.Username)
+	filename := profileFilename(config, prof, "", "json")
 	filepath := filepath.Join(config.OutputDir, filename)
 
 	data, err := json.MarshalIndent(prof, "", "  ")
@@ -420,7 +1039,7 @@ func saveJSONProfile(prof *profile.UserProfile, config Config) error {
 		return fmt.Errorf("failed to marshal profile to JSON: %w", err)
 	}
 
-	if err := os.WriteFile(filepath, data, 0644); err != nil {
+	if err := profile.WriteFileAtomic(filepath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write JSON file: %w", err)
 	}
 
@@ -431,17 +1050,111 @@ func saveJSONProfile(prof *profile.UserProfile, config Config) error {
 	return nil
 }
 
-// generateMarkdownProfile generates and saves the markdown profile
-func generateMarkdownProfile(prof *profile.UserProfile, config Config) error {
+// saveSkillsJSON saves the complete skills taxonomy as <username>_skills.json
+func saveSkillsJSON(prof *profile.UserProfile, config Config) error {
+	filename := extraFilename(config, prof, "skills", "json")
+	filepath := filepath.Join(config.OutputDir, filename)
+
+	data, err := json.MarshalIndent(prof.Skills, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal skills to JSON: %w", err)
+	}
+
+	if err := profile.WriteFileAtomic(filepath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write skills JSON file: %w", err)
+	}
+
+	if config.Verbose {
+		log.Printf("Saved skills JSON: %s", filepath)
+	}
+
+	return nil
+}
+
+// saveBadges saves shields.io badges for the user's profile README as <username>_badges.md
+func saveBadges(prof *profile.UserProfile, config Config) error {
+	badges, err := markdown.GenerateProfileBadge(prof)
+	if err != nil {
+		return err
+	}
+
+	filepath := filepath.Join(config.OutputDir, extraFilename(config, prof, "badges", "md"))
+	if err := profile.WriteFileAtomic(filepath, []byte(badges), 0644); err != nil {
+		return fmt.Errorf("failed to write badges file: %w", err)
+	}
+
+	if config.Verbose {
+		log.Printf("Saved badges: %s", filepath)
+	}
+
+	return nil
+}
+
+// writeStdout writes the selected template, or the JSON profile with -format json, to w
+func writeStdout(w io.Writer, prof *profile.UserProfile, config Config) error {
+	if config.Format == "json" {
+		data, err := json.MarshalIndent(prof, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal profile to JSON: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	content, err := newGenerator(config, prof).GenerateMarkdown(prof, markdown.TemplateType(config.Template))
+	if err != nil {
+		return fmt.Errorf("failed to generate markdown: %w", err)
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+// newGenerator creates a markdown generator for prof configured from the command-line flags. With
+// -with-avatar it downloads the avatar, so callers share one generator across templates.
+func newGenerator(config Config, prof *profile.UserProfile) *markdown.Generator {
 	generator := markdown.NewGenerator()
+	generator.SetMinLanguagePercent(config.MinLanguagePercent)
+	generator.SetMinSkillConfidence(config.MinSkillConfidence)
+	generator.SetMinForks(config.MinForks)
+	generator.SetStarDecay(config.StarDecay)
+	if config.ProfileURLPrefix != "" {
+		generator.SetGitHubURL(config.ProfileURLPrefix)
+	}
+	if config.DockerHubURLPrefix != "" {
+		generator.SetDockerHubURL(config.DockerHubURLPrefix)
+	}
+	generator.SetGrouping(markdown.Grouping(config.GroupBy))
+	generator.SetLanguageOrder(markdown.LanguageOrder(config.SortLanguages))
+	generator.SetUseEmoji(!config.NoEmoji)
+	generator.SetMermaid(config.Mermaid)
+	generator.SetPrivateAggregateOnly(config.PrivateAggregateOnly)
+	if err := generator.SetLanguage(config.Lang); err != nil {
+		// Already validated; keep the default English headers
+		log.Printf("Warning: %v", err)
+	}
+	if config.WithAvatar && prof.AvatarURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), avatarFetchTimeout)
+		defer cancel()
+		avatar, err := markdown.FetchAvatarDataURI(ctx, http.DefaultClient, prof.AvatarURL)
+		if err != nil {
+			// The profile is still useful without a picture
+			log.Printf("Warning: omitting avatar: %v", err)
+		} else {
+			generator.SetAvatar(avatar)
+		}
+	}
+	return generator
+}
 
+// generateMarkdownProfile generates and saves the markdown profile
+func generateMarkdownProfile(generator *markdown.Generator, prof *profile.UserProfile, config Config) error {
 	templateType := markdown.TemplateType(config.Template)
 	content, err := generator.GenerateMarkdown(prof, templateType)
 	if err != nil {
 		return fmt.Errorf("failed to generate markdown: %w", err)
 	}
 
-	filename := fmt.Sprintf("%s_profile_%s.md", prof.Username, config.Template)
+	filename := profileFilename(config, prof, config.Template, "md")
 	filepath := filepath.Join(config.OutputDir, filename)
 
 	if err := os.WriteFile(filepath, []byte(content), 0644); err != nil {
@@ -455,6 +1168,83 @@ func generateMarkdownProfile(prof *profile.UserProfile, config Config) error {
 	return nil
 }
 
+// generateCombinedMarkdownProfile generates the selected templates and saves them as one markdown file
+func generateCombinedMarkdownProfile(generator *markdown.Generator, prof *profile.UserProfile, config Config, templates []string) error {
+	templateTypes := make([]markdown.TemplateType, 0, len(templates))
+	for _, template := range templates {
+		templateTypes = append(templateTypes, markdown.TemplateType(template))
+	}
+
+	content, err := generator.GenerateCombinedMarkdown(prof, templateTypes)
+	if err != nil {
+		return fmt.Errorf("failed to generate markdown: %w", err)
+	}
+
+	filename := profileFilename(config, prof, "combined", "md")
+	filepath := filepath.Join(config.OutputDir, filename)
+
+	if err := os.WriteFile(filepath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write markdown file: %w", err)
+	}
+
+	if config.Verbose {
+		log.Printf("Generated combined markdown profile: %s", filepath)
+	}
+
+	return nil
+}
+
+// writeProfileFiles writes the profile in every format selected by config.Format. The JSON keeps
+// the full profile while the markdown templates render view.
+func writeProfileFiles(prof, view *profile.UserProfile, config Config) error {
+	if writesFormat(config.Format, "json") {
+		if err := saveJSONProfile(prof, config); err != nil {
+			return fmt.Errorf("failed to save JSON profile: %w", err)
+		}
+	}
+
+	if config.SkillsJSON {
+		if err := saveSkillsJSON(prof, config); err != nil {
+			return fmt.Errorf("failed to save skills JSON: %w", err)
+		}
+	}
+
+	if config.Badges {
+		if err := saveBadges(prof, config); err != nil {
+			return fmt.Errorf("failed to save badges: %w", err)
+		}
+	}
+
+	if writesFormat(config.Format, "markdown") {
+		// Determine which templates to generate
+		var templatesToGenerate []string
+		if config.Template == "all" {
+			templatesToGenerate = []string{"resume", "technical", "executive", "ats"}
+		} else {
+			templatesToGenerate = []string{config.Template}
+		}
+		generator := newGenerator(config, view)
+
+		if config.Combined {
+			// Join all templates into a single document
+			if err := generateCombinedMarkdownProfile(generator, view, config, templatesToGenerate); err != nil {
+				return fmt.Errorf("failed to generate combined markdown profile: %w", err)
+			}
+		} else {
+			// Generate each template
+			for _, template := range templatesToGenerate {
+				templateConfig := config
+				templateConfig.Template = template
+				if err := generateMarkdownProfile(generator, view, templateConfig); err != nil {
+					return fmt.Errorf("failed to generate %s markdown profile: %w", template, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // printSummary prints a summary of the analysis
 func printSummary(prof *profile.UserProfile, config Config) {
 	fmt.Printf("\n🎉 Analysis Complete for @%s\n", prof.Username)
@@ -517,23 +1307,8 @@ func printSummary(prof *profile.UserProfile, config Config) {
 	}
 
 	fmt.Printf("\n📁 Output Files:\n")
-
-	if config.Format == "json" || config.Format == "both" {
-		jsonFile := fmt.Sprintf("%s_profile.json", prof.Username)
-		fmt.Printf("   • JSON Data: %s\n", filepath.Join(config.OutputDir, jsonFile))
-	}
-
-	if config.Format == "markdown" || config.Format == "both" {
-		if config.Template == "all" {
-			templates := []string{"resume", "technical", "executive", "ats"}
-			for _, template := range templates {
-				mdFile := fmt.Sprintf("%s_profile_%s.md", prof.Username, template)
-				fmt.Printf("   • Markdown Profile (%s): %s\n", template, filepath.Join(config.OutputDir, mdFile))
-			}
-		} else {
-			mdFile := fmt.Sprintf("%s_profile_%s.md", prof.Username, config.Template)
-			fmt.Printf("   • Markdown Profile: %s\n", filepath.Join(config.OutputDir, mdFile))
-		}
+	for _, file := range outputFiles(prof, config) {
+		fmt.Printf("   • %s\n", file)
 	}
 
 	fmt.Printf("\n✨ Impact Score: %.1f/10\n", prof.Insights.OverallImpactScore*10)
@@ -553,6 +1328,109 @@ func printSummary(prof *profile.UserProfile, config Config) {
 	fmt.Printf("\n🚀 Ready to enhance your resume with GitHub data!\n")
 }
 
+// analysisSummary is the machine-readable counterpart of printSummary
+type analysisSummary struct {
+	Username         string   `json:"username"`
+	Name             string   `json:"name,omitempty"`
+	CareerLevel      string   `json:"career_level"`
+	Years            int      `json:"years"`
+	Repositories     int      `json:"repositories"`
+	OwnedRepos       int      `json:"owned_repositories"`
+	Stars            int      `json:"stars"`
+	PrimaryLanguages []string `json:"primary_languages"`
+	ImpactScore      float64  `json:"impact_score"` // 0-10 scale, as shown in the pretty summary
+	OutputFiles      []string `json:"output_files"`
+}
+
+// printSummaryJSON writes a compact JSON summary of the analysis to w
+func printSummaryJSON(w io.Writer, prof *profile.UserProfile, config Config) error {
+	summary := analysisSummary{
+		Username:         prof.Username,
+		Name:             prof.Name,
+		CareerLevel:      prof.Insights.CareerLevel,
+		Years:            prof.Contributions.ContributionYears,
+		Repositories:     len(prof.Repositories),
+		PrimaryLanguages: prof.Skills.PrimaryLanguages,
+		ImpactScore:      prof.Insights.OverallImpactScore * 10,
+		OutputFiles:      outputFiles(prof, config),
+	}
+
+	for _, repo := range prof.Repositories {
+		summary.Stars += repo.Stars
+		if repo.IsOwner {
+			summary.OwnedRepos++
+		}
+	}
+
+	if len(summary.PrimaryLanguages) > 5 { // Same top 5 as the pretty summary
+		summary.PrimaryLanguages = summary.PrimaryLanguages[:5]
+	}
+	if summary.PrimaryLanguages == nil {
+		summary.PrimaryLanguages = []string{}
+	}
+
+	return json.NewEncoder(w).Encode(summary)
+}
+
+// outputFiles lists the paths of the files written for the configured format and templates
+func outputFiles(prof *profile.UserProfile, config Config) []string {
+	files := []string{}
+
+	if writesFormat(config.Format, "json") {
+		files = append(files, filepath.Join(config.OutputDir, profileFilename(config, prof, "", "json")))
+	}
+
+	if config.SkillsJSON {
+		files = append(files, filepath.Join(config.OutputDir, extraFilename(config, prof, "skills", "json")))
+	}
+
+	if config.Badges {
+		files = append(files, filepath.Join(config.OutputDir, extraFilename(config, prof, "badges", "md")))
+	}
+
+	if writesFormat(config.Format, "markdown") {
+		if config.Combined {
+			files = append(files, filepath.Join(config.OutputDir, profileFilename(config, prof, "combined", "md")))
+		} else if config.Template == "all" {
+			for _, template := range []string{"resume", "technical", "executive", "ats"} {
+				files = append(files, filepath.Join(config.OutputDir, profileFilename(config, prof, template, "md")))
+			}
+		} else {
+			files = append(files, filepath.Join(config.OutputDir, profileFilename(config, prof, config.Template, "md")))
+		}
+	}
+
+	return files
+}
+
+// writesFormat reports whether the -format value produces output of the given kind ("json" or
+// "markdown"); "all" selects every kind this tool can write
+func writesFormat(format, kind string) bool {
+	return format == kind || format == "both" || format == "all"
+}
+
+// validateURLPrefix checks that a link host flag is an http or https URL; empty keeps the public default
+func validateURLPrefix(name, prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if u, err := url.Parse(prefix); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid %s: %q (must be an http or https URL)", name, prefix)
+	}
+	return nil
+}
+
+// parseList splits a comma-separated flag value, dropping blanks; an empty value yields an empty list
+func parseList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -570,7 +1448,7 @@ func showCacheStats(config Config) error {
 		return nil
 	}
 
-	cacheManager, err := profile.NewProfileCacheManager(config.CacheDir, false)
+	cacheManager, err := profile.NewProfileCacheManagerWithNamespace(config.CacheDir, config.CacheNamespace, false)
 	if err != nil {
 		return fmt.Errorf("failed to initialize cache manager: %w", err)
 	}
@@ -589,7 +1467,7 @@ func clearCache(config Config) error {
 		return nil
 	}
 
-	cacheManager, err := profile.NewProfileCacheManager(config.CacheDir, false)
+	cacheManager, err := profile.NewProfileCacheManagerWithNamespace(config.CacheDir, config.CacheNamespace, false)
 	if err != nil {
 		return fmt.Errorf("failed to initialize cache manager: %w", err)
 	}
@@ -603,6 +1481,180 @@ func clearCache(config Config) error {
 	return nil
 }
 
+// listProgress prints saved progress files and exits
+func listProgress(config Config) error {
+	analyzer := profile.NewAnalyzer(config.Token)
+
+	progress, err := analyzer.ListProgress()
+	if err != nil {
+		return err
+	}
+
+	if len(progress) == 0 {
+		fmt.Println("No saved progress files")
+		return nil
+	}
+
+	fmt.Printf("Saved progress (%d):\n", len(progress))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, p := range progress {
+		fmt.Printf("   • %s: step %d completed, saved %s ago (%s)\n",
+			p.Username, p.LastStep, p.Age().Round(time.Minute), p.Path)
+	}
+
+	return nil
+}
+
+// clearProgress removes saved progress files for a user (or all users) and exits
+func clearProgress(config Config) error {
+	analyzer := profile.NewAnalyzer(config.Token)
+
+	removed, err := analyzer.ClearProgress(config.ClearProgress)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Removed %d progress file(s) for %s\n", removed, config.ClearProgress)
+	return nil
+}
+
+// validateProfile writes a completeness checklist of the profile JSON at path to w, and returns an
+// error when any section is missing so that the exit status reflects completeness
+func validateProfile(w io.Writer, path string) error {
+	prof, err := profile.LoadProfile(path)
+	if err != nil {
+		return err
+	}
+
+	checks := profile.CheckCompleteness(prof)
+	missing := 0
+
+	fmt.Fprintf(w, "\n🔎 Completeness of %s (@%s)\n", path, prof.Username)
+	fmt.Fprintln(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, check := range checks {
+		if check.Complete {
+			fmt.Fprintf(w, "   ✅ %s\n", check.Section)
+			continue
+		}
+		missing++
+		fmt.Fprintf(w, "   ❌ %s: %s\n", check.Section, check.Hint)
+	}
+
+	if missing > 0 {
+		return fmt.Errorf("profile is incomplete: %d of %d sections missing", missing, len(checks))
+	}
+	fmt.Fprintln(w, "\n✅ Profile is complete")
+	return nil
+}
+
+// runDiff reports the changes between the cached analysis and the previous one, as markdown and/or JSON
+func runDiff(config Config) error {
+	analyzer := profile.NewAnalyzer(config.Token)
+
+	diff, err := analyzer.Diff(config.Username)
+	if err != nil {
+		if errors.Is(err, profile.ErrNoPriorSnapshot) {
+			fmt.Fprintf(os.Stderr, "ℹ️  Nothing to compare yet: analyze %s again later to track changes\n", config.Username)
+			return nil
+		}
+		return fmt.Errorf("failed to compare analyses: %w", err)
+	}
+
+	generator := markdown.NewGenerator()
+	if err := generator.SetLanguage(config.Lang); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff to JSON: %w", err)
+	}
+
+	if config.Stdout {
+		if config.Format == "json" {
+			_, err = fmt.Printf("%s\n", data)
+			return err
+		}
+		_, err = io.WriteString(os.Stdout, generator.GenerateDiffMarkdown(diff))
+		return err
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var files []string
+	if writesFormat(config.Format, "json") {
+		path := filepath.Join(config.OutputDir, fmt.Sprintf("%s_diff.json", config.Username))
+		if err := profile.WriteFileAtomic(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write JSON diff: %w", err)
+		}
+		files = append(files, path)
+	}
+	if writesFormat(config.Format, "markdown") {
+		path := filepath.Join(config.OutputDir, fmt.Sprintf("%s_diff.md", config.Username))
+		if err := os.WriteFile(path, []byte(generator.GenerateDiffMarkdown(diff)), 0644); err != nil {
+			return fmt.Errorf("failed to write markdown diff: %w", err)
+		}
+		files = append(files, path)
+	}
+
+	fmt.Printf("\n📈 Changes for @%s since %s\n", diff.Username, diff.From.Format("2006-01-02"))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("   • New repositories: %d\n", len(diff.NewRepositories))
+	fmt.Printf("   • Stars: %d → %d\n", diff.StarsBefore, diff.StarsAfter)
+	fmt.Printf("   • Commits: %+d\n", diff.Contributions.Commits)
+	if diff.CareerLevelChanged() {
+		fmt.Printf("   • Career level: %s → %s\n", diff.CareerLevelBefore, diff.CareerLevelAfter)
+	}
+	fmt.Printf("\n📁 Output Files:\n")
+	for _, file := range files {
+		fmt.Printf("   • %s\n", file)
+	}
+
+	return nil
+}
+
+// dryRun prints the estimated GitHub API usage of an analysis against the remaining quota and exits
+func dryRun(ctx context.Context, config Config, analyzer *profile.Analyzer) error {
+	estimate, err := analyzer.EstimateCost(ctx, config.Username)
+	if err != nil {
+		return fmt.Errorf("failed to estimate analysis cost: %w", err)
+	}
+	rateLimit := analyzer.GetGitHubRateLimitStatus()
+
+	fmt.Printf("Dry run for %s (repository count from %s):\n", config.Username, estimate.RepositorySource)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("   • Repositories: %d (%d pages)\n", estimate.Repositories, estimate.RepositoryPages)
+	fmt.Printf("   • Contribution queries: %d\n", estimate.ContributionQueries)
+	if config.WithLOC {
+		fmt.Printf("   • Line statistics queries: %d (at least)\n", estimate.LineStatsQueries)
+	}
+	if config.WithContributors {
+		fmt.Printf("   • Contributor queries: %d (at most)\n", estimate.ContributorQueries)
+	}
+	if config.WithReviews {
+		fmt.Printf("   • Review queries: %d\n", estimate.ReviewQueries)
+	}
+	fmt.Printf("   • Docker content calls: %d\n", estimate.DockerContentCalls)
+	if config.WithSecurityScan {
+		fmt.Printf("   • Security scan calls: %d (at most)\n", estimate.SecurityScanCalls)
+	}
+	fmt.Printf("   • Total: %d GraphQL queries, %d REST calls\n", estimate.GraphQLQueries(), estimate.RESTCalls())
+
+	if rateLimit.Updated {
+		fmt.Printf("   • Remaining %s quota: %d/%d (resets at %s)\n",
+			rateLimit.Resource, rateLimit.Remaining, rateLimit.Limit, rateLimit.ResetTime.Format("15:04:05"))
+		if estimate.GraphQLQueries() > rateLimit.Remaining {
+			fmt.Println("⚠️  The estimate exceeds the remaining quota; the analysis will wait for the rate limit to reset")
+		}
+	} else {
+		fmt.Printf("   • Remaining quota: unknown until the first API request (limit %d/hour)\n", rateLimit.Limit)
+	}
+
+	return nil
+}
+
 // runAnalysisWithCache performs analysis using the cache-aware analyzer
 func runAnalysisWithCache(ctx context.Context, config Config, cacheAnalyzer *profile.CacheAwareAnalyzer) error {
 	// Analyze user profile with caching
@@ -614,46 +1666,70 @@ func runAnalysisWithCache(ctx context.Context, config Config, cacheAnalyzer *pro
 		log.Printf("Using Discourse username: %s", config.DiscourseUsername)
 	}
 	prof, err := cacheAnalyzer.AnalyzeUserWithCustomUsernames(ctx, config.Username, config.DockerUsername, config.DiscourseUsername)
+	// An interrupted analysis still writes what it gathered, flagged as partial
+	var partialErr error
+	if errors.Is(err, profile.ErrPartialAnalysis) && prof != nil {
+		log.Printf("Warning: %v; writing partial outputs", err)
+		partialErr = fmt.Errorf("outputs for %s are partial: %w", config.Username, err)
+		err = nil
+	}
 	if err != nil {
+		// A mistyped username is not a failure of the tool
+		if errors.Is(err, github.ErrUserNotFound) {
+			fmt.Fprintf(os.Stderr, "❌ No such GitHub user: %s\n", config.Username)
+			return nil
+		}
 		return fmt.Errorf("failed to analyze user %s: %w", config.Username, err)
 	}
 
-	// Create output directory
-	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+	// Also covers profiles loaded from a cache written before -anonymize was used
+	if config.Anonymize {
+		profile.Anonymize(prof)
 	}
 
-	// Generate outputs based on format
-	if config.Format == "json" || config.Format == "both" {
-		if err := saveJSONProfile(prof, config); err != nil {
-			return fmt.Errorf("failed to save JSON profile: %w", err)
-		}
+	if config.EnrichDescriptions && partialErr == nil {
+		cacheAnalyzer.EnrichDescriptions(ctx, prof, profile.DefaultEnrichLimit)
 	}
 
-	if config.Format == "markdown" || config.Format == "both" {
-		// Determine which templates to generate
-		var templatesToGenerate []string
-		if config.Template == "all" {
-			templatesToGenerate = []string{"resume", "technical", "executive", "ats"}
-		} else {
-			templatesToGenerate = []string{config.Template}
-		}
+	// Stale repositories are left out of the templates but kept in the JSON profile
+	view := prof
+	if config.ActiveSince != "" {
+		age, _ := parseAge(config.ActiveSince) // already validated
+		view = cacheAnalyzer.ActiveView(prof, time.Now().Add(-age))
+	}
 
-		// Generate each template
-		for _, template := range templatesToGenerate {
-			templateConfig := config
-			templateConfig.Template = template
-			if err := generateMarkdownProfile(prof, templateConfig); err != nil {
-				return fmt.Errorf("failed to generate %s markdown profile: %w", template, err)
-			}
+	// Nothing but the document goes to stdout so it can be piped
+	if config.Stdout {
+		doc := view
+		if config.Format == "json" {
+			doc = prof
+		}
+		if err := writeStdout(os.Stdout, doc, config); err != nil {
+			return err
 		}
+		return partialErr
+	}
+
+	// Create output directory
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := writeProfileFiles(prof, view, config); err != nil {
+		return err
 	}
 
 	// Print summary
-	printSummary(prof, config)
+	if config.SummaryJSON {
+		if err := printSummaryJSON(os.Stdout, prof, config); err != nil {
+			return fmt.Errorf("failed to print JSON summary: %w", err)
+		}
+	} else {
+		printSummary(prof, config)
+	}
 
-	// Print cache statistics if verbose
-	if config.Verbose {
+	// Print cache statistics if verbose (stdout is reserved for the JSON summary otherwise)
+	if config.Verbose && !config.SummaryJSON {
 		fmt.Printf("\n📊 Cache Performance:\n")
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 		cacheManager := cacheAnalyzer.GetCacheManager()
@@ -673,6 +1749,65 @@ func runAnalysisWithCache(ctx context.Context, config Config, cacheAnalyzer *pro
 		log.Printf("  Usage: %.1f%% of hourly quota", percentUsed)
 	}
 
+	return partialErr
+}
+
+// runOrganizationAnalysis analyzes the public repositories of config.Org and writes the organization template
+func runOrganizationAnalysis(ctx context.Context, config Config, analyzer *profile.Analyzer) error {
+	// A single template is written, so there is nothing to combine
+	config.Template = string(markdown.OrganizationTemplate)
+	config.Combined = false
+
+	log.Printf("Analyzing GitHub organization: %s", config.Org)
+	prof, err := analyzer.AnalyzeOrganization(ctx, config.Org)
+	if err != nil {
+		if errors.Is(err, github.ErrOrganizationNotFound) {
+			fmt.Fprintf(os.Stderr, "❌ No such GitHub organization: %s\n", config.Org)
+			return nil
+		}
+		return fmt.Errorf("failed to analyze organization %s: %w", config.Org, err)
+	}
+
+	if config.EnrichDescriptions {
+		analyzer.EnrichDescriptions(ctx, prof, profile.DefaultEnrichLimit)
+	}
+
+	if config.Stdout {
+		return writeStdout(os.Stdout, prof, config)
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if writesFormat(config.Format, "json") {
+		if err := saveJSONProfile(prof, config); err != nil {
+			return fmt.Errorf("failed to save JSON profile: %w", err)
+		}
+	}
+	if writesFormat(config.Format, "markdown") {
+		if err := generateMarkdownProfile(newGenerator(config, prof), prof, config); err != nil {
+			return fmt.Errorf("failed to generate organization markdown profile: %w", err)
+		}
+	}
+
+	if config.SummaryJSON {
+		return printSummaryJSON(os.Stdout, prof, config)
+	}
+
+	fmt.Printf("\n🏢 Organization Analysis for %s\n", prof.Username)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("   • Public Repositories: %d\n", len(prof.Repositories))
+	fmt.Printf("   • Members: %d\n", prof.MemberCount)
+	if len(prof.Skills.PrimaryLanguages) > 0 {
+		fmt.Printf("   • Top Languages: %s\n", strings.Join(prof.Skills.PrimaryLanguages, ", "))
+	}
+
+	fmt.Printf("\n📁 Output Files:\n")
+	for _, file := range outputFiles(prof, config) {
+		fmt.Printf("   • %s\n", file)
+	}
+
 	return nil
 }
 
@@ -755,7 +1890,7 @@ func runDockerOnlyAnalysis(ctx context.Context, config Config) error {
 	}
 
 	// Generate markdown files for all templates
-	generator := markdown.NewGenerator()
+	generator := newGenerator(config, userProfile)
 	templates := []string{"resume", "technical", "executive", "ats"}
 
 	fmt.Printf("\n📁 Output Files:\n")
@@ -799,7 +1934,7 @@ func runDockerOnlyAnalysis(ctx context.Context, config Config) error {
 	}
 
 	// Save JSON if requested
-	if config.Format == "json" || config.Format == "both" {
+	if writesFormat(config.Format, "json") {
 		filename := fmt.Sprintf("%s_docker_profile.json", config.DockerUsername)
 		filepath := filepath.Join(config.OutputDir, filename)
 
@@ -808,7 +1943,7 @@ func runDockerOnlyAnalysis(ctx context.Context, config Config) error {
 			return fmt.Errorf("failed to marshal profile to JSON: %w", err)
 		}
 
-		if err := os.WriteFile(filepath, data, 0644); err != nil {
+		if err := profile.WriteFileAtomic(filepath, data, 0644); err != nil {
 			return fmt.Errorf("failed to write JSON file: %w", err)
 		}
 
@@ -0,0 +1,505 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/profile"
+)
+
+// captureStdout runs fn and returns everything it wrote to os.Stdout
+func captureStdout(t *testing.T, fn func()) []byte {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+
+	original := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = original }()
+
+	fn()
+	w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read captured stdout: %v", err)
+	}
+	return out
+}
+
+// TestPrintSummaryJSON verifies the JSON summary printed to stdout can be unmarshaled
+func TestPrintSummaryJSON(t *testing.T) {
+	prof := &profile.UserProfile{
+		Username: "octocat",
+		Name:     "The Octocat",
+		Repositories: []profile.RepositoryProfile{
+			{Name: "hello-world", Stars: 10, IsOwner: true},
+			{Name: "spoon-knife", Stars: 5},
+		},
+		Contributions: profile.ContributionSummary{ContributionYears: 7},
+		Skills:        profile.SkillProfile{PrimaryLanguages: []string{"Go", "Ruby"}},
+		Insights:      profile.UserInsights{CareerLevel: "senior", OverallImpactScore: 0.65},
+	}
+	config := Config{OutputDir: "out", Format: "both", Template: "resume"}
+
+	out := captureStdout(t, func() {
+		if err := printSummaryJSON(os.Stdout, prof, config); err != nil {
+			t.Errorf("printSummaryJSON failed: %v", err)
+		}
+	})
+
+	var summary analysisSummary
+	if err := json.Unmarshal(out, &summary); err != nil {
+		t.Fatalf("Failed to unmarshal summary %q: %v", out, err)
+	}
+
+	if summary.Username != "octocat" || summary.CareerLevel != "senior" || summary.Years != 7 {
+		t.Errorf("Unexpected identity fields: %+v", summary)
+	}
+	if summary.Repositories != 2 || summary.OwnedRepos != 1 || summary.Stars != 15 {
+		t.Errorf("Unexpected repository counts: %+v", summary)
+	}
+	if len(summary.PrimaryLanguages) != 2 || summary.PrimaryLanguages[0] != "Go" {
+		t.Errorf("Unexpected primary languages: %v", summary.PrimaryLanguages)
+	}
+	if summary.ImpactScore != 6.5 {
+		t.Errorf("Expected impact score 6.5, got %.2f", summary.ImpactScore)
+	}
+
+	expectedFiles := []string{
+		filepath.Join("out", "octocat_profile.json"),
+		filepath.Join("out", "octocat_profile_resume.md"),
+	}
+	if len(summary.OutputFiles) != len(expectedFiles) {
+		t.Fatalf("Expected output files %v, got %v", expectedFiles, summary.OutputFiles)
+	}
+	for i, file := range expectedFiles {
+		if summary.OutputFiles[i] != file {
+			t.Errorf("Expected output file %s, got %s", file, summary.OutputFiles[i])
+		}
+	}
+}
+
+// TestGenerateMarkdownProfileWithAvatar verifies -with-avatar embeds the fetched avatar, downloaded
+// once for all templates, and that a failed fetch only omits the image
+func TestGenerateMarkdownProfileWithAvatar(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		if r.URL.Path != "/octocat.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("avatar"))
+	}))
+	defer server.Close()
+
+	outputDir := t.TempDir()
+	config := Config{OutputDir: outputDir, Template: "resume", Lang: "en", WithAvatar: true}
+	prof := &profile.UserProfile{Username: "octocat", AvatarURL: server.URL + "/octocat.png"}
+
+	readResume := func() string {
+		t.Helper()
+		if err := generateMarkdownProfile(newGenerator(config, prof), prof, config); err != nil {
+			t.Fatalf("generateMarkdownProfile failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(outputDir, "octocat_profile_resume.md"))
+		if err != nil {
+			t.Fatalf("Failed to read resume: %v", err)
+		}
+		return string(content)
+	}
+
+	if content := readResume(); !strings.Contains(content, "data:image/png;base64,YXZhdGFy") {
+		t.Errorf("Expected the avatar data URI in the resume:\n%s", content)
+	}
+
+	atomic.StoreInt32(&fetches, 0)
+	all := config
+	all.Template, all.Format, all.FilenameTemplate = "all", "markdown", defaultFilenameTemplate
+	if err := writeProfileFiles(prof, prof, all); err != nil {
+		t.Fatalf("writeProfileFiles failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("Expected one avatar download for all templates, got %d", n)
+	}
+
+	prof.AvatarURL = server.URL + "/missing.png"
+	if content := readResume(); strings.Contains(content, "<img") {
+		t.Errorf("Expected no avatar after a failed fetch:\n%s", content)
+	}
+}
+
+// TestWriteStdout verifies -stdout prints only the selected document, with logs kept off stdout
+func TestWriteStdout(t *testing.T) {
+	prof := &profile.UserProfile{
+		Username:     "octocat",
+		Repositories: []profile.RepositoryProfile{{Name: "hello-world", Stars: 10, IsOwner: true}},
+		Insights:     profile.UserInsights{CareerLevel: "senior"},
+	}
+
+	out := captureStdout(t, func() {
+		config := Config{Template: "resume", Format: "both", Lang: "en"}
+		if err := writeStdout(os.Stdout, prof, config); err != nil {
+			t.Errorf("writeStdout failed: %v", err)
+		}
+	})
+	content := string(out)
+
+	if !strings.HasPrefix(content, "# GitHub Professional Profile - octocat\n") {
+		t.Errorf("Expected the markdown document to start with its title, got %q", content[:min(len(content), 80)])
+	}
+	for _, noise := range []string{"Checking for Docker", "Analysis Complete", "Output Files"} {
+		if strings.Contains(content, noise) {
+			t.Errorf("Expected no %q on stdout", noise)
+		}
+	}
+
+	out = captureStdout(t, func() {
+		config := Config{Template: "resume", Format: "json", Lang: "en"}
+		if err := writeStdout(os.Stdout, prof, config); err != nil {
+			t.Errorf("writeStdout failed: %v", err)
+		}
+	})
+	var decoded profile.UserProfile
+	if err := json.Unmarshal(out, &decoded); err != nil || decoded.Username != "octocat" {
+		t.Errorf("Expected the JSON profile on stdout, got %q (%v)", out, err)
+	}
+}
+
+// TestValidateConfigStdout verifies -stdout requires a single template
+func TestValidateConfigStdout(t *testing.T) {
+	config := Config{Username: "octocat", Token: "test-token", Template: "all", Format: "both", Lang: "en", Stdout: true}
+	if err := validateConfig(config); err == nil || !strings.Contains(err.Error(), "-template") {
+		t.Errorf("Expected -stdout with all templates to be rejected, got %v", err)
+	}
+}
+
+// TestValidateConfigOrg verifies -org replaces -user and only renders the organization template
+func TestValidateConfigOrg(t *testing.T) {
+	base := Config{Token: "test-token", Template: "all", Format: "both", Lang: "en", GroupBy: "language",
+		GitHubRPS: 1, RequestTimeout: time.Minute, RepoPageSize: 50, ProgressMaxAge: time.Hour, AnalysisMaxAge: time.Hour}
+
+	config := base
+	config.Org = "acme"
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected -org without -user to be valid, got %v", err)
+	}
+
+	config.Username = "octocat"
+	if err := validateConfig(config); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("Expected -org with -user to be rejected, got %v", err)
+	}
+
+	config = base
+	config.Org = "acme"
+	config.Template = "resume"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected -org with a user template to be rejected")
+	}
+
+	config = base
+	config.Username = "octocat"
+	config.Template = "organization"
+	if err := validateConfig(config); err == nil || !strings.Contains(err.Error(), "-org") {
+		t.Errorf("Expected the organization template without -org to be rejected, got %v", err)
+	}
+}
+
+// TestParseAge verifies day, week and year units are accepted alongside Go durations
+func TestParseAge(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"2y":   2 * 365 * 24 * time.Hour,
+		"18w":  18 * 7 * 24 * time.Hour,
+		"90d":  90 * 24 * time.Hour,
+		"1.5y": 547*24*time.Hour + 12*time.Hour,
+		"720h": 720 * time.Hour,
+	} {
+		if age, err := parseAge(value); err != nil || age != expected {
+			t.Errorf("parseAge(%q) = %v, %v; expected %v", value, age, err, expected)
+		}
+	}
+	for _, value := range []string{"y", "two years", "-2y", "0d"} {
+		if _, err := parseAge(value); err == nil {
+			t.Errorf("Expected parseAge(%q) to fail", value)
+		}
+	}
+}
+
+// TestGenerateMarkdownProfilePartial verifies a profile from an interrupted analysis is still written,
+// with the PARTIAL banner
+func TestGenerateMarkdownProfilePartial(t *testing.T) {
+	outputDir := t.TempDir()
+	config := Config{OutputDir: outputDir, Template: "resume", Lang: "en"}
+	prof := &profile.UserProfile{
+		Username:     "octocat",
+		Partial:      true,
+		Repositories: []profile.RepositoryProfile{{Name: "hello-world", Stars: 10, IsOwner: true}},
+	}
+
+	if err := generateMarkdownProfile(newGenerator(config, prof), prof, config); err != nil {
+		t.Fatalf("generateMarkdownProfile failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "octocat_profile_resume.md"))
+	if err != nil {
+		t.Fatalf("Expected the resume to be written: %v", err)
+	}
+	if !strings.Contains(string(content), "PARTIAL") || !strings.Contains(string(content), "hello-world") {
+		t.Errorf("Expected the partial data under a PARTIAL banner:\n%s", content)
+	}
+}
+
+// TestFormatAll verifies -format all writes the JSON profile and the markdown template in one pass
+func TestFormatAll(t *testing.T) {
+	outputDir := t.TempDir()
+	config := Config{OutputDir: outputDir, Format: "all", Template: "resume", Lang: "en", FilenameTemplate: defaultFilenameTemplate}
+	prof := &profile.UserProfile{
+		Username:     "octocat",
+		Repositories: []profile.RepositoryProfile{{Name: "hello-world", Stars: 10, IsOwner: true}},
+	}
+
+	valid := Config{Username: "octocat", Token: "test-token", Template: "resume", Format: "all", Lang: "en", GroupBy: "language",
+		GitHubRPS: 1, RequestTimeout: time.Minute, RepoPageSize: 50, ProgressMaxAge: time.Hour, AnalysisMaxAge: time.Hour,
+		FilenameTemplate: defaultFilenameTemplate}
+	if err := validateConfig(valid); err != nil {
+		t.Fatalf("Expected -format all to be accepted: %v", err)
+	}
+	if err := writeProfileFiles(prof, prof, config); err != nil {
+		t.Fatalf("writeProfileFiles failed: %v", err)
+	}
+
+	files := outputFiles(prof, config)
+	extensions := map[string]bool{}
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("Expected %s to be written: %v", file, err)
+		}
+		extensions[filepath.Ext(file)] = true
+	}
+	if !extensions[".json"] || !extensions[".md"] || len(files) != 2 {
+		t.Errorf("Expected one JSON and one markdown file, got %v", files)
+	}
+}
+
+// TestLoadEnvFile verifies a specified env file's GITHUB_TOKEN is loaded and a missing one fails
+func TestLoadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profile.env")
+	if err := os.WriteFile(path, []byte("GITHUB_TOKEN=from-env-file\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	t.Setenv("ENV_FILE", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	os.Unsetenv("GITHUB_TOKEN")
+
+	if got := envFileFromArgs([]string{"-user", "octocat", "-env-file", path}); got != path {
+		t.Errorf("Expected -env-file %s, got %q", path, got)
+	}
+	if got := envFileFromArgs([]string{"--env-file=" + path}); got != path {
+		t.Errorf("Expected --env-file= %s, got %q", path, got)
+	}
+
+	if err := loadEnvFile(path); err != nil {
+		t.Fatalf("loadEnvFile failed: %v", err)
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "from-env-file" {
+		t.Errorf("Expected GITHUB_TOKEN from the env file, got %q", token)
+	}
+
+	if err := loadEnvFile(filepath.Join(t.TempDir(), "missing.env")); err == nil {
+		t.Error("Expected an error for a missing env file")
+	}
+}
+
+// TestSaveSkillsJSON verifies -skills-json writes the skills taxonomy as a separate file
+func TestSaveSkillsJSON(t *testing.T) {
+	prof := &profile.UserProfile{
+		Username: "octocat",
+		Skills: profile.SkillProfile{
+			PrimaryLanguages: []string{"Go", "Java"},
+			Frameworks:       []profile.TechnologySkill{{Name: "Spring", Confidence: 0.7, Evidence: []string{"octocat/app"}}},
+			TechnicalAreas: []profile.TechnicalArea{
+				{Area: "backend development", Competency: 0.8, Technologies: []string{"Go", "Spring"}},
+			},
+		},
+	}
+	config := Config{OutputDir: t.TempDir(), SkillsJSON: true}
+
+	if err := saveSkillsJSON(prof, config); err != nil {
+		t.Fatalf("saveSkillsJSON failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(config.OutputDir, "octocat_skills.json"))
+	if err != nil {
+		t.Fatalf("Failed to read skills JSON: %v", err)
+	}
+
+	var skills profile.SkillProfile
+	if err := json.Unmarshal(data, &skills); err != nil {
+		t.Fatalf("Skills JSON does not unmarshal: %v\n%s", err, data)
+	}
+	if len(skills.PrimaryLanguages) != 2 || skills.PrimaryLanguages[0] != "Go" {
+		t.Errorf("Expected primary languages [Go Java], got %v", skills.PrimaryLanguages)
+	}
+	if len(skills.TechnicalAreas) == 0 || skills.TechnicalAreas[0].Area != "backend development" {
+		t.Errorf("Expected the backend development technical area, got %+v", skills.TechnicalAreas)
+	}
+	if len(skills.Frameworks) != 1 || skills.Frameworks[0].Evidence[0] != "octocat/app" {
+		t.Errorf("Expected framework evidence to be kept, got %+v", skills.Frameworks)
+	}
+
+	config.FilenameTemplate = "{{.Date}}-{{.Username}}-{{.Template}}.{{.Format}}"
+	prof.LastAnalyzed = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := saveSkillsJSON(prof, config); err != nil {
+		t.Fatalf("saveSkillsJSON failed: %v", err)
+	}
+	expected := filepath.Join(config.OutputDir, "2025-03-01-octocat-skills.json")
+	if _, err := os.Stat(expected); err != nil {
+		t.Errorf("Expected -filename-template to name the skills file: %v", err)
+	}
+	if files := outputFiles(prof, config); !contains(files, expected) {
+		t.Errorf("Expected %s among the output files, got %v", expected, files)
+	}
+}
+
+// TestOutputPerUser verifies -output-per-user writes each user's files into their own subdirectory
+// while the default layout stays flat
+func TestOutputPerUser(t *testing.T) {
+	base := t.TempDir()
+
+	for _, username := range []string{"alice", "bob"} {
+		config := Config{OutputDir: base, OutputPerUser: true, Username: username}
+		config.OutputDir = outputDir(config)
+		if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+			t.Fatalf("Failed to create output directory: %v", err)
+		}
+		if err := saveJSONProfile(&profile.UserProfile{Username: username}, config); err != nil {
+			t.Fatalf("saveJSONProfile(%s) failed: %v", username, err)
+		}
+	}
+
+	for _, username := range []string{"alice", "bob"} {
+		if _, err := os.Stat(filepath.Join(base, username, username+"_profile.json")); err != nil {
+			t.Errorf("Expected %s's profile in its own subdirectory: %v", username, err)
+		}
+	}
+
+	if dir := outputDir(Config{OutputDir: base, Username: "alice"}); dir != base {
+		t.Errorf("Expected the flat layout by default, got %s", dir)
+	}
+	if dir := outputDir(Config{OutputDir: base, OutputPerUser: true, Org: "jenkinsci"}); dir != filepath.Join(base, "jenkinsci") {
+		t.Errorf("Expected organizations to get their own subdirectory, got %s", dir)
+	}
+}
+
+// TestValidateProfile verifies -validate prints a checklist and fails for an incomplete profile
+func TestValidateProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "octocat_profile.json")
+	if err := os.WriteFile(path, []byte(`{"username": "octocat"}`), 0644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+
+	var out strings.Builder
+	err := validateProfile(&out, path)
+	if err == nil || !strings.Contains(err.Error(), "8 of 9 sections missing") {
+		t.Errorf("Expected an error counting 8 missing sections, got %v", err)
+	}
+	for _, expected := range []string{"✅ Analysis finished", "❌ Languages:", "❌ Docker Hub profile: re-run with -docker-user"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected %q in the checklist:\n%s", expected, out.String())
+		}
+	}
+}
+
+// TestFilenameTemplate verifies a custom -filename-template names files with the analysis date,
+// the default keeps the historical names and path separators cannot escape the output directory
+func TestFilenameTemplate(t *testing.T) {
+	prof := &profile.UserProfile{Username: "octocat", LastAnalyzed: time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)}
+
+	config := Config{FilenameTemplate: "{{.Date}}/{{.Username}}-{{.Template}}.{{.Format}}"}
+	if name := profileFilename(config, prof, "resume", "md"); name != "2024-03-05_octocat-resume.md" {
+		t.Errorf("Expected 2024-03-05_octocat-resume.md, got %s", name)
+	}
+
+	config.FilenameTemplate = defaultFilenameTemplate
+	if name := profileFilename(config, prof, "", "json"); name != "octocat_profile.json" {
+		t.Errorf("Expected the default JSON name, got %s", name)
+	}
+	if name := profileFilename(config, prof, "technical", "md"); name != "octocat_profile_technical.md" {
+		t.Errorf("Expected the default markdown name, got %s", name)
+	}
+
+	if name := profileFilename(Config{FilenameTemplate: "../../{{.Username}}"}, prof, "", "json"); strings.ContainsAny(name, `/\`) {
+		t.Errorf("Expected path separators to be replaced, got %s", name)
+	}
+	if _, err := renderFilename("..", filenameData{}); err == nil {
+		t.Error("Expected a template rendering to .. to be rejected")
+	}
+	if err := validateConfig(Config{Username: "octocat", Token: "t", Template: "all", Format: "both", FilenameTemplate: "{{.Unknown}}"}); err == nil ||
+		!strings.Contains(err.Error(), "-filename-template") {
+		t.Errorf("Expected an invalid -filename-template error, got %v", err)
+	}
+}
+
+// TestWithInterrupt verifies SIGINT cancels the analysis context instead of killing the process
+func TestWithInterrupt(t *testing.T) {
+	ctx, stop := withInterrupt(context.Background())
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("Failed to find own process: %v", err)
+	}
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Skipf("Sending SIGINT is not supported on this platform: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected SIGINT to cancel the context")
+	}
+}
+
+// TestSaveBadges verifies -badges writes the profile README badges next to the other outputs
+func TestSaveBadges(t *testing.T) {
+	prof := &profile.UserProfile{Username: "octocat", Skills: profile.SkillProfile{PrimaryLanguages: []string{"Go"}}}
+	config := Config{OutputDir: t.TempDir(), Badges: true}
+
+	if err := saveBadges(prof, config); err != nil {
+		t.Fatalf("saveBadges failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(config.OutputDir, "octocat_badges.md"))
+	if err != nil {
+		t.Fatalf("Failed to read badges: %v", err)
+	}
+	if !strings.Contains(string(data), "https://img.shields.io/badge/top%20language-Go-") {
+		t.Errorf("Expected a Go language badge, got:\n%s", data)
+	}
+
+	config.FilenameTemplate = "{{.Username}}-{{.Template}}.{{.Format}}"
+	if err := saveBadges(prof, config); err != nil {
+		t.Fatalf("saveBadges failed: %v", err)
+	}
+	expected := filepath.Join(config.OutputDir, "octocat-badges.md")
+	if _, err := os.Stat(expected); err != nil {
+		t.Errorf("Expected -filename-template to name the badges file: %v", err)
+	}
+	if files := outputFiles(prof, config); !contains(files, expected) {
+		t.Errorf("Expected %s among the output files, got %v", expected, files)
+	}
+}
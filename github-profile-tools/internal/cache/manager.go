@@ -3,14 +3,19 @@ package cache
 import (
 	"fmt"
 	"log"
+	"sync"
 	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/metrics"
 )
 
 // Manager provides high-level cache operations and coordination
 type Manager struct {
-	storage   *FileStorage
+	storage   Storage
 	config    *CacheConfig
 	isEnabled bool
+	stop      chan struct{} // closed by Close to end the cleanup routine
+	closeOnce sync.Once
 }
 
 // NewManager creates a new cache manager with the specified configuration
@@ -30,7 +35,7 @@ func NewManager(config *CacheConfig) (*Manager, error) {
 		config.Version = "1.0"
 	}
 
-	storage, err := NewFileStorage(config)
+	storage, err := NewStorage(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cache storage: %w", err)
 	}
@@ -39,6 +44,7 @@ func NewManager(config *CacheConfig) (*Manager, error) {
 		storage:   storage,
 		config:    config,
 		isEnabled: true,
+		stop:      make(chan struct{}),
 	}
 
 	// Start background cleanup routine
@@ -53,7 +59,15 @@ func (m *Manager) Get(key CacheKey) (*CacheResult, error) {
 		return &CacheResult{Hit: false, Key: key.String()}, nil
 	}
 
-	return m.storage.Get(key.String())
+	result, err := m.storage.Get(key.String())
+	if err == nil {
+		if result.Hit {
+			metrics.EntryCacheHits.Inc()
+		} else {
+			metrics.EntryCacheMisses.Inc()
+		}
+	}
+	return result, err
 }
 
 // Set stores data in cache with the specified key and TTL
@@ -83,7 +97,7 @@ func (m *Manager) InvalidateUser(username string) error {
 	log.Printf("Invalidating all cache entries for user: %s", username)
 
 	// Define all possible cache types for a user
-	cacheTypes := []string{"profile", "repositories", "organizations", "contributions", "languages", "skills"}
+	cacheTypes := []string{"profile", "repositories", "organizations", "contributions", "languages", "skills", "dockerhub"}
 
 	var lastError error
 	for _, cacheType := range cacheTypes {
@@ -168,6 +182,14 @@ func (m *Manager) GetUserSkillsKey(username string) CacheKey {
 	}
 }
 
+// GetDockerHubProfileKey creates a cache key for a Docker Hub user's analysis
+func (m *Manager) GetDockerHubProfileKey(dockerUsername string) CacheKey {
+	return CacheKey{
+		Type:     "dockerhub",
+		Username: dockerUsername,
+	}
+}
+
 // Clear removes all cache entries
 func (m *Manager) Clear() error {
 	if !m.isEnabled {
@@ -239,13 +261,24 @@ func (m *Manager) cleanupRoutine() {
 	ticker := time.NewTicker(m.config.CleanupInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		if m.isEnabled {
-			m.Cleanup()
+	for {
+		select {
+		case <-ticker.C:
+			if m.isEnabled {
+				m.Cleanup()
+			}
+		case <-m.stop:
+			return
 		}
 	}
 }
 
+// Close stops the background cleanup and waits for the storage's background writes
+func (m *Manager) Close() error {
+	m.closeOnce.Do(func() { close(m.stop) })
+	return m.storage.Close()
+}
+
 // ValidateIntegrity checks cache integrity and repairs if needed
 func (m *Manager) ValidateIntegrity() error {
 	// This could be expanded to include checksum validation,
@@ -258,7 +291,7 @@ func (m *Manager) GetCacheInfo(username string) map[string]interface{} {
 	info := make(map[string]interface{})
 
 	// Check each cache type for the user
-	cacheTypes := []string{"profile", "repositories", "organizations", "contributions", "languages", "skills"}
+	cacheTypes := []string{"profile", "repositories", "organizations", "contributions", "languages", "skills", "dockerhub"}
 
 	for _, cacheType := range cacheTypes {
 		key := CacheKey{
@@ -37,10 +37,19 @@ func setupTestManager(t *testing.T) (*Manager, string) {
 	return manager, tempDir
 }
 
+// cleanupTestManager closes the manager, waiting for its background writes, and removes its directory
+func cleanupTestManager(t *testing.T, manager *Manager, tempDir string) {
+	t.Helper()
+	if err := manager.Close(); err != nil {
+		t.Errorf("Failed to close manager: %v", err)
+	}
+	cleanupTestStorage(t, tempDir)
+}
+
 // TestManagerBasicOperations tests basic Get/Set/Delete operations
 func TestManagerBasicOperations(t *testing.T) {
 	manager, tempDir := setupTestManager(t)
-	defer cleanupTestStorage(t, tempDir)
+	defer cleanupTestManager(t, manager, tempDir)
 
 	// Test data
 	testKey := CacheKey{Type: "test", Username: "user1"}
@@ -93,7 +102,7 @@ func TestManagerBasicOperations(t *testing.T) {
 // TestKeyGeneration tests cache key generation for different types
 func TestKeyGeneration(t *testing.T) {
 	manager, tempDir := setupTestManager(t)
-	defer cleanupTestStorage(t, tempDir)
+	defer cleanupTestManager(t, manager, tempDir)
 
 	testCases := []struct {
 		name     string
@@ -139,7 +148,7 @@ func TestKeyGeneration(t *testing.T) {
 // TestMarshalUnmarshalEdgeCases tests JSON serialization edge cases
 func TestMarshalUnmarshalEdgeCases(t *testing.T) {
 	manager, tempDir := setupTestManager(t)
-	defer cleanupTestStorage(t, tempDir)
+	defer cleanupTestManager(t, manager, tempDir)
 
 	edgeCases := []struct {
 		name string
@@ -237,7 +246,7 @@ func TestMarshalUnmarshalEdgeCases(t *testing.T) {
 // TestInvalidateUser tests user-specific cache invalidation
 func TestInvalidateUser(t *testing.T) {
 	manager, tempDir := setupTestManager(t)
-	defer cleanupTestStorage(t, tempDir)
+	defer cleanupTestManager(t, manager, tempDir)
 
 	users := []string{"user1", "user2", "user3"}
 	dataTypes := []string{"profile", "repositories", "organizations"}
@@ -297,9 +306,10 @@ func TestInvalidateUser(t *testing.T) {
 // TestForceRefresh tests force refresh functionality
 func TestForceRefresh(t *testing.T) {
 	manager, tempDir := setupTestManager(t)
-	defer cleanupTestStorage(t, tempDir)
+	defer cleanupTestManager(t, manager, tempDir)
 
-	key := CacheKey{Type: "test", Username: "refresh_user"}
+	// ForceRefresh invalidates the per-user cache types, so use one of them rather than an ad hoc type
+	key := manager.GetUserProfileKey("refresh_user")
 	originalData := map[string]interface{}{"version": "original"}
 
 	// Set original data
@@ -349,7 +359,7 @@ func TestForceRefresh(t *testing.T) {
 // TestConcurrentManagerOperations tests concurrent operations on the manager
 func TestConcurrentManagerOperations(t *testing.T) {
 	manager, tempDir := setupTestManager(t)
-	defer cleanupTestStorage(t, tempDir)
+	defer cleanupTestManager(t, manager, tempDir)
 
 	const (
 		numGoroutines = 50
@@ -416,7 +426,7 @@ func TestConcurrentManagerOperations(t *testing.T) {
 // TestManagerEnabledDisabled tests enabled/disabled functionality
 func TestManagerEnabledDisabled(t *testing.T) {
 	manager, tempDir := setupTestManager(t)
-	defer cleanupTestStorage(t, tempDir)
+	defer cleanupTestManager(t, manager, tempDir)
 
 	key := CacheKey{Type: "test", Username: "enable_test"}
 	data := map[string]interface{}{"test": "enabled"}
@@ -466,7 +476,7 @@ func TestManagerEnabledDisabled(t *testing.T) {
 // TestManagerCleanup tests manager cleanup functionality
 func TestManagerCleanup(t *testing.T) {
 	manager, tempDir := setupTestManager(t)
-	defer cleanupTestStorage(t, tempDir)
+	defer cleanupTestManager(t, manager, tempDir)
 
 	// Create entries with different expiration times
 	entries := []struct {
@@ -503,4 +513,44 @@ func TestManagerCleanup(t *testing.T) {
 			t.Errorf("Valid entry %s should not have been cleaned", entry.key.String())
 		}
 	}
-}
\ No newline at end of file
+}
+
+// TestVersionAndNamespaceIsolation verifies entries written under another version or namespace are cache misses
+func TestVersionAndNamespaceIsolation(t *testing.T) {
+	tempDir := t.TempDir()
+	newManager := func(version, namespace string) *Manager {
+		manager, err := NewManager(&CacheConfig{BaseDir: tempDir, Version: version, Namespace: namespace})
+		if err != nil {
+			t.Fatalf("Failed to create manager: %v", err)
+		}
+		// Hits persist their entry in the background, which must end before tempDir is removed
+		t.Cleanup(func() { manager.Close() })
+		return manager
+	}
+
+	key := CacheKey{Type: "profile", Username: "testuser"}
+	if err := newManager("1.0", "").Set(key, map[string]interface{}{"name": "old"}, time.Hour); err != nil {
+		t.Fatalf("Failed to set data: %v", err)
+	}
+
+	if result, _ := newManager("1.0", "").Get(key); !result.Hit {
+		t.Error("Expected a hit under the same version")
+	}
+	if result, _ := newManager("2.0", "").Get(key); result.Hit {
+		t.Error("Expected a miss for an entry written under the old version")
+	}
+	if result, _ := newManager("1.0", "tenant-a").Get(key); result.Hit {
+		t.Error("Expected a miss in another namespace")
+	}
+
+	tenant := newManager("2.0", "tenant-a")
+	if err := tenant.Set(key, map[string]interface{}{"name": "tenant"}, time.Hour); err != nil {
+		t.Fatalf("Failed to set data: %v", err)
+	}
+	if err := newManager("2.0", "").Clear(); err != nil {
+		t.Fatalf("Failed to clear default namespace: %v", err)
+	}
+	if result, _ := tenant.Get(key); !result.Hit {
+		t.Error("Expected clearing the default namespace to keep other namespaces")
+	}
+}
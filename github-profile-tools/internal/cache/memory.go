@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStorage implements cache storage in process memory, for ephemeral runs such as CI where
+// cache files would be discarded with the container. Entries are kept JSON-encoded so reads return
+// the same decoded form as FileStorage and callers cannot mutate cached data in place.
+type MemoryStorage struct {
+	config  *CacheConfig
+	entries map[string]*memoryEntry
+	mutex   sync.RWMutex
+	stats   *CacheStats
+}
+
+// memoryEntry is a cache entry whose data is held encoded
+type memoryEntry struct {
+	entry CacheEntry
+	data  []byte
+}
+
+// NewMemoryStorage creates an empty in-memory cache storage
+func NewMemoryStorage(config *CacheConfig) *MemoryStorage {
+	return &MemoryStorage{
+		config:  config,
+		entries: make(map[string]*memoryEntry),
+		stats: &CacheStats{
+			LastCleanup: time.Now(),
+		},
+	}
+}
+
+// Get retrieves a cache entry by key
+func (ms *MemoryStorage) Get(key string) (*CacheResult, error) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	stored, ok := ms.entries[key]
+	if !ok || stored.entry.IsExpired() || stored.entry.Version != ms.config.Version {
+		if ok {
+			ms.remove(key)
+		}
+		ms.stats.MissCount++
+		ms.updateHitRatio()
+		return &CacheResult{
+			Hit: false,
+			Key: key,
+		}, nil
+	}
+
+	// Decode numbers as json.Number, as FileStorage does
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(stored.data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		ms.stats.MissCount++
+		ms.updateHitRatio()
+		return &CacheResult{
+			Hit:   false,
+			Key:   key,
+			Error: fmt.Errorf("failed to decode cache entry: %w", err),
+		}, nil
+	}
+
+	stored.entry.UpdateAccess()
+	ms.stats.HitCount++
+	ms.updateHitRatio()
+
+	return &CacheResult{
+		Hit:       true,
+		Key:       key,
+		Data:      data,
+		CreatedAt: stored.entry.CreatedAt,
+		ExpiresAt: stored.entry.ExpiresAt,
+	}, nil
+}
+
+// Set stores a cache entry
+func (ms *MemoryStorage) Set(key string, data interface{}, ttl time.Duration) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	// Use default TTL if none specified
+	if ttl == 0 {
+		ttl = ms.config.DefaultTTL
+	}
+
+	now := time.Now()
+	ms.remove(key)
+	ms.entries[key] = &memoryEntry{
+		entry: CacheEntry{
+			Key:        key,
+			CreatedAt:  now,
+			ExpiresAt:  now.Add(ttl),
+			Version:    ms.config.Version,
+			AccessedAt: now,
+		},
+		data: encoded,
+	}
+	ms.stats.TotalEntries++
+	ms.stats.TotalSize += int64(len(encoded))
+
+	return nil
+}
+
+// Delete removes a cache entry
+func (ms *MemoryStorage) Delete(key string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	ms.remove(key)
+	return nil
+}
+
+// DeleteByPrefix removes all cache entries whose keys start with the given prefix
+func (ms *MemoryStorage) DeleteByPrefix(keyPrefix string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	for key := range ms.entries {
+		if strings.HasPrefix(key, keyPrefix) {
+			ms.remove(key)
+		}
+	}
+	return nil
+}
+
+// Clear removes all cache entries
+func (ms *MemoryStorage) Clear() error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	ms.entries = make(map[string]*memoryEntry)
+	ms.stats = &CacheStats{
+		LastCleanup: time.Now(),
+	}
+	return nil
+}
+
+// Cleanup removes expired entries and entries written by another cache version
+func (ms *MemoryStorage) Cleanup() error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	for key, stored := range ms.entries {
+		if stored.entry.IsExpired() || stored.entry.Version != ms.config.Version {
+			ms.remove(key)
+		}
+	}
+	ms.stats.LastCleanup = time.Now()
+	return nil
+}
+
+// Close does nothing, as the memory storage writes nothing in the background
+func (ms *MemoryStorage) Close() error {
+	return nil
+}
+
+// GetStats returns current cache statistics
+func (ms *MemoryStorage) GetStats() *CacheStats {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	// Create a copy to avoid race conditions
+	stats := *ms.stats
+	return &stats
+}
+
+// remove deletes an entry and its share of the statistics. Callers must hold ms.mutex.
+func (ms *MemoryStorage) remove(key string) {
+	stored, ok := ms.entries[key]
+	if !ok {
+		return
+	}
+	delete(ms.entries, key)
+	ms.stats.TotalEntries--
+	ms.stats.TotalSize -= int64(len(stored.data))
+}
+
+// updateHitRatio recalculates the cache hit ratio. Callers must hold ms.mutex.
+func (ms *MemoryStorage) updateHitRatio() {
+	total := ms.stats.HitCount + ms.stats.MissCount
+	if total > 0 {
+		ms.stats.HitRatio = float64(ms.stats.HitCount) / float64(total)
+	}
+}
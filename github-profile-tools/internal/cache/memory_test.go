@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestMemoryStorage creates an in-memory storage configured like setupTestStorage
+func newTestMemoryStorage() *MemoryStorage {
+	return NewMemoryStorage(&CacheConfig{
+		DefaultTTL: 1 * time.Hour,
+		Version:    "test",
+	})
+}
+
+// TestMemoryStorageMatchesFileStorage verifies set, get, prefix deletion and clear behave as on file storage
+func TestMemoryStorageMatchesFileStorage(t *testing.T) {
+	fileStorage, _ := setupTestStorage(t)
+
+	data := map[string]interface{}{"login": "octocat", "repos": []interface{}{"hello-world"}, "stars": 10}
+	for name, storage := range map[string]Storage{"file": fileStorage, "memory": newTestMemoryStorage()} {
+		if err := storage.Set("profile_octocat", data, 0); err != nil {
+			t.Fatalf("%s: Set failed: %v", name, err)
+		}
+		if err := storage.Set("profile_octocat_scope:docker:cat", data, 0); err != nil {
+			t.Fatalf("%s: Set failed: %v", name, err)
+		}
+		if err := storage.Set("profile_hubot", data, 0); err != nil {
+			t.Fatalf("%s: Set failed: %v", name, err)
+		}
+
+		result, err := storage.Get("profile_octocat")
+		if err != nil || !result.Hit {
+			t.Fatalf("%s: Expected a hit, got %+v (%v)", name, result, err)
+		}
+		// Both backends return the JSON-decoded form callers unmarshal from
+		expected := map[string]interface{}{"login": "octocat", "repos": []interface{}{"hello-world"}, "stars": json.Number("10")}
+		if !reflect.DeepEqual(result.Data, expected) {
+			t.Errorf("%s: Expected %v, got %v", name, expected, result.Data)
+		}
+
+		if err := storage.DeleteByPrefix("profile_octocat"); err != nil {
+			t.Fatalf("%s: DeleteByPrefix failed: %v", name, err)
+		}
+		for key, hit := range map[string]bool{"profile_octocat": false, "profile_octocat_scope:docker:cat": false, "profile_hubot": true} {
+			if result, _ := storage.Get(key); result.Hit != hit {
+				t.Errorf("%s: Expected hit=%v for %s after DeleteByPrefix", name, hit, key)
+			}
+		}
+
+		if err := storage.Clear(); err != nil {
+			t.Fatalf("%s: Clear failed: %v", name, err)
+		}
+		if result, _ := storage.Get("profile_hubot"); result.Hit {
+			t.Errorf("%s: Expected a miss after Clear", name)
+		}
+	}
+}
+
+// TestMemoryStorageTTL verifies expired entries miss and are dropped by Cleanup
+func TestMemoryStorageTTL(t *testing.T) {
+	storage := newTestMemoryStorage()
+
+	if err := storage.Set("short", "value", 50*time.Millisecond); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := storage.Set("long", "value", time.Hour); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if result, _ := storage.Get("short"); !result.Hit {
+		t.Error("Expected a hit before the TTL elapsed")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if err := storage.Cleanup(); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	if stats := storage.GetStats(); stats.TotalEntries != 1 {
+		t.Errorf("Expected 1 entry left after Cleanup, got %d", stats.TotalEntries)
+	}
+	if result, _ := storage.Get("short"); result.Hit {
+		t.Error("Expected a miss after the TTL elapsed")
+	}
+	if result, _ := storage.Get("long"); !result.Hit {
+		t.Error("Expected the unexpired entry to survive Cleanup")
+	}
+}
+
+// TestMemoryStorageIsolatesData verifies mutating data after Set does not change the cached copy
+func TestMemoryStorageIsolatesData(t *testing.T) {
+	storage := newTestMemoryStorage()
+
+	data := map[string]interface{}{"login": "octocat"}
+	if err := storage.Set("profile_octocat", data, 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	data["login"] = "changed"
+
+	result, _ := storage.Get("profile_octocat")
+	if got := result.Data.(map[string]interface{})["login"]; got != "octocat" {
+		t.Errorf("Expected the cached login to stay octocat, got %v", got)
+	}
+}
@@ -15,29 +15,71 @@ import (
 	"time"
 )
 
+// namespacesDir is the BaseDir subdirectory holding one directory per named namespace
+const namespacesDir = "namespaces"
+
+// tempSuffix ends the names of entries being written, before they are renamed into place.
+// Cleanup leaves them alone for tempMaxAge, after which they are leftovers of a crash.
+const (
+	tempSuffix = ".tmp"
+	tempMaxAge = time.Minute
+)
+
+// Storage is implemented by the cache backends the Manager delegates to
+type Storage interface {
+	Get(key string) (*CacheResult, error)
+	Set(key string, data interface{}, ttl time.Duration) error
+	Delete(key string) error
+	DeleteByPrefix(keyPrefix string) error
+	Clear() error
+	Cleanup() error
+	GetStats() *CacheStats
+	Close() error
+}
+
+// NewStorage creates the storage backend selected by config.Backend
+func NewStorage(config *CacheConfig) (Storage, error) {
+	switch config.Backend {
+	case "", BackendFile:
+		return NewFileStorage(config)
+	case BackendMemory:
+		return NewMemoryStorage(config), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend: %s", config.Backend)
+	}
+}
+
 // FileStorage implements cache storage using the local filesystem
 type FileStorage struct {
-	config *CacheConfig
-	mutex  sync.RWMutex
-	stats  *CacheStats
+	config  *CacheConfig
+	root    string // BaseDir, or the namespace directory below it
+	mutex   sync.RWMutex
+	stats   *CacheStats
+	pending sync.WaitGroup // background writes started by Get and updateStats
 }
 
 // NewFileStorage creates a new file-based cache storage
 func NewFileStorage(config *CacheConfig) (*FileStorage, error) {
-	if err := os.MkdirAll(config.BaseDir, 0755); err != nil {
+	root := config.BaseDir
+	if config.Namespace != "" {
+		root = filepath.Join(config.BaseDir, namespacesDir, sanitizeKey(config.Namespace))
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
 	// Create subdirectories for organization
 	subdirs := []string{"profiles", "repositories", "organizations", "contributions", "metadata"}
 	for _, subdir := range subdirs {
-		if err := os.MkdirAll(filepath.Join(config.BaseDir, subdir), 0755); err != nil {
+		if err := os.MkdirAll(filepath.Join(root, subdir), 0755); err != nil {
 			return nil, fmt.Errorf("failed to create cache subdirectory %s: %w", subdir, err)
 		}
 	}
 
 	fs := &FileStorage{
 		config: config,
+		root:   root,
 		stats: &CacheStats{
 			LastCleanup: time.Now(),
 		},
@@ -60,8 +102,10 @@ func (fs *FileStorage) Get(key string) (*CacheResult, error) {
 	filePath := fs.getFilePath(key)
 
 	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
 		atomic.AddInt64(&fs.stats.MissCount, 1)
+		fs.updateHitRatio()
 		return &CacheResult{
 			Hit: false,
 			Key: key,
@@ -72,6 +116,7 @@ func (fs *FileStorage) Get(key string) (*CacheResult, error) {
 	entry, err := fs.readCacheEntry(filePath)
 	if err != nil {
 		atomic.AddInt64(&fs.stats.MissCount, 1)
+		fs.updateHitRatio()
 		return &CacheResult{
 			Hit:   false,
 			Key:   key,
@@ -79,11 +124,12 @@ func (fs *FileStorage) Get(key string) (*CacheResult, error) {
 		}, nil
 	}
 
-	// Check if entry is expired
-	if entry.IsExpired() {
+	// Check if entry is expired or was written by another cache version
+	if entry.IsExpired() || entry.Version != fs.config.Version {
 		atomic.AddInt64(&fs.stats.MissCount, 1)
+		fs.updateHitRatio()
 		// Async cleanup of expired entry
-		go fs.deleteFile(filePath)
+		fs.backgroundIfUnchanged(filePath, info, func() { fs.deleteFile(filePath) })
 		return &CacheResult{
 			Hit: false,
 			Key: key,
@@ -97,11 +143,11 @@ func (fs *FileStorage) Get(key string) (*CacheResult, error) {
 	fs.updateHitRatio()
 
 	// Persist the updated entry asynchronously
-	go func(path string, e *CacheEntry) {
-		if err := fs.writeCacheEntry(path, e); err != nil {
+	fs.backgroundIfUnchanged(filePath, info, func() {
+		if err := fs.writeCacheEntry(filePath, entry); err != nil {
 			// Log error but don't fail the read
 		}
-	}(filePath, entry)
+	})
 
 	return &CacheResult{
 		Hit:       true,
@@ -192,10 +238,13 @@ func (fs *FileStorage) DeleteByPrefix(keyPrefix string) error {
 	var lastError error
 
 	// Walk through cache directory to find matching entries
-	err := filepath.Walk(fs.config.BaseDir, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(fs.root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if fs.isOtherNamespace(path, info) {
+			return filepath.SkipDir
+		}
 
 		// Skip directories and metadata files
 		if info.IsDir() || strings.HasSuffix(path, "_stats.json") {
@@ -203,7 +252,7 @@ func (fs *FileStorage) DeleteByPrefix(keyPrefix string) error {
 		}
 
 		// Extract the original key from the file path
-		// Files are stored as: <BaseDir>/<subdir>/<sanitized_key>.json[.gz]
+		// Files are stored as: <root>/<subdir>/<sanitized_key>.json[.gz]
 		filename := filepath.Base(path)
 		// Remove .gz extension if present
 		if strings.HasSuffix(filename, ".gz") {
@@ -250,10 +299,13 @@ func (fs *FileStorage) Clear() error {
 	defer fs.mutex.Unlock()
 
 	// Remove all files in cache directory
-	err := filepath.Walk(fs.config.BaseDir, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(fs.root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if fs.isOtherNamespace(path, info) {
+			return filepath.SkipDir
+		}
 
 		// Skip directories and metadata files
 		if info.IsDir() || strings.HasSuffix(path, "_stats.json") {
@@ -283,15 +335,21 @@ func (fs *FileStorage) Cleanup() error {
 	var removedCount int
 	var removedSize int64
 
-	err := filepath.Walk(fs.config.BaseDir, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(fs.root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if fs.isOtherNamespace(path, info) {
+			return filepath.SkipDir
+		}
 
-		// Skip directories and metadata files
+		// Skip directories, metadata files and entries still being written
 		if info.IsDir() || strings.HasSuffix(path, "_stats.json") {
 			return nil
 		}
+		if strings.HasSuffix(path, tempSuffix) && time.Since(info.ModTime()) < tempMaxAge {
+			return nil
+		}
 
 		// Try to read cache entry
 		entry, err := fs.readCacheEntry(path)
@@ -303,8 +361,8 @@ func (fs *FileStorage) Cleanup() error {
 			return nil
 		}
 
-		// Remove if expired
-		if entry.IsExpired() {
+		// Remove if expired or unreadable by this cache version
+		if entry.IsExpired() || entry.Version != fs.config.Version {
 			fs.deleteFile(path)
 			removedCount++
 			removedSize += info.Size()
@@ -336,10 +394,7 @@ func (fs *FileStorage) GetStats() *CacheStats {
 
 // getFilePath generates the file path for a cache key
 func (fs *FileStorage) getFilePath(key string) string {
-	// Sanitize key for filesystem
-	sanitized := strings.ReplaceAll(key, "/", "_")
-	sanitized = strings.ReplaceAll(sanitized, "\\", "_")
-	sanitized = strings.ReplaceAll(sanitized, ":", "_")
+	sanitized := sanitizeKey(key)
 
 	// Determine subdirectory based on key type
 	var subdir string
@@ -360,7 +415,20 @@ func (fs *FileStorage) getFilePath(key string) string {
 		filename += ".gz"
 	}
 
-	return filepath.Join(fs.config.BaseDir, subdir, filename)
+	return filepath.Join(fs.root, subdir, filename)
+}
+
+// sanitizeKey makes a key or namespace usable as a file name
+func sanitizeKey(key string) string {
+	sanitized := strings.ReplaceAll(key, "/", "_")
+	sanitized = strings.ReplaceAll(sanitized, "\\", "_")
+	return strings.ReplaceAll(sanitized, ":", "_")
+}
+
+// isOtherNamespace reports whether a walked path is the namespaces directory below the default
+// namespace, whose entries belong to other namespaces
+func (fs *FileStorage) isOtherNamespace(path string, info os.FileInfo) bool {
+	return info.IsDir() && path == filepath.Join(fs.root, namespacesDir)
 }
 
 // readCacheEntry reads and deserializes a cache entry from disk
@@ -383,8 +451,11 @@ func (fs *FileStorage) readCacheEntry(filePath string) (*CacheEntry, error) {
 		reader = gzReader
 	}
 
+	// Keep numbers as json.Number so that integers beyond float64 precision survive the round trip
 	var entry CacheEntry
-	if err := json.NewDecoder(reader).Decode(&entry); err != nil {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	if err := decoder.Decode(&entry); err != nil {
 		return nil, fmt.Errorf("failed to decode cache entry: %w", err)
 	}
 
@@ -393,25 +464,54 @@ func (fs *FileStorage) readCacheEntry(filePath string) (*CacheEntry, error) {
 
 // writeCacheEntry serializes and writes a cache entry to disk
 func (fs *FileStorage) writeCacheEntry(filePath string, entry *CacheEntry) error {
-	file, err := os.Create(filePath)
+	// Write to a temporary file and rename it into place so a crash never leaves a truncated entry.
+	// Each writer gets its own temporary file, so concurrent writes of one key never mix.
+	file, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*"+tempSuffix)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	tmpPath := file.Name()
+	if err := fs.encodeCacheEntry(file, entry); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
 
+	return nil
+}
+
+// encodeCacheEntry encodes a cache entry into file, compressing it if enabled, and closes it
+func (fs *FileStorage) encodeCacheEntry(file *os.File, entry *CacheEntry) error {
 	var writer io.Writer = file
+	var gzWriter *gzip.Writer
 
 	// Handle compression
 	if fs.config.EnableCompression {
-		gzWriter := gzip.NewWriter(file)
-		defer gzWriter.Close()
+		gzWriter = gzip.NewWriter(file)
 		writer = gzWriter
 	}
 
 	encoder := json.NewEncoder(writer)
 	encoder.SetIndent("", "  ")
 
-	return encoder.Encode(entry)
+	if err := encoder.Encode(entry); err != nil {
+		file.Close()
+		return err
+	}
+
+	// Flush the compressed stream and the file before the rename makes them visible
+	if gzWriter != nil {
+		if err := gzWriter.Close(); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	return file.Close()
 }
 
 // deleteFile safely removes a file
@@ -430,11 +530,8 @@ func (fs *FileStorage) calculateChecksum(data interface{}) (string, error) {
 	return fmt.Sprintf("%x", hash), nil
 }
 
-// updateHitRatio recalculates the cache hit ratio
+// updateHitRatio recalculates the cache hit ratio. Callers must hold fs.mutex.
 func (fs *FileStorage) updateHitRatio() {
-	fs.mutex.Lock()
-	defer fs.mutex.Unlock()
-
 	hitCount := atomic.LoadInt64(&fs.stats.HitCount)
 	missCount := atomic.LoadInt64(&fs.stats.MissCount)
 	total := hitCount + missCount
@@ -443,28 +540,59 @@ func (fs *FileStorage) updateHitRatio() {
 	}
 }
 
-// updateStats updates internal statistics
+// updateStats updates internal statistics. Callers must hold fs.mutex.
 func (fs *FileStorage) updateStats() {
 	fs.updateHitRatio()
 
 	// Make a copy and save asynchronously to avoid blocking and races
-	fs.mutex.RLock()
 	statsCopy := *fs.stats
-	fs.mutex.RUnlock()
 
-	go func() {
+	fs.background(func() {
 		// Create a temporary FileStorage to save the copied stats
 		tempFS := &FileStorage{
 			config: fs.config,
+			root:   fs.root,
 			stats:  &statsCopy,
 		}
 		tempFS.saveStats()
+	})
+}
+
+// background runs fn in its own goroutine, tracked until Close
+func (fs *FileStorage) background(fn func()) {
+	fs.pending.Add(1)
+	go func() {
+		defer fs.pending.Done()
+		fn()
 	}()
 }
 
+// backgroundIfUnchanged runs fn in the background under the storage mutex, unless the file read as
+// info was deleted or replaced in the meantime, so a late write-back never resurrects an invalidated
+// entry nor overwrites a newer one
+func (fs *FileStorage) backgroundIfUnchanged(filePath string, info os.FileInfo, fn func()) {
+	fs.background(func() {
+		fs.mutex.Lock()
+		defer fs.mutex.Unlock()
+
+		current, err := os.Stat(filePath)
+		if err != nil || info == nil || !current.ModTime().Equal(info.ModTime()) || current.Size() != info.Size() {
+			return
+		}
+		fn()
+	})
+}
+
+// Close waits for the background writes still running, so that the cache directory can be
+// removed. The storage stays usable afterwards.
+func (fs *FileStorage) Close() error {
+	fs.pending.Wait()
+	return nil
+}
+
 // loadStats loads cache statistics from disk
 func (fs *FileStorage) loadStats() error {
-	statsPath := filepath.Join(fs.config.BaseDir, "metadata", "cache_stats.json")
+	statsPath := filepath.Join(fs.root, "metadata", "cache_stats.json")
 
 	file, err := os.Open(statsPath)
 	if err != nil {
@@ -477,7 +605,7 @@ func (fs *FileStorage) loadStats() error {
 
 // saveStats saves cache statistics to disk
 func (fs *FileStorage) saveStats() error {
-	statsPath := filepath.Join(fs.config.BaseDir, "metadata", "cache_stats.json")
+	statsPath := filepath.Join(fs.root, "metadata", "cache_stats.json")
 
 	// Ensure metadata directory exists
 	if err := os.MkdirAll(filepath.Dir(statsPath), 0755); err != nil {
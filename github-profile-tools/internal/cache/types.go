@@ -49,10 +49,23 @@ type CacheConfig struct {
 	// EnableCompression enables gzip compression for cache files
 	EnableCompression bool
 
-	// Version is the cache format version for migration support
+	// Version is the cache format version for migration support. Entries written under another
+	// version are treated as misses, so bumping it invalidates them without a manual clear.
 	Version string
+
+	// Namespace separates caches sharing BaseDir, e.g. per tenant; empty means the default namespace
+	Namespace string
+
+	// Backend selects where entries are kept: BackendFile (the default when empty) or BackendMemory
+	Backend string
 }
 
+// Storage backends selectable through CacheConfig.Backend
+const (
+	BackendFile   = "file"
+	BackendMemory = "memory"
+)
+
 // CacheStats provides runtime statistics about cache performance
 type CacheStats struct {
 	HitCount     int64   `json:"hit_count"`
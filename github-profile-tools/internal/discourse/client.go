@@ -5,25 +5,204 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
+	"math/rand"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+const (
+	// DefaultBaseURL is the Jenkins community forum used when no other forum is configured
+	DefaultBaseURL = "https://community.jenkins.io"
+
+	// DefaultMaxPosts caps how many posts are fetched per user unless overridden
+	DefaultMaxPosts = 500
+
+	// DefaultCategoryCacheTTL is how long the forum category list is reused between analyses
+	DefaultCategoryCacheTTL = 1 * time.Hour
+
+	postsPageSize = 50 // Discourse user_actions page size
+
+	maxRetries = 4
+	baseDelay  = 2 * time.Second
+	maxDelay   = 1 * time.Minute
+)
+
 // Client handles Discourse API interactions
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	httpClient  *http.Client
+	apiKey      string
+	apiUsername string
+	maxPosts    int
+	retryDelay  time.Duration // base delay for exponential backoff
+
+	// Category cache shared across analyses; categoryMutex also serializes refreshes
+	categoryMutex     sync.Mutex
+	categoryCacheTTL  time.Duration
+	categoryCacheFile string
+	categories        map[int]string
+	categoriesFetched time.Time
 }
 
-// NewClient creates a new Discourse client
+// categoryCacheData is the on-disk representation of the category cache
+type categoryCacheData struct {
+	BaseURL    string         `json:"base_url"`
+	FetchedAt  time.Time      `json:"fetched_at"`
+	Categories map[int]string `json:"categories"`
+}
+
+// NewClient creates a new Discourse client that makes anonymous requests
 func NewClient() *Client {
+	return NewClientWithAuth(DefaultBaseURL, "", "")
+}
+
+// NewClientWithAuth creates a Discourse client for the given forum that authenticates
+// with an API key. When apiKey is empty, requests are made anonymously.
+func NewClientWithAuth(baseURL, apiKey, apiUsername string) *Client {
 	return &Client{
-		baseURL: "https://community.jenkins.io",
+		baseURL: strings.TrimSuffix(baseURL, "/"),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		apiKey:      apiKey,
+		apiUsername: apiUsername,
+		maxPosts:    DefaultMaxPosts,
+		retryDelay:  baseDelay,
+
+		categoryCacheTTL: DefaultCategoryCacheTTL,
+	}
+}
+
+// WithMaxPosts sets the maximum number of posts fetched per user (0 means no cap)
+func (c *Client) WithMaxPosts(maxPosts int) *Client {
+	c.maxPosts = maxPosts
+	return c
+}
+
+// newRequest builds a GET request for the given URL, adding API key headers when configured
+func (c *Client) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Api-Key", c.apiKey)
+		if c.apiUsername != "" {
+			req.Header.Set("Api-Username", c.apiUsername)
+		}
+	}
+
+	return req, nil
+}
+
+// get performs a GET request with exponential backoff retry for rate-limited (429) and
+// server error responses, honoring Retry-After. Other responses, including 404 for unknown
+// users, are returned immediately for the caller to handle.
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	var lastErr error
+	var delay time.Duration
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			log.Printf("Retrying Discourse request in %v (attempt %d/%d): %v", delay, attempt+1, maxRetries, lastErr)
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		req, err := c.newRequest(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = fmt.Errorf("failed to execute request: %w", err)
+			delay = c.backoffDuration(attempt)
+			continue
+		}
+
+		if !isRetryableStatusCode(resp.StatusCode) || attempt == maxRetries-1 {
+			return resp, nil
+		}
+
+		lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+		delay = c.backoffDuration(attempt)
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			delay = retryAfter
+		}
+		resp.Body.Close()
+	}
+
+	return nil, fmt.Errorf("request failed after %d attempts: %w", maxRetries, lastErr)
+}
+
+// backoffDuration calculates exponential backoff with jitter for the given attempt
+func (c *Client) backoffDuration(attempt int) time.Duration {
+	delay := c.retryDelay * time.Duration(1<<uint(attempt))
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	// Add jitter (up to 20% of delay) for better distribution
+	jitter := time.Duration(rand.Float64() * 0.2 * float64(delay))
+	return delay + jitter
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		delay := time.Duration(seconds) * time.Second
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		return delay, true
+	}
+
+	if retryAt, err := http.ParseTime(value); err == nil {
+		delay := time.Until(retryAt)
+		if delay < 0 {
+			delay = 0
+		}
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// isRetryableStatusCode determines if an HTTP status code is retryable
+func isRetryableStatusCode(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, // 429
+		http.StatusInternalServerError, // 500
+		http.StatusBadGateway,          // 502
+		http.StatusServiceUnavailable,  // 503
+		http.StatusGatewayTimeout:      // 504
+		return true
+	default:
+		return false
 	}
 }
 
@@ -54,26 +233,38 @@ func (c *Client) AnalyzeDiscourseProfile(ctx context.Context, username string) (
 		BadgeCount:     user.BadgeCount,
 	}
 
-	// Step 2: Fetch user badges
+	// Step 2: Fetch the reading time and solved count from the user summary
+	summary, err := c.fetchUserSummary(ctx, username, profile)
+	if err != nil {
+		// Continue even if the summary fails - reading time is estimated instead
+		log.Printf("Warning: Failed to fetch summary for %s: %v", username, err)
+	}
+
+	// Step 2b: Fetch user badges
 	if err := c.fetchUserBadges(ctx, username, profile); err != nil {
 		// Continue even if badges fail - not critical
-		fmt.Printf("Warning: Failed to fetch badges for %s: %v\n", username, err)
+		log.Printf("Warning: Failed to fetch badges for %s: %v", username, err)
 	}
 
 	// Step 3: Fetch user posts for analysis
 	if err := c.fetchUserPosts(ctx, username, profile); err != nil {
 		// Continue even if posts fail - not critical
-		fmt.Printf("Warning: Failed to fetch posts for %s: %v\n", username, err)
+		log.Printf("Warning: Failed to fetch posts for %s: %v", username, err)
+	}
+
+	// The posts only sample recent answers, so prefer the forum's own solved count when it has one
+	if summary != nil && summary.UserSummary.SolvedCount != nil {
+		profile.SolutionsCount = *summary.UserSummary.SolvedCount
 	}
 
 	// Step 4: Fetch user topics
 	if err := c.fetchUserTopics(ctx, username, profile); err != nil {
 		// Continue even if topics fail - not critical
-		fmt.Printf("Warning: Failed to fetch topics for %s: %v\n", username, err)
+		log.Printf("Warning: Failed to fetch topics for %s: %v", username, err)
 	}
 
 	// Step 5: Fetch categories for context
-	categories, err := c.fetchCategories(ctx)
+	categories, err := c.getCategories(ctx)
 	if err != nil {
 		// Continue without categories - analysis will be less detailed
 		fmt.Printf("Warning: Failed to fetch categories: %v\n", err)
@@ -89,14 +280,9 @@ func (c *Client) AnalyzeDiscourseProfile(ctx context.Context, username string) (
 func (c *Client) fetchUser(ctx context.Context, username string) (*DiscourseUserResponse, error) {
 	url := fmt.Sprintf("%s/users/%s.json", c.baseURL, username)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -113,18 +299,38 @@ func (c *Client) fetchUser(ctx context.Context, username string) (*DiscourseUser
 	return &userResp, nil
 }
 
-// fetchUserBadges retrieves user's badges
-func (c *Client) fetchUserBadges(ctx context.Context, username string, profile *DiscourseProfile) error {
-	url := fmt.Sprintf("%s/user-badges/%s.json", c.baseURL, username)
+// fetchUserSummary retrieves the time the user spent reading, which Discourse reports in seconds,
+// and returns the summary for its other totals
+func (c *Client) fetchUserSummary(ctx context.Context, username string, profile *DiscourseProfile) (*DiscourseUserSummaryResponse, error) {
+	url := fmt.Sprintf("%s/u/%s/summary.json", c.baseURL, username)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("summary request failed with status %d", resp.StatusCode)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	var summaryResp DiscourseUserSummaryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&summaryResp); err != nil {
+		return nil, fmt.Errorf("failed to decode summary response: %w", err)
+	}
+
+	profile.ReadingTime = summaryResp.UserSummary.TimeRead / 60
+	profile.RecentReadingTime = summaryResp.UserSummary.RecentTimeRead / 60
+	return &summaryResp, nil
+}
+
+// fetchUserBadges retrieves user's badges
+func (c *Client) fetchUserBadges(ctx context.Context, username string, profile *DiscourseProfile) error {
+	url := fmt.Sprintf("%s/user-badges/%s.json", c.baseURL, username)
+
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -192,28 +398,38 @@ func (c *Client) fetchUserBadges(ctx context.Context, username string, profile *
 	return nil
 }
 
-// fetchUserPosts retrieves user's recent posts for analysis
+// fetchUserPosts retrieves user's posts for analysis, paging through the history with offset
+// until a short page is returned or the maxPosts cap is reached
 func (c *Client) fetchUserPosts(ctx context.Context, username string, profile *DiscourseProfile) error {
-	url := fmt.Sprintf("%s/user_actions.json?username=%s&filter=5&limit=50", c.baseURL, username) // filter=5 is posts
+	var postsResp DiscoursePostsResponse
+	var fetchErr error
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	for offset := 0; c.maxPosts <= 0 || offset < c.maxPosts; offset += postsPageSize {
+		if err := ctx.Err(); err != nil {
+			fetchErr = err
+			break
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		page, err := c.fetchUserPostsPage(ctx, username, offset)
+		if err != nil {
+			fetchErr = err
+			break
+		}
+
+		postsResp.LatestPosts = append(postsResp.LatestPosts, page.LatestPosts...)
+
+		if len(page.LatestPosts) < postsPageSize {
+			break
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("posts request failed with status %d", resp.StatusCode)
+	// Stop on the first failure but keep whatever pages were already fetched
+	if fetchErr != nil && len(postsResp.LatestPosts) == 0 {
+		return fetchErr
 	}
 
-	var postsResp DiscoursePostsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&postsResp); err != nil {
-		return fmt.Errorf("failed to decode posts response: %w", err)
+	if c.maxPosts > 0 && len(postsResp.LatestPosts) > c.maxPosts {
+		postsResp.LatestPosts = postsResp.LatestPosts[:c.maxPosts]
 	}
 
 	// Convert to our post structure and analyze
@@ -255,21 +471,42 @@ func (c *Client) fetchUserPosts(ctx context.Context, username string, profile *D
 		profile.TopPosts = profile.TopPosts[:10]
 	}
 
+	if fetchErr != nil {
+		return fmt.Errorf("stopped after %d posts: %w", len(postsResp.LatestPosts), fetchErr)
+	}
+
 	return nil
 }
 
-// fetchUserTopics retrieves user's topics
-func (c *Client) fetchUserTopics(ctx context.Context, username string, profile *DiscourseProfile) error {
-	url := fmt.Sprintf("%s/user_actions.json?username=%s&filter=4&limit=20", c.baseURL, username) // filter=4 is topics
+// fetchUserPostsPage retrieves a single page of the user's posts starting at offset
+func (c *Client) fetchUserPostsPage(ctx context.Context, username string, offset int) (*DiscoursePostsResponse, error) {
+	url := fmt.Sprintf("%s/user_actions.json?username=%s&filter=5&offset=%d&limit=%d", c.baseURL, username, offset, postsPageSize) // filter=5 is posts
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("posts request failed with status %d", resp.StatusCode)
+	}
+
+	var postsResp DiscoursePostsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&postsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode posts response: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	return &postsResp, nil
+}
+
+// fetchUserTopics retrieves user's topics
+func (c *Client) fetchUserTopics(ctx context.Context, username string, profile *DiscourseProfile) error {
+	url := fmt.Sprintf("%s/user_actions.json?username=%s&filter=4&limit=20", c.baseURL, username) // filter=4 is topics
+
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -324,18 +561,124 @@ func (c *Client) fetchUserTopics(ctx context.Context, username string, profile *
 	return nil
 }
 
-// fetchCategories retrieves forum categories for context
-func (c *Client) fetchCategories(ctx context.Context) (map[int]string, error) {
-	url := fmt.Sprintf("%s/categories.json", c.baseURL)
+// WithCategoryCacheTTL sets how long fetched categories are reused (0 disables caching)
+func (c *Client) WithCategoryCacheTTL(ttl time.Duration) *Client {
+	c.categoryMutex.Lock()
+	defer c.categoryMutex.Unlock()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	c.categoryCacheTTL = ttl
+	return c
+}
+
+// WithCategoryCacheFile persists the category cache to the given file so it survives between runs
+func (c *Client) WithCategoryCacheFile(path string) *Client {
+	c.categoryMutex.Lock()
+	defer c.categoryMutex.Unlock()
+
+	c.categoryCacheFile = path
+	return c
+}
+
+// getCategories returns forum categories, serving them from the in-memory or file cache while fresh
+func (c *Client) getCategories(ctx context.Context) (map[int]string, error) {
+	c.categoryMutex.Lock()
+	defer c.categoryMutex.Unlock()
+
+	if c.categoryCacheTTL <= 0 {
+		return c.fetchCategories(ctx)
+	}
+
+	if c.categories == nil && c.categoryCacheFile != "" {
+		c.loadCategoryCacheFile()
+	}
+
+	if c.categories != nil && time.Since(c.categoriesFetched) < c.categoryCacheTTL {
+		return c.categories, nil
+	}
+
+	categories, err := c.fetchCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.categories = categories
+	c.categoriesFetched = time.Now()
+
+	if c.categoryCacheFile != "" {
+		if err := c.saveCategoryCacheFile(); err != nil {
+			log.Printf("Warning: Failed to save Discourse category cache: %v", err)
+		}
+	}
+
+	return categories, nil
+}
+
+// loadCategoryCacheFile loads cached categories from disk if they belong to this forum
+func (c *Client) loadCategoryCacheFile() {
+	data, err := os.ReadFile(c.categoryCacheFile)
+	if err != nil {
+		return
+	}
+
+	var cached categoryCacheData
+	if err := json.Unmarshal(data, &cached); err != nil {
+		log.Printf("Warning: Ignoring unreadable Discourse category cache %s: %v", c.categoryCacheFile, err)
+		return
+	}
+
+	if cached.BaseURL != c.baseURL {
+		return
+	}
+
+	c.categories = cached.Categories
+	c.categoriesFetched = cached.FetchedAt
+}
+
+// saveCategoryCacheFile writes the current category cache to disk
+func (c *Client) saveCategoryCacheFile() error {
+	if err := os.MkdirAll(filepath.Dir(c.categoryCacheFile), 0755); err != nil {
+		return fmt.Errorf("failed to create category cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(categoryCacheData{
+		BaseURL:    c.baseURL,
+		FetchedAt:  c.categoriesFetched,
+		Categories: c.categories,
+	}, "", "  ")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to marshal category cache: %w", err)
+	}
+
+	// Write to a temporary file and rename it into place, so a crash or a concurrent analysis
+	// never leaves a truncated cache behind
+	file, err := os.CreateTemp(filepath.Dir(c.categoryCacheFile), filepath.Base(c.categoryCacheFile)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary category cache: %w", err)
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Chmod(0644)
 	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), c.categoryCacheFile)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to write category cache: %w", err)
+	}
+	return nil
+}
+
+// fetchCategories retrieves forum categories for context
+func (c *Client) fetchCategories(ctx context.Context) (map[int]string, error) {
+	url := fmt.Sprintf("%s/categories.json", c.baseURL)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -358,9 +701,10 @@ func (c *Client) fetchCategories(ctx context.Context) (map[int]string, error) {
 
 // analyzeEngagement performs comprehensive analysis of user engagement
 func (c *Client) analyzeEngagement(profile *DiscourseProfile, categories map[int]string) {
-	// Calculate reading time (estimated from days active and posts)
-	if profile.DaysActive > 0 {
+	// Estimate reading time from days active when the user summary did not provide it
+	if profile.ReadingTime == 0 && profile.DaysActive > 0 {
 		profile.ReadingTime = profile.DaysActive * 30 // Rough estimate: 30 min per active day
+		profile.ReadingEstimated = true
 	}
 
 	// Update category names in posts and topics now that we have the categories map
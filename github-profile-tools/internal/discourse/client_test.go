@@ -0,0 +1,418 @@
+package discourse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestServer creates a fake Discourse server that records the headers of the last request
+func newTestServer(t *testing.T, lastHeaders *http.Header) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*lastHeaders = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user": {"id": 1, "username": "testuser"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// TestAuthHeadersPresentWhenConfigured verifies that API key headers are sent when configured
+func TestAuthHeadersPresentWhenConfigured(t *testing.T) {
+	var headers http.Header
+	server := newTestServer(t, &headers)
+
+	client := NewClientWithAuth(server.URL, "secret-key", "system")
+	if _, err := client.fetchUser(context.Background(), "testuser"); err != nil {
+		t.Fatalf("fetchUser failed: %v", err)
+	}
+
+	if got := headers.Get("Api-Key"); got != "secret-key" {
+		t.Errorf("Expected Api-Key header 'secret-key', got '%s'", got)
+	}
+	if got := headers.Get("Api-Username"); got != "system" {
+		t.Errorf("Expected Api-Username header 'system', got '%s'", got)
+	}
+}
+
+// TestAuthHeadersAbsentWhenAnonymous verifies that anonymous clients send no API key headers
+func TestAuthHeadersAbsentWhenAnonymous(t *testing.T) {
+	var headers http.Header
+	server := newTestServer(t, &headers)
+
+	client := NewClientWithAuth(server.URL, "", "")
+	if _, err := client.fetchUser(context.Background(), "testuser"); err != nil {
+		t.Fatalf("fetchUser failed: %v", err)
+	}
+
+	if got := headers.Get("Api-Key"); got != "" {
+		t.Errorf("Expected no Api-Key header, got '%s'", got)
+	}
+	if got := headers.Get("Api-Username"); got != "" {
+		t.Errorf("Expected no Api-Username header, got '%s'", got)
+	}
+}
+
+// newPaginatedPostsServer creates a fake Discourse server that serves totalPosts posts in pages
+func newPaginatedPostsServer(t *testing.T, totalPosts int, offsets *[]int) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		mu.Lock()
+		*offsets = append(*offsets, offset)
+		mu.Unlock()
+
+		var resp DiscoursePostsResponse
+		for i := offset; i < offset+limit && i < totalPosts; i++ {
+			resp.LatestPosts = append(resp.LatestPosts, struct {
+				ID             int       `json:"id"`
+				Username       string    `json:"username"`
+				CreatedAt      time.Time `json:"created_at"`
+				Cooked         string    `json:"cooked"`
+				PostNumber     int       `json:"post_number"`
+				PostType       int       `json:"post_type"`
+				TopicID        int       `json:"topic_id"`
+				TopicTitle     string    `json:"topic_title"`
+				CategoryID     int       `json:"category_id"`
+				LikeCount      int       `json:"like_count"`
+				ReplyCount     int       `json:"reply_count"`
+				TopicViews     int       `json:"topic_views"`
+				AcceptedAnswer bool      `json:"accepted_answer"`
+			}{ID: i + 1, LikeCount: i / 4, AcceptedAnswer: i%10 == 0})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// TestFetchUserPostsPaginates verifies that all pages are consumed and ranked together
+func TestFetchUserPostsPaginates(t *testing.T) {
+	var offsets []int
+	server := newPaginatedPostsServer(t, 120, &offsets)
+
+	client := NewClientWithAuth(server.URL, "", "")
+	profile := &DiscourseProfile{}
+	if err := client.fetchUserPosts(context.Background(), "testuser", profile); err != nil {
+		t.Fatalf("fetchUserPosts failed: %v", err)
+	}
+
+	expectedOffsets := []int{0, 50, 100}
+	if len(offsets) != len(expectedOffsets) {
+		t.Fatalf("Expected %d page requests, got %d (%v)", len(expectedOffsets), len(offsets), offsets)
+	}
+	for i, offset := range expectedOffsets {
+		if offsets[i] != offset {
+			t.Errorf("Expected page %d at offset %d, got %d", i, offset, offsets[i])
+		}
+	}
+
+	// Every 10th post is an accepted answer: 12 solutions across 120 posts
+	if profile.SolutionsCount != 12 {
+		t.Errorf("Expected 12 solutions counted across all pages, got %d", profile.SolutionsCount)
+	}
+
+	if len(profile.TopPosts) != 10 {
+		t.Fatalf("Expected top 10 posts to be kept, got %d", len(profile.TopPosts))
+	}
+
+	// The most-liked post lives on the last page, so ranking must see every page
+	if profile.TopPosts[0].ID != 111 {
+		t.Errorf("Expected top post from the last page (ID 111), got ID %d", profile.TopPosts[0].ID)
+	}
+}
+
+// TestFetchUserPostsRespectsMaxPosts verifies that paging stops at the configured cap
+func TestFetchUserPostsRespectsMaxPosts(t *testing.T) {
+	var offsets []int
+	server := newPaginatedPostsServer(t, 120, &offsets)
+
+	client := NewClientWithAuth(server.URL, "", "").WithMaxPosts(50)
+	profile := &DiscourseProfile{}
+	if err := client.fetchUserPosts(context.Background(), "testuser", profile); err != nil {
+		t.Fatalf("fetchUserPosts failed: %v", err)
+	}
+
+	if len(offsets) != 1 {
+		t.Errorf("Expected a single page request with a cap of 50, got %d (%v)", len(offsets), offsets)
+	}
+	if profile.SolutionsCount != 5 {
+		t.Errorf("Expected 5 solutions within the first 50 posts, got %d", profile.SolutionsCount)
+	}
+}
+
+// TestCategoriesCachedAcrossAnalyses verifies that the categories endpoint is hit once within the TTL
+func TestCategoriesCachedAcrossAnalyses(t *testing.T) {
+	var mu sync.Mutex
+	categoryRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/categories.json":
+			mu.Lock()
+			categoryRequests++
+			mu.Unlock()
+			w.Write([]byte(`{"category_list": {"categories": [{"id": 1, "name": "Using Jenkins"}]}}`))
+		case r.URL.Path == "/user_actions.json":
+			w.Write([]byte(`{"latest_posts": [], "topic_list": {"topics": []}}`))
+		case strings.HasPrefix(r.URL.Path, "/user-badges/"):
+			w.Write([]byte(`{"user_badges": []}`))
+		default:
+			w.Write([]byte(`{"user": {"id": 1, "username": "testuser"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithAuth(server.URL, "", "").WithCategoryCacheTTL(time.Hour)
+
+	for _, username := range []string{"alice", "bob"} {
+		if _, err := client.AnalyzeDiscourseProfile(context.Background(), username); err != nil {
+			t.Fatalf("AnalyzeDiscourseProfile(%s) failed: %v", username, err)
+		}
+	}
+
+	if categoryRequests != 1 {
+		t.Errorf("Expected categories endpoint to be hit once, got %d", categoryRequests)
+	}
+}
+
+// TestCategoryCacheFileReused verifies that a file-backed cache is reused by a new client
+func TestCategoryCacheFileReused(t *testing.T) {
+	categoryRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		categoryRequests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"category_list": {"categories": [{"id": 7, "name": "Plugins"}]}}`))
+	}))
+	defer server.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "discourse", "categories.json")
+
+	first := NewClientWithAuth(server.URL, "", "").WithCategoryCacheFile(cacheFile)
+	if _, err := first.getCategories(context.Background()); err != nil {
+		t.Fatalf("getCategories failed: %v", err)
+	}
+
+	second := NewClientWithAuth(server.URL, "", "").WithCategoryCacheFile(cacheFile)
+	categories, err := second.getCategories(context.Background())
+	if err != nil {
+		t.Fatalf("getCategories failed: %v", err)
+	}
+
+	if categories[7] != "Plugins" {
+		t.Errorf("Expected cached category 'Plugins', got %v", categories)
+	}
+	if categoryRequests != 1 {
+		t.Errorf("Expected categories endpoint to be hit once, got %d", categoryRequests)
+	}
+
+	// The cache is written through a temporary file renamed into place
+	if entries, err := os.ReadDir(filepath.Dir(cacheFile)); err != nil || len(entries) != 1 {
+		t.Errorf("Expected only the cache file to be left, got %v (%v)", entries, err)
+	}
+}
+
+// TestRetryOnRateLimit verifies that a 429 response is retried until the request succeeds
+func TestRetryOnRateLimit(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user": {"id": 1, "username": "testuser"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAuth(server.URL, "", "")
+	userResp, err := client.fetchUser(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Expected eventual success, got error: %v", err)
+	}
+
+	if userResp.User.Username != "testuser" {
+		t.Errorf("Expected username 'testuser', got '%s'", userResp.User.Username)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests (429 then 200), got %d", requests)
+	}
+}
+
+// TestRetryOnServerErrorWithBackoff verifies that 5xx responses are retried using backoff
+func TestRetryOnServerErrorWithBackoff(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"category_list": {"categories": []}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithAuth(server.URL, "", "")
+	client.retryDelay = time.Millisecond
+
+	if _, err := client.fetchCategories(context.Background()); err != nil {
+		t.Fatalf("Expected eventual success, got error: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+// TestNoRetryOnNotFound verifies that a 404 fails immediately without retrying
+func TestNoRetryOnNotFound(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClientWithAuth(server.URL, "", "")
+	if _, err := client.fetchUser(context.Background(), "missing"); err == nil {
+		t.Fatal("Expected error for unknown user")
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request for 404, got %d", requests)
+	}
+}
+
+// TestRetryStopsOnContextCancel verifies that backoff waits respect context cancellation
+func TestRetryStopsOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClientWithAuth(server.URL, "", "")
+	start := time.Now()
+	if _, err := client.fetchUser(ctx, "testuser"); err == nil {
+		t.Fatal("Expected error when context is cancelled")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to stop retries promptly, took %v", elapsed)
+	}
+}
+
+// readingTimeServer serves a minimal profile whose summary endpoint is answered by summary
+func readingTimeServer(summary http.HandlerFunc) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/summary.json"):
+			summary(w, r)
+		case r.URL.Path == "/categories.json":
+			w.Write([]byte(`{"category_list": {"categories": []}}`))
+		case r.URL.Path == "/user_actions.json":
+			w.Write([]byte(`{"latest_posts": [], "topic_list": {"topics": []}}`))
+		case strings.HasPrefix(r.URL.Path, "/user-badges/"):
+			w.Write([]byte(`{"user_badges": []}`))
+		default:
+			w.Write([]byte(`{"user": {"id": 1, "username": "testuser", "days_visited": 10}}`))
+		}
+	}))
+}
+
+// TestReadingTimeFromSummary verifies that the time_read reported by the user summary replaces the estimate
+func TestReadingTimeFromSummary(t *testing.T) {
+	server := readingTimeServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"user_summary": {"time_read": 7200, "recent_time_read": 1800, "days_visited": 10}}`))
+	})
+	defer server.Close()
+
+	profile, err := NewClientWithAuth(server.URL, "", "").AnalyzeDiscourseProfile(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("AnalyzeDiscourseProfile failed: %v", err)
+	}
+
+	if profile.ReadingTime != 120 {
+		t.Errorf("Expected 120 minutes of reading time, got %d", profile.ReadingTime)
+	}
+	if profile.RecentReadingTime != 30 {
+		t.Errorf("Expected 30 minutes of recent reading time, got %d", profile.RecentReadingTime)
+	}
+	if profile.ReadingEstimated {
+		t.Error("Expected reading time not to be flagged as estimated")
+	}
+}
+
+// TestReadingTimeFallsBackToEstimate verifies that the 30 minutes per active day estimate is used when the summary is unavailable
+func TestReadingTimeFallsBackToEstimate(t *testing.T) {
+	server := readingTimeServer(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	defer server.Close()
+
+	profile, err := NewClientWithAuth(server.URL, "", "").AnalyzeDiscourseProfile(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("AnalyzeDiscourseProfile failed: %v", err)
+	}
+
+	if profile.ReadingTime != 300 || !profile.ReadingEstimated {
+		t.Errorf("Expected an estimated 300 minutes, got %d (estimated %v)", profile.ReadingTime, profile.ReadingEstimated)
+	}
+}
+
+// TestSolvedCountOverridesSampledPosts verifies that the summary's solved_count replaces the count taken from sampled posts
+func TestSolvedCountOverridesSampledPosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/summary.json"):
+			w.Write([]byte(`{"user_summary": {"time_read": 0, "solved_count": 42}}`))
+		case r.URL.Path == "/categories.json":
+			w.Write([]byte(`{"category_list": {"categories": []}}`))
+		case r.URL.Path == "/user_actions.json" && r.URL.Query().Get("filter") == "5":
+			w.Write([]byte(`{"latest_posts": [{"id": 1, "accepted_answer": true}, {"id": 2, "accepted_answer": true}]}`))
+		case r.URL.Path == "/user_actions.json":
+			w.Write([]byte(`{"latest_posts": [], "topic_list": {"topics": []}}`))
+		case strings.HasPrefix(r.URL.Path, "/user-badges/"):
+			w.Write([]byte(`{"user_badges": []}`))
+		default:
+			w.Write([]byte(`{"user": {"id": 1, "username": "testuser", "post_count": 100, "solution_count": 3}}`))
+		}
+	}))
+	defer server.Close()
+
+	profile, err := NewClientWithAuth(server.URL, "", "").AnalyzeDiscourseProfile(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("AnalyzeDiscourseProfile failed: %v", err)
+	}
+
+	if profile.SolutionsCount != 42 {
+		t.Errorf("Expected the authoritative 42 solutions, got %d", profile.SolutionsCount)
+	}
+	if want := 42 * 3; profile.CommunityMetrics.PeopleHelped != want {
+		t.Errorf("Expected derived metrics to use the authoritative count (%d people helped), got %d", want, profile.CommunityMetrics.PeopleHelped)
+	}
+}
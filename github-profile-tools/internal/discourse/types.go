@@ -19,6 +19,8 @@ type DiscourseProfile struct {
 	SolutionsCount      int                       `json:"solutions_count"`
 	DaysActive          int                       `json:"days_active"`
 	ReadingTime         int                       `json:"reading_time_minutes"`
+	RecentReadingTime   int                       `json:"recent_reading_time_minutes,omitempty"` // last 60 days
+	ReadingEstimated    bool                      `json:"reading_time_estimated,omitempty"`      // summary unavailable, derived from DaysActive
 
 	// Trust and reputation
 	TrustLevel          int                       `json:"trust_level"`
@@ -193,6 +195,16 @@ type DiscourseUserResponse struct {
 	} `json:"user_badges"`
 }
 
+// DiscourseUserSummaryResponse represents the API response for a user's activity summary
+type DiscourseUserSummaryResponse struct {
+	UserSummary struct {
+		TimeRead       int  `json:"time_read"`        // seconds
+		RecentTimeRead int  `json:"recent_time_read"` // seconds over the last 60 days
+		DaysVisited    int  `json:"days_visited"`
+		SolvedCount    *int `json:"solved_count"` // only reported when the solved plugin is installed
+	} `json:"user_summary"`
+}
+
 // DiscoursePostsResponse represents the API response for user posts
 type DiscoursePostsResponse struct {
 	LatestPosts []struct {
@@ -33,6 +33,12 @@ func NewClient() *Client {
 	}
 }
 
+// WithBaseURL overrides the Docker Hub API base URL, e.g. for tests
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+	return c
+}
+
 // SearchUserRepositories searches for repositories by a specific user using v2 API
 func (c *Client) SearchUserRepositories(ctx context.Context, username string) ([]DockerSearchResult, error) {
 	log.Printf("Searching Docker Hub repositories for user: %s", username)
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+// ErrGitHubDown is returned without contacting GitHub while the circuit breaker is open, after
+// consecutive infrastructure failures suggest an outage
+var ErrGitHubDown = errors.New("GitHub appears to be down")
+
+// circuitBreaker counts consecutive infrastructure failures across all requests of a client and,
+// past a threshold, fails every request fast until a cooldown elapses
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int       // consecutive infrastructure failures
+	openUntil time.Time // requests fail fast until then; zero when closed
+}
+
+// allow returns ErrGitHubDown while the breaker is open. Once the cooldown has elapsed, requests go
+// through again, and a single new failure reopens the breaker.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if remaining := time.Until(b.openUntil); remaining > 0 {
+		return fmt.Errorf("%w: failing fast for another %v", ErrGitHubDown, remaining.Round(time.Second))
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of an attempt, opening it for cooldown once threshold
+// consecutive infrastructure failures are seen. A threshold of zero, set by Client.WithoutBreaker,
+// disables the breaker.
+func (b *circuitBreaker) record(err error, threshold int, cooldown time.Duration) {
+	if threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isInfrastructureError(err) {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= threshold {
+		logging.Warnf("%d consecutive infrastructure failures, GitHub appears to be down: failing fast for %v", b.failures, cooldown)
+		b.openUntil = time.Now().Add(cooldown)
+		b.failures = threshold - 1 // a single failure after the cooldown reopens the breaker
+	}
+}
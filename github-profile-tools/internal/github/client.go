@@ -4,44 +4,81 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
-	"math/rand"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/jenkins/github-profile-tools/internal/logging"
+	"github.com/jenkins/github-profile-tools/internal/metrics"
 	"golang.org/x/oauth2"
 	"golang.org/x/time/rate"
+	"jenkins.io/alpha-omega-stats/ratelimit"
 )
 
 const (
 	githubGraphQLEndpoint = "https://api.github.com/graphql"
-	maxRetries           = 8  // Increased for better resilience
-	baseDelay            = 3 * time.Second  // Longer initial delay
-	maxDelay             = 10 * time.Minute // Longer max delay for infrastructure issues
+	githubRESTEndpoint    = "https://api.github.com"
+
+	// DefaultRequestTimeout bounds a single HTTP request, retries included separately
+	DefaultRequestTimeout = 30 * time.Second
 )
 
-// RateLimitInfo tracks GitHub API rate limit status
-type RateLimitInfo struct {
-	Limit     int       // Maximum number of requests per hour
-	Remaining int       // Number of requests remaining in current window
-	ResetTime time.Time // When the rate limit window resets
-	Used      int       // Number of requests used in current window
-	Resource  string    // Rate limit resource (graphql, core, etc.)
-	Updated   bool      // Whether this info has been updated from API response
-	mutex     sync.RWMutex
+// RetryConfig tunes the exponential backoff between attempts of a failed request
+type RetryConfig struct {
+	MaxRetries              int           // attempts before giving up
+	BaseDelay               time.Duration // delay before the first retry, doubled on every attempt
+	MaxDelay                time.Duration // upper bound of a single delay, before jitter
+	Jitter                  float64       // random extra delay, as a fraction of the delay
+	InfrastructureBaseDelay time.Duration // BaseDelay for bad gateway and network errors
+	InfrastructureJitter    float64       // Jitter for bad gateway and network errors
+	BreakerThreshold        int           // consecutive infrastructure failures before failing fast
+	BreakerCooldown         time.Duration // how long requests fail fast once the threshold is reached
+}
+
+// DefaultRetryConfig is the backoff used by new clients
+var DefaultRetryConfig = RetryConfig{
+	MaxRetries:              8,                // Increased for better resilience
+	BaseDelay:               3 * time.Second,  // Longer initial delay
+	MaxDelay:                10 * time.Minute, // Longer max delay for infrastructure issues
+	Jitter:                  0.2,
+	InfrastructureBaseDelay: 10 * time.Second,
+	InfrastructureJitter:    0.3,
+	BreakerThreshold:        5,
+	BreakerCooldown:         2 * time.Minute,
 }
 
+// RateLimitInfo tracks GitHub API rate limit status
+type RateLimitInfo = ratelimit.Status
+
 // Client represents a GitHub API client
 type Client struct {
-	httpClient    *http.Client
-	endpoint      string
-	limiter       *rate.Limiter
-	rateLimitInfo *RateLimitInfo
+	httpClient     *http.Client
+	endpoint       string
+	restEndpoint   string
+	limiter        *rate.Limiter
+	rateLimit      *ratelimit.Limiter
+	requestTimeout time.Duration // deadline of each request attempt
+	retry          RetryConfig   // backoff between attempts
+	breaker        circuitBreaker // fails fast during a GitHub outage
+	logQueryCost   bool          // request and log the rate limit cost of each query
+	sessionCost    int           // total cost of the queries executed by this client
+	costMutex      sync.Mutex    // protects sessionCost
+}
+
+// queryCostFragment is added to the top-level selection set of queries when cost logging is enabled
+const queryCostFragment = "rateLimit { cost remaining }"
+
+// queryCostResponse is the part of a GraphQL response carrying the query cost
+type queryCostResponse struct {
+	RateLimit *struct {
+		Cost      int `json:"cost"`
+		Remaining int `json:"remaining"`
+	} `json:"rateLimit"`
 }
 
 // GraphQLRequest represents a GitHub GraphQL API request
@@ -63,25 +100,63 @@ type GraphQLError struct {
 	Path    []interface{} `json:"path,omitempty"`
 }
 
+// ErrUserNotFound is returned when the requested GitHub login does not exist
+var ErrUserNotFound = errors.New("no such GitHub user")
+
+// ErrOrganizationNotFound is returned when the requested GitHub organization does not exist
+var ErrOrganizationNotFound = errors.New("no such GitHub organization")
+
+// GraphQLErrors is returned when GitHub answers a query with non-retryable errors
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	return fmt.Sprintf("GraphQL errors: %+v", []GraphQLError(e))
+}
+
+// IsNotFoundError reports whether err carries a GraphQL NOT_FOUND error, which GitHub returns for
+// unknown logins ("Could not resolve to a User with the login of ...")
+func IsNotFoundError(err error) bool {
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) {
+		return false
+	}
+	for _, gqlErr := range gqlErrs {
+		if gqlErr.Type == "NOT_FOUND" || strings.Contains(gqlErr.Message, "Could not resolve to a User") {
+			return true
+		}
+	}
+	return false
+}
+
 // RetryableError wraps errors that can be retried
 type RetryableError struct {
-	Err       error
-	ShouldLog bool
+	Err        error
+	ShouldLog  bool
+	RetryAfter time.Duration // delay requested by GitHub, replacing the computed backoff when set
 }
 
 func (e *RetryableError) Error() string {
 	return e.Err.Error()
 }
 
+// DefaultRequestsPerSecond is the conservative request rate of NewClient
+const DefaultRequestsPerSecond = 1.0
+
 // NewClient creates a new GitHub API client
 func NewClient(token string) *Client {
+	return NewClientWithRateLimit(token, DefaultRequestsPerSecond, 1)
+}
+
+// NewClientWithRateLimit creates a GitHub API client sending at most rps requests per second,
+// in bursts of up to burst requests. Non-positive values fall back to the defaults.
+func NewClientWithRateLimit(token string, rps float64, burst int) *Client {
 	src := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
 
 	httpClient := &http.Client{
 		Transport: &oauth2.Transport{Source: src},
-		Timeout:   30 * time.Second,
+		Timeout:   DefaultRequestTimeout,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if len(via) >= 10 {
 				return fmt.Errorf("stopped after 10 redirects")
@@ -90,31 +165,161 @@ func NewClient(token string) *Client {
 		},
 	}
 
-	// Rate limiter: 1 request per second (conservative approach)
-	limiter := rate.NewLimiter(rate.Every(time.Second), 1)
+	// Rate limiter: 1 request per second unless configured (conservative approach)
+	if rps <= 0 {
+		rps = DefaultRequestsPerSecond
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	limiter := rate.NewLimiter(rate.Limit(rps), burst)
 
 	return &Client{
-		httpClient: httpClient,
-		endpoint:   githubGraphQLEndpoint,
-		limiter:    limiter,
-		rateLimitInfo: &RateLimitInfo{
-			Limit:     5000, // Default GraphQL limit
-			Remaining: 5000,
-			ResetTime: time.Now().Add(time.Hour),
-			Resource:  "graphql",
-		},
+		httpClient:     httpClient,
+		endpoint:       githubGraphQLEndpoint,
+		restEndpoint:   githubRESTEndpoint,
+		limiter:        limiter,
+		requestTimeout: DefaultRequestTimeout,
+		retry:          DefaultRetryConfig,
+		rateLimit:      newRateLimiter(),
+	}
+}
+
+// newRateLimiter creates the limiter tracking the GitHub rate limit, logging through the logging
+// package and exporting the remaining budget as a metric
+func newRateLimiter() *ratelimit.Limiter {
+	return ratelimit.New(5000, "graphql"). // Default GraphQL limit
+		WithLogger(rateLimitLogger{}).
+		WithObserver(func(status ratelimit.Status) {
+			metrics.RateLimitRemaining.Set(int64(status.Remaining))
+		})
+}
+
+// rateLimitLogger sends the rate limiter's messages to the logging package
+type rateLimitLogger struct{}
+
+func (rateLimitLogger) Debugf(format string, args ...interface{}) { logging.Debugf(format, args...) }
+func (rateLimitLogger) Infof(format string, args ...interface{})  { logging.Infof(format, args...) }
+func (rateLimitLogger) Warnf(format string, args ...interface{})  { logging.Warnf(format, args...) }
+
+// WithEndpoint overrides the GraphQL endpoint, e.g. for GitHub Enterprise or tests
+func (c *Client) WithEndpoint(endpoint string) *Client {
+	c.endpoint = endpoint
+	return c
+}
+
+// WithRESTEndpoint overrides the REST API base URL, e.g. for GitHub Enterprise or tests
+func (c *Client) WithRESTEndpoint(endpoint string) *Client {
+	c.restEndpoint = strings.TrimSuffix(endpoint, "/")
+	return c
+}
+
+// WithRequestTimeout sets how long a single request may take before it fails and is retried.
+// Non-positive values keep the current timeout.
+func (c *Client) WithRequestTimeout(timeout time.Duration) *Client {
+	if timeout > 0 {
+		c.requestTimeout = timeout
+		c.httpClient.Timeout = timeout
+	}
+	return c
+}
+
+// WithRetryConfig overrides the backoff between attempts, e.g. to use tiny delays in tests.
+// Zero-valued fields keep the current setting; use WithoutJitter to make delays exact and
+// WithoutBreaker to never fail fast.
+func (c *Client) WithRetryConfig(config RetryConfig) *Client {
+	if config.MaxRetries > 0 {
+		c.retry.MaxRetries = config.MaxRetries
+	}
+	if config.BaseDelay > 0 {
+		c.retry.BaseDelay = config.BaseDelay
+	}
+	if config.MaxDelay > 0 {
+		c.retry.MaxDelay = config.MaxDelay
+	}
+	if config.Jitter > 0 {
+		c.retry.Jitter = config.Jitter
+	}
+	if config.InfrastructureBaseDelay > 0 {
+		c.retry.InfrastructureBaseDelay = config.InfrastructureBaseDelay
+	}
+	if config.InfrastructureJitter > 0 {
+		c.retry.InfrastructureJitter = config.InfrastructureJitter
 	}
+	if config.BreakerThreshold > 0 {
+		c.retry.BreakerThreshold = config.BreakerThreshold
+	}
+	if config.BreakerCooldown > 0 {
+		c.retry.BreakerCooldown = config.BreakerCooldown
+	}
+	return c
+}
+
+// WithoutJitter removes the random extra delay from every backoff, including after infrastructure errors
+func (c *Client) WithoutJitter() *Client {
+	c.retry.Jitter = 0
+	c.retry.InfrastructureJitter = 0
+	return c
+}
+
+// WithoutBreaker disables the circuit breaker, so every request is retried up to MaxRetries even
+// during a GitHub outage
+func (c *Client) WithoutBreaker() *Client {
+	c.retry.BreakerThreshold = 0
+	return c
+}
+
+// WithQueryCost enables logging the rate limit cost of every GraphQL query
+func (c *Client) WithQueryCost() *Client {
+	c.logQueryCost = true
+	return c
+}
+
+// GetSessionCost returns the total rate limit cost of the queries executed so far.
+// Costs are only known when query cost logging is enabled.
+func (c *Client) GetSessionCost() int {
+	c.costMutex.Lock()
+	defer c.costMutex.Unlock()
+	return c.sessionCost
+}
+
+// withQueryCost adds the rate limit cost fragment to the top-level selection set of query
+func withQueryCost(query string) string {
+	end := strings.LastIndex(query, "}")
+	if end < 0 || strings.Contains(query, "rateLimit") {
+		return query
+	}
+	return query[:end] + "  " + queryCostFragment + "\n" + query[end:]
+}
+
+// recordQueryCost logs and accumulates the cost found in the data of a GraphQL response
+func (c *Client) recordQueryCost(data json.RawMessage) {
+	var costResp queryCostResponse
+	if err := json.Unmarshal(data, &costResp); err != nil || costResp.RateLimit == nil {
+		return
+	}
+
+	c.costMutex.Lock()
+	c.sessionCost += costResp.RateLimit.Cost
+	total := c.sessionCost
+	c.costMutex.Unlock()
+
+	logging.Infof("GraphQL query cost: %d (session total: %d, remaining: %d)",
+		costResp.RateLimit.Cost, total, costResp.RateLimit.Remaining)
 }
 
 // ExecuteGraphQL executes a GraphQL query with retry logic
 func (c *Client) ExecuteGraphQL(ctx context.Context, req *GraphQLRequest, result interface{}) error {
 	// Check rate limit before attempting request
-	if err := c.checkRateLimit(ctx); err != nil {
+	if err := c.rateLimit.Wait(ctx); err != nil {
 		return err
 	}
 
 	return c.executeWithRetry(ctx, func() error {
-		return c.executeGraphQLRequest(ctx, req, result)
+		// Each attempt gets its own deadline so that one stuck request fails fast and is retried
+		reqCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+		return c.executeGraphQLRequest(reqCtx, req, result)
 	})
 }
 
@@ -122,16 +327,25 @@ func (c *Client) ExecuteGraphQL(ctx context.Context, req *GraphQLRequest, result
 func (c *Client) executeWithRetry(ctx context.Context, operation func() error) error {
 	var lastErr error
 
+	maxRetries := c.retry.MaxRetries
 	for attempt := 0; attempt < maxRetries; attempt++ {
+		// Fail fast instead of waiting and retrying while GitHub appears to be down
+		if err := c.breaker.allow(); err != nil {
+			return err
+		}
+
 		if attempt > 0 {
 			// Use appropriate backoff strategy based on previous error
 			var delay time.Duration
-			if isInfrastructureError(lastErr) {
-				delay = calculateBackoffDurationForInfrastructureError(attempt)
-				log.Printf("Infrastructure error detected, using extended backoff: %v (attempt %d/%d)", delay, attempt+1, maxRetries)
+			if retryableErr, ok := lastErr.(*RetryableError); ok && retryableErr.RetryAfter > 0 {
+				delay = retryableErr.RetryAfter
+				logging.Infof("Rate limited, waiting %v as requested by GitHub (attempt %d/%d)", delay, attempt+1, maxRetries)
+			} else if isInfrastructureError(lastErr) {
+				delay = c.calculateBackoffDurationForInfrastructureError(attempt)
+				logging.Warnf("Infrastructure error detected, using extended backoff: %v (attempt %d/%d)", delay, attempt+1, maxRetries)
 			} else {
-				delay = calculateBackoffDuration(attempt)
-				log.Printf("Retrying in %v (attempt %d/%d)", delay, attempt+1, maxRetries)
+				delay = c.calculateBackoffDuration(attempt)
+				logging.Infof("Retrying in %v (attempt %d/%d)", delay, attempt+1, maxRetries)
 			}
 
 			select {
@@ -147,6 +361,7 @@ func (c *Client) executeWithRetry(ctx context.Context, operation func() error) e
 		}
 
 		err := operation()
+		c.breaker.record(err, c.retry.BreakerThreshold, c.retry.BreakerCooldown)
 		if err == nil {
 			return nil
 		}
@@ -159,7 +374,7 @@ func (c *Client) executeWithRetry(ctx context.Context, operation func() error) e
 		}
 
 		if retryableErr, ok := err.(*RetryableError); ok && retryableErr.ShouldLog {
-			log.Printf("Retryable error (attempt %d/%d): %v", attempt+1, maxRetries, err)
+			logging.Warnf("Retryable error (attempt %d/%d): %v", attempt+1, maxRetries, err)
 		}
 	}
 
@@ -168,12 +383,16 @@ func (c *Client) executeWithRetry(ctx context.Context, operation func() error) e
 
 // executeGraphQLRequest performs the actual GraphQL request
 func (c *Client) executeGraphQLRequest(ctx context.Context, req *GraphQLRequest, result interface{}) error {
-	log.Printf("Marshaling GraphQL request...")
+	if c.logQueryCost {
+		req = &GraphQLRequest{Query: withQueryCost(req.Query), Variables: req.Variables}
+	}
+
+	logging.Debugf("Marshaling GraphQL request...")
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return fmt.Errorf("failed to marshal GraphQL request: %w", err)
 	}
-	log.Printf("GraphQL request marshaled, size: %d bytes", len(jsonData))
+	logging.Debugf("GraphQL request marshaled, size: %d bytes", len(jsonData))
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
@@ -182,8 +401,8 @@ func (c *Client) executeGraphQLRequest(ctx context.Context, req *GraphQLRequest,
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	log.Printf("Sending HTTP request to GitHub API...")
-	resp, err := c.httpClient.Do(httpReq)
+	logging.Debugf("Sending HTTP request to GitHub API...")
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return &RetryableError{
 			Err:       fmt.Errorf("HTTP request failed: %w", err),
@@ -191,40 +410,50 @@ func (c *Client) executeGraphQLRequest(ctx context.Context, req *GraphQLRequest,
 		}
 	}
 	defer resp.Body.Close()
-	log.Printf("HTTP response received, status: %d", resp.StatusCode)
+	logging.Debugf("HTTP response received, status: %d", resp.StatusCode)
 
 	// Parse and update rate limit information from headers
-	c.updateRateLimitFromHeaders(resp.Header)
+	c.rateLimit.Update(resp.Header)
 
-	log.Printf("Reading response body...")
+	logging.Debugf("Reading response body...")
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
+		// A request that ran out of time while streaming the body is retried like any other timeout
+		if ctx.Err() == context.DeadlineExceeded {
+			return &RetryableError{
+				Err:       fmt.Errorf("request timed out reading response body: %w", err),
+				ShouldLog: true,
+			}
+		}
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
-	log.Printf("Response body read, size: %d bytes", len(body))
+	logging.Debugf("Response body read, size: %d bytes", len(body))
 
 	// Handle HTTP errors
 	if resp.StatusCode != http.StatusOK {
 		// Special handling for rate limit responses
-		if resp.StatusCode == 403 {
+		if resp.StatusCode == 403 || resp.StatusCode == http.StatusTooManyRequests {
 			// Check if this is a rate limit error by examining response body or headers
-			c.rateLimitInfo.mutex.RLock()
-			remaining := c.rateLimitInfo.Remaining
-			resetTime := c.rateLimitInfo.ResetTime
-			c.rateLimitInfo.mutex.RUnlock()
+			status := c.rateLimit.Status()
+			remaining, resetTime := status.Remaining, status.ResetTime
 
-			if remaining <= 0 ||
+			if resp.StatusCode == http.StatusTooManyRequests ||
+			   remaining <= 0 ||
 			   contains(strings.ToLower(string(body)), "rate limit") ||
 			   contains(strings.ToLower(string(body)), "api rate limit exceeded") {
 
-				log.Printf("GitHub API rate limit exceeded (HTTP 403)")
-				// This is a rate limit error, wait until reset
-				waitDuration := time.Until(resetTime)
+				logging.Warnf("GitHub API rate limit exceeded (HTTP %d)", resp.StatusCode)
+				// This is a rate limit error, wait as long as GitHub asks or until the tracked reset
+				waitDuration := retryAfterFromHeaders(resp.Header, resetTime)
+				if waitDuration <= 0 && resp.StatusCode == 403 {
+					waitDuration = time.Until(resetTime)
+				}
 				if waitDuration > 0 && waitDuration < 2*time.Hour {
-					log.Printf("Waiting %v for rate limit reset", waitDuration)
+					logging.Infof("Waiting %v for rate limit reset", waitDuration)
 					return &RetryableError{
-						Err:       fmt.Errorf("rate limit exceeded, waiting until reset"),
-						ShouldLog: false, // Don't spam logs
+						Err:        fmt.Errorf("rate limit exceeded, waiting until reset"),
+						ShouldLog:  false, // Don't spam logs
+						RetryAfter: waitDuration,
 					}
 				}
 			}
@@ -239,12 +468,12 @@ func (c *Client) executeGraphQLRequest(ctx context.Context, req *GraphQLRequest,
 		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
 	}
 
-	log.Printf("Unmarshaling GraphQL response envelope...")
+	logging.Debugf("Unmarshaling GraphQL response envelope...")
 	var graphqlResp GraphQLResponse
 	if err := json.Unmarshal(body, &graphqlResp); err != nil {
 		return fmt.Errorf("failed to unmarshal GraphQL response: %w", err)
 	}
-	log.Printf("GraphQL response envelope unmarshaled successfully")
+	logging.Debugf("GraphQL response envelope unmarshaled successfully")
 
 	// Handle GraphQL errors
 	if len(graphqlResp.Errors) > 0 {
@@ -256,43 +485,58 @@ func (c *Client) executeGraphQLRequest(ctx context.Context, req *GraphQLRequest,
 				}
 			}
 		}
-		return fmt.Errorf("GraphQL errors: %+v", graphqlResp.Errors)
+		return GraphQLErrors(graphqlResp.Errors)
+	}
+
+	if c.logQueryCost {
+		c.recordQueryCost(graphqlResp.Data)
 	}
 
-	log.Printf("Unmarshaling GraphQL data into result structure (size: %d bytes)...", len(graphqlResp.Data))
+	logging.Debugf("Unmarshaling GraphQL data into result structure (size: %d bytes)...", len(graphqlResp.Data))
 	if err := json.Unmarshal(graphqlResp.Data, result); err != nil {
 		return fmt.Errorf("failed to unmarshal GraphQL data: %w", err)
 	}
-	log.Printf("GraphQL data unmarshaled successfully into result")
+	logging.Debugf("GraphQL data unmarshaled successfully into result")
 
 	return nil
 }
 
-// calculateBackoffDuration calculates exponential backoff with jitter
-func calculateBackoffDuration(attempt int) time.Duration {
-	delay := baseDelay * time.Duration(1<<uint(attempt))
-	if delay > maxDelay {
-		delay = maxDelay
+// do sends req, counting it and any failure or error status other than 404 in the process metrics
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	metrics.GitHubRequests.Inc()
+	resp, err := c.httpClient.Do(req)
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound) {
+		metrics.GitHubRequestErrors.Inc()
 	}
-
-	// Add jitter (up to 20% of delay) for better distribution
-	jitter := time.Duration(rand.Float64() * 0.2 * float64(delay))
-	return delay + jitter
+	return resp, err
 }
 
-// calculateBackoffDurationForInfrastructureError calculates longer backoff for infrastructure errors
-func calculateBackoffDurationForInfrastructureError(attempt int) time.Duration {
-	// Use longer base delay for infrastructure issues
-	infrastructureBaseDelay := 10 * time.Second
-	delay := infrastructureBaseDelay * time.Duration(1<<uint(attempt))
+// retryAfterFromHeaders returns the delay GitHub asks for before retrying: the Retry-After seconds,
+// or the time until resetTime when an X-RateLimit-Reset header was sent. It returns 0 when neither is set.
+func retryAfterFromHeaders(headers http.Header, resetTime time.Time) time.Duration {
+	if retryAfter := headers.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
 
-	if delay > maxDelay {
-		delay = maxDelay
+	// the rate limiter has already parsed the reset into resetTime
+	if headers.Get("X-RateLimit-Reset") != "" {
+		return time.Until(resetTime)
 	}
 
-	// Add jitter (up to 30% of delay)
-	jitter := time.Duration(rand.Float64() * 0.3 * float64(delay))
-	return delay + jitter
+	return 0
+}
+
+// calculateBackoffDuration calculates exponential backoff with jitter
+func (c *Client) calculateBackoffDuration(attempt int) time.Duration {
+	return ratelimit.Backoff(c.retry.BaseDelay, c.retry.MaxDelay, c.retry.Jitter, attempt)
+}
+
+// calculateBackoffDurationForInfrastructureError calculates longer backoff for infrastructure errors
+func (c *Client) calculateBackoffDurationForInfrastructureError(attempt int) time.Duration {
+	// Use longer base delay for infrastructure issues
+	return ratelimit.Backoff(c.retry.InfrastructureBaseDelay, c.retry.MaxDelay, c.retry.InfrastructureJitter, attempt)
 }
 
 // isRetryableError determines if an error should be retried
@@ -419,143 +663,9 @@ func indexOf(s, substr string) int {
 	return -1
 }
 
-// checkRateLimit checks if we can make a request without exceeding rate limits
-func (c *Client) checkRateLimit(ctx context.Context) error {
-	c.rateLimitInfo.mutex.RLock()
-	remaining := c.rateLimitInfo.Remaining
-	resetTime := c.rateLimitInfo.ResetTime
-	limit := c.rateLimitInfo.Limit
-	updated := c.rateLimitInfo.Updated
-	c.rateLimitInfo.mutex.RUnlock()
-
-	now := time.Now()
-
-	// If we haven't received actual rate limit data yet, proceed with caution
-	if !updated {
-		log.Printf("Rate limit check: No API data yet, proceeding cautiously")
-		return nil
-	}
-
-	// If rate limit window has reset, we're good to go
-	if now.After(resetTime) {
-		log.Printf("Rate limit window has reset, proceeding with request")
-		return nil
-	}
-
-	// If we have plenty of requests remaining (>10% of limit), proceed
-	if remaining > limit/10 {
-		log.Printf("Rate limit check: %d/%d requests remaining", remaining, limit)
-		return nil
-	}
-
-	// If we're running low on requests, implement smart waiting
-	if remaining <= 0 {
-		waitDuration := time.Until(resetTime)
-		log.Printf("Rate limit exceeded, waiting %v until reset (%v)", waitDuration, resetTime.Format(time.RFC3339))
-
-		select {
-		case <-time.After(waitDuration):
-			log.Printf("Rate limit window reset, proceeding")
-			return nil
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-	}
-
-	// If we're running low but not empty, implement intelligent throttling
-	if remaining < limit/20 { // Less than 5% remaining
-		// Calculate how much time is left in the window
-		timeRemaining := time.Until(resetTime)
-		// Space out remaining requests evenly across remaining time
-		waitTime := timeRemaining / time.Duration(remaining+1)
-
-		// Don't wait more than 30 seconds for a single request
-		if waitTime > 30*time.Second {
-			waitTime = 30 * time.Second
-		}
-
-		log.Printf("Rate limit low (%d/%d), throttling request by %v", remaining, limit, waitTime)
-
-		select {
-		case <-time.After(waitTime):
-			return nil
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-	}
-
-	return nil
-}
-
-// updateRateLimitFromHeaders parses GitHub rate limit headers and updates our tracking
-func (c *Client) updateRateLimitFromHeaders(headers http.Header) {
-	c.rateLimitInfo.mutex.Lock()
-	defer c.rateLimitInfo.mutex.Unlock()
-
-	// GitHub GraphQL API uses these headers:
-	// X-RateLimit-Limit: Maximum number of requests per hour
-	// X-RateLimit-Remaining: Number of requests remaining
-	// X-RateLimit-Reset: Unix timestamp when the rate limit resets
-	// X-RateLimit-Used: Number of requests used
-	// X-RateLimit-Resource: The rate limit resource (graphql, core, etc.)
-
-	if limitStr := headers.Get("X-RateLimit-Limit"); limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil {
-			c.rateLimitInfo.Limit = limit
-		}
-	}
-
-	if remainingStr := headers.Get("X-RateLimit-Remaining"); remainingStr != "" {
-		if remaining, err := strconv.Atoi(remainingStr); err == nil {
-			c.rateLimitInfo.Remaining = remaining
-		}
-	}
-
-	if resetStr := headers.Get("X-RateLimit-Reset"); resetStr != "" {
-		if resetUnix, err := strconv.ParseInt(resetStr, 10, 64); err == nil {
-			c.rateLimitInfo.ResetTime = time.Unix(resetUnix, 0)
-		}
-	}
-
-	if usedStr := headers.Get("X-RateLimit-Used"); usedStr != "" {
-		if used, err := strconv.Atoi(usedStr); err == nil {
-			c.rateLimitInfo.Used = used
-		}
-	}
-
-	if resource := headers.Get("X-RateLimit-Resource"); resource != "" {
-		c.rateLimitInfo.Resource = resource
-	}
-
-	// Mark that we've received actual rate limit data from API
-	c.rateLimitInfo.Updated = true
-
-	// Log rate limit status for monitoring
-	log.Printf("GitHub API Rate Limit - Resource: %s, Used: %d/%d, Remaining: %d, Resets: %v",
-		c.rateLimitInfo.Resource, c.rateLimitInfo.Used, c.rateLimitInfo.Limit,
-		c.rateLimitInfo.Remaining, c.rateLimitInfo.ResetTime.Format(time.RFC3339))
-
-	// Warn if getting close to rate limit
-	if c.rateLimitInfo.Remaining < c.rateLimitInfo.Limit/10 { // Less than 10%
-		percentRemaining := float64(c.rateLimitInfo.Remaining) / float64(c.rateLimitInfo.Limit) * 100
-		log.Printf("⚠️  GitHub API rate limit warning: Only %.1f%% (%d) requests remaining until %v",
-			percentRemaining, c.rateLimitInfo.Remaining, c.rateLimitInfo.ResetTime.Format("15:04:05"))
-	}
-}
-
 // GetRateLimitStatus returns current rate limit information for monitoring
 func (c *Client) GetRateLimitStatus() RateLimitInfo {
-	c.rateLimitInfo.mutex.RLock()
-	defer c.rateLimitInfo.mutex.RUnlock()
-
-	// Return a copy to avoid race conditions
-	return RateLimitInfo{
-		Limit:     c.rateLimitInfo.Limit,
-		Remaining: c.rateLimitInfo.Remaining,
-		ResetTime: c.rateLimitInfo.ResetTime,
-		Used:      c.rateLimitInfo.Used,
-		Resource:  c.rateLimitInfo.Resource,
-	}
+	return c.rateLimit.Status()
 }
 
 // RepositoryContentResponse represents GitHub REST API response for repository contents
@@ -571,11 +681,70 @@ type RepositoryContentResponse struct {
 
 // FetchRepositoryContents fetches repository root directory contents via REST API
 func (c *Client) FetchRepositoryContents(ctx context.Context, owner, repo string) ([]RepositoryContentResponse, error) {
-	var contents []RepositoryContentResponse
+	return c.FetchDirectoryContents(ctx, owner, repo, "")
+}
 
-	err := c.executeWithRetry(ctx, func() error {
-		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents", owner, repo)
+// FetchDirectoryContents fetches the contents of a repository directory, such as ".github", via REST API.
+// A missing directory yields no contents.
+func (c *Client) FetchDirectoryContents(ctx context.Context, owner, repo, path string) ([]RepositoryContentResponse, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/contents", c.restEndpoint, owner, repo)
+	if path != "" {
+		url += "/" + strings.Trim(path, "/")
+	}
+
+	contents := []RepositoryContentResponse{}
+	if err := c.getREST(ctx, url, &contents); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+// FetchRepositoryLanguages fetches the bytes of code per language of a repository via REST API.
+// A missing repository yields no languages.
+func (c *Client) FetchRepositoryLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	languages := map[string]int{}
+	if err := c.getREST(ctx, fmt.Sprintf("%s/repos/%s/%s/languages", c.restEndpoint, owner, repo), &languages); err != nil {
+		return nil, err
+	}
+	return languages, nil
+}
+
+// UserRESTResponse represents GitHub REST API response for a user
+type UserRESTResponse struct {
+	Login           string    `json:"login"`
+	Name            string    `json:"name"`
+	Bio             string    `json:"bio"`
+	Company         string    `json:"company"`
+	Location        string    `json:"location"`
+	Email           string    `json:"email"`
+	Blog            string    `json:"blog"`
+	TwitterUsername string    `json:"twitter_username"`
+	AvatarURL       string    `json:"avatar_url"`
+	PublicRepos     int       `json:"public_repos"`
+	PublicGists     int       `json:"public_gists"`
+	Followers       int       `json:"followers"`
+	Following       int       `json:"following"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
 
+// FetchUser fetches a user's public profile via REST API, which needs no token scope.
+// A missing user yields ErrUserNotFound.
+func (c *Client) FetchUser(ctx context.Context, username string) (*UserRESTResponse, error) {
+	var user UserRESTResponse
+	if err := c.getREST(ctx, fmt.Sprintf("%s/users/%s", c.restEndpoint, username), &user); err != nil {
+		return nil, err
+	}
+	if user.Login == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, username)
+	}
+	return &user, nil
+}
+
+// getREST decodes the JSON response of a REST GET request into result, retrying on rate limits and
+// transient failures. A 404 leaves result untouched.
+func (c *Client) getREST(ctx context.Context, url string, result interface{}) error {
+	return c.executeWithRetry(ctx, func() error {
 		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return fmt.Errorf("failed to create request: %w", err)
@@ -584,7 +753,7 @@ func (c *Client) FetchRepositoryContents(ctx context.Context, owner, repo string
 		req.Header.Set("Accept", "application/vnd.github.v3+json")
 		req.Header.Set("User-Agent", "github-profile-tools/1.0")
 
-		resp, err := c.httpClient.Do(req)
+		resp, err := c.do(req)
 		if err != nil {
 			return &RetryableError{
 				Err:       fmt.Errorf("failed to execute request: %w", err),
@@ -594,11 +763,10 @@ func (c *Client) FetchRepositoryContents(ctx context.Context, owner, repo string
 		defer resp.Body.Close()
 
 		// Parse and update rate limit information from headers
-		c.updateRateLimitFromHeaders(resp.Header)
+		c.rateLimit.Update(resp.Header)
 
 		if resp.StatusCode == 404 {
 			// Repository not found or contents are empty
-			contents = []RepositoryContentResponse{}
 			return nil
 		}
 
@@ -610,20 +778,18 @@ func (c *Client) FetchRepositoryContents(ctx context.Context, owner, repo string
 		if resp.StatusCode != 200 {
 			// Handle rate limit errors (403)
 			if resp.StatusCode == 403 {
-				c.rateLimitInfo.mutex.RLock()
-				remaining := c.rateLimitInfo.Remaining
-				resetTime := c.rateLimitInfo.ResetTime
-				c.rateLimitInfo.mutex.RUnlock()
+				status := c.rateLimit.Status()
+				remaining, resetTime := status.Remaining, status.ResetTime
 
 				if remaining <= 0 ||
 					contains(strings.ToLower(string(body)), "rate limit") ||
 					contains(strings.ToLower(string(body)), "api rate limit exceeded") {
 
-					log.Printf("GitHub REST API rate limit exceeded (HTTP 403)")
+					logging.Warnf("GitHub REST API rate limit exceeded (HTTP 403)")
 					// Calculate wait duration until rate limit resets
 					waitDuration := time.Until(resetTime)
 					if waitDuration > 0 {
-						log.Printf("Waiting %v until rate limit resets at %v", waitDuration, resetTime)
+						logging.Infof("Waiting %v until rate limit resets at %v", waitDuration, resetTime)
 						select {
 						case <-time.After(waitDuration + 10*time.Second):
 							// Add 10 seconds buffer after reset
@@ -643,12 +809,70 @@ func (c *Client) FetchRepositoryContents(ctx context.Context, owner, repo string
 			return fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, string(body))
 		}
 
-		if err := json.Unmarshal(body, &contents); err != nil {
+		if err := json.Unmarshal(body, result); err != nil {
 			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 
 		return nil
 	})
+}
+
+// FetchReadme returns the README at the root of a repository, or an empty string when it has none
+func (c *Client) FetchReadme(ctx context.Context, owner, repo string) (string, error) {
+	contents, err := c.FetchRepositoryContents(ctx, owner, repo)
+	if err != nil {
+		return "", err
+	}
+
+	var downloadURL string
+	for _, item := range contents {
+		if item.Type == "file" && strings.HasPrefix(strings.ToLower(item.Name), "readme") && item.DownloadURL != "" {
+			downloadURL = item.DownloadURL
+			break
+		}
+	}
+	if downloadURL == "" {
+		return "", nil
+	}
+
+	var readme string
+	err = c.executeWithRetry(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("User-Agent", "github-profile-tools/1.0")
+
+		resp, err := c.do(req)
+		if err != nil {
+			return &RetryableError{
+				Err:       fmt.Errorf("failed to download README: %w", err),
+				ShouldLog: true,
+			}
+		}
+		defer resp.Body.Close()
+
+		body, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return fmt.Errorf("failed to read README: %w", readErr)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			readme = string(body)
+			return nil
+		case resp.StatusCode == http.StatusNotFound:
+			return nil
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			return &RetryableError{
+				Err:        fmt.Errorf("README download returned status %d", resp.StatusCode),
+				ShouldLog:  true,
+				RetryAfter: retryAfterFromHeaders(resp.Header, time.Time{}),
+			}
+		default:
+			return fmt.Errorf("README download returned status %d", resp.StatusCode)
+		}
+	})
 
-	return contents, err
-}
\ No newline at end of file
+	return readme, err
+}
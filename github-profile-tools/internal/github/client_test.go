@@ -0,0 +1,307 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// newTestClient returns a client for server without client-side throttling
+func newTestClient(server *httptest.Server) *Client {
+	client := NewClient("test-token").WithEndpoint(server.URL)
+	client.limiter = rate.NewLimiter(rate.Inf, 1)
+	return client
+}
+
+// TestQueryCostSummed verifies the cost fragment is requested and the returned costs are summed
+func TestQueryCostSummed(t *testing.T) {
+	costs := []int{3, 5}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !strings.Contains(req.Query, queryCostFragment) {
+			t.Errorf("Expected the query to request its cost, got %q", req.Query)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":{"viewer":{"login":"testuser"},"rateLimit":{"cost":%d,"remaining":4990}}}`, costs[requests])
+		requests++
+	}))
+	defer server.Close()
+
+	client := newTestClient(server).WithQueryCost()
+	for range costs {
+		var result struct {
+			Viewer struct {
+				Login string `json:"login"`
+			} `json:"viewer"`
+		}
+		req := &GraphQLRequest{Query: "query { viewer { login } }"}
+		if err := client.ExecuteGraphQL(context.Background(), req, &result); err != nil {
+			t.Fatalf("ExecuteGraphQL failed: %v", err)
+		}
+		if result.Viewer.Login != "testuser" {
+			t.Errorf("Expected the result to be decoded, got %+v", result)
+		}
+	}
+
+	if cost := client.GetSessionCost(); cost != 8 {
+		t.Errorf("Expected session cost 8, got %d", cost)
+	}
+}
+
+// TestWithQueryCost verifies the cost fragment is added to the top-level selection set
+func TestWithQueryCost(t *testing.T) {
+	query := withQueryCost("query($login: String!) {\n  user(login: $login) { name }\n}")
+	expected := "query($login: String!) {\n  user(login: $login) { name }\n  rateLimit { cost remaining }\n}"
+	if query != expected {
+		t.Errorf("Expected %q, got %q", expected, query)
+	}
+
+	if query := withQueryCost(expected); query != expected {
+		t.Errorf("Expected a query already requesting its cost to be unchanged, got %q", query)
+	}
+}
+
+// TestRetryAfterHonored verifies a 429 with Retry-After: 2 is retried after about two seconds
+func TestRetryAfterHonored(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"You have exceeded a secondary rate limit"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"viewer":{"login":"testuser"}}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	var result map[string]interface{}
+	start := time.Now()
+	if err := client.ExecuteGraphQL(context.Background(), &GraphQLRequest{Query: "query { viewer { login } }"}, &result); err != nil {
+		t.Fatalf("ExecuteGraphQL failed: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if requests != 2 {
+		t.Errorf("Expected one retry, got %d requests", requests)
+	}
+	// The computed backoff would wait at least BaseDelay*2
+	if elapsed < 2*time.Second || elapsed >= 2*DefaultRetryConfig.BaseDelay {
+		t.Errorf("Expected a wait of about 2s, got %v", elapsed)
+	}
+}
+
+// TestRetryAfterFromHeaders verifies Retry-After takes precedence over X-RateLimit-Reset
+func TestRetryAfterFromHeaders(t *testing.T) {
+	reset := time.Now().Add(time.Minute)
+
+	headers := http.Header{}
+	if wait := retryAfterFromHeaders(headers, reset); wait != 0 {
+		t.Errorf("Expected no wait without headers, got %v", wait)
+	}
+
+	headers.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	if wait := retryAfterFromHeaders(headers, reset); wait <= 50*time.Second || wait > time.Minute {
+		t.Errorf("Expected a wait until the reset, got %v", wait)
+	}
+
+	headers.Set("Retry-After", "5")
+	if wait := retryAfterFromHeaders(headers, reset); wait != 5*time.Second {
+		t.Errorf("Expected Retry-After to win, got %v", wait)
+	}
+}
+
+// TestNewClientWithRateLimit verifies the limiter is configured to the requested rate and burst
+func TestNewClientWithRateLimit(t *testing.T) {
+	client := NewClientWithRateLimit("test-token", 5, 3)
+	if client.limiter.Limit() != rate.Limit(5) || client.limiter.Burst() != 3 {
+		t.Errorf("Expected 5 rps with burst 3, got %v rps with burst %d", client.limiter.Limit(), client.limiter.Burst())
+	}
+
+	client = NewClientWithRateLimit("test-token", 0, -1)
+	if client.limiter.Limit() != rate.Limit(DefaultRequestsPerSecond) || client.limiter.Burst() != 1 {
+		t.Errorf("Expected the default rate for invalid values, got %v rps with burst %d", client.limiter.Limit(), client.limiter.Burst())
+	}
+
+	client = NewClient("test-token")
+	if client.limiter.Limit() != rate.Limit(1) {
+		t.Errorf("Expected NewClient to keep 1 rps, got %v", client.limiter.Limit())
+	}
+}
+
+// TestRequestTimeoutRetries verifies a request outlasting the per-request timeout fails fast and is retried
+func TestRequestTimeoutRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			time.Sleep(time.Second)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"viewer":{"login":"testuser"}}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server).WithRequestTimeout(100 * time.Millisecond).WithRetryConfig(RetryConfig{BaseDelay: 10 * time.Millisecond})
+	var result map[string]interface{}
+	start := time.Now()
+	if err := client.ExecuteGraphQL(context.Background(), &GraphQLRequest{Query: "query { viewer { login } }"}, &result); err != nil {
+		t.Fatalf("ExecuteGraphQL failed: %v", err)
+	}
+
+	if n := requests.Load(); n != 2 {
+		t.Errorf("Expected the timed out request to be retried, got %d requests", n)
+	}
+	if elapsed := time.Since(start); elapsed >= 500*time.Millisecond {
+		t.Errorf("Expected the slow request to be abandoned after the timeout, took %v", elapsed)
+	}
+}
+
+// TestIsNotFoundError verifies only NOT_FOUND GraphQL errors are reported as not found
+func TestIsNotFoundError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{GraphQLErrors{{Type: "NOT_FOUND", Message: "Could not resolve to a User with the login of 'x'."}}, true},
+		{fmt.Errorf("wrapped: %w", GraphQLErrors{{Message: "Could not resolve to a User with the login of 'x'."}}), true},
+		{GraphQLErrors{{Type: "FORBIDDEN", Message: "Resource not accessible"}}, false},
+		{errors.New("NOT_FOUND"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNotFoundError(tt.err); got != tt.want {
+			t.Errorf("IsNotFoundError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// TestRetryConfigShortensBackoff verifies a tiny base delay lets retries complete without real waits
+func TestRetryConfigShortensBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"viewer":{"login":"testuser"}}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server).WithRetryConfig(RetryConfig{
+		BaseDelay:               time.Millisecond,
+		InfrastructureBaseDelay: time.Millisecond,
+	})
+	var result map[string]interface{}
+	start := time.Now()
+	if err := client.ExecuteGraphQL(context.Background(), &GraphQLRequest{Query: "query { viewer { login } }"}, &result); err != nil {
+		t.Fatalf("ExecuteGraphQL failed: %v", err)
+	}
+
+	if n := requests.Load(); n != 3 {
+		t.Errorf("Expected two retries, got %d requests", n)
+	}
+	if elapsed := time.Since(start); elapsed >= 500*time.Millisecond {
+		t.Errorf("Expected retries to complete quickly, took %v", elapsed)
+	}
+	if client.retry.MaxRetries != DefaultRetryConfig.MaxRetries {
+		t.Errorf("Expected unset fields to keep their defaults, got %d retries", client.retry.MaxRetries)
+	}
+}
+
+// TestWithoutJitter verifies jitter can be turned off, which a zero Jitter in WithRetryConfig cannot do
+func TestWithoutJitter(t *testing.T) {
+	client := NewClient("test-token").WithRetryConfig(RetryConfig{Jitter: 0}).WithoutJitter()
+	if client.retry.Jitter != 0 || client.retry.InfrastructureJitter != 0 {
+		t.Fatalf("Expected no jitter, got %v and %v", client.retry.Jitter, client.retry.InfrastructureJitter)
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		expected := DefaultRetryConfig.BaseDelay << attempt
+		if delay := client.calculateBackoffDuration(attempt); delay != expected {
+			t.Errorf("Attempt %d: expected exactly %v, got %v", attempt, expected, delay)
+		}
+	}
+}
+
+// TestCircuitBreakerFailsFast verifies sustained 502s trip the breaker, cutting the retries short,
+// and that later calls fail without reaching GitHub
+func TestCircuitBreakerFailsFast(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server).WithRetryConfig(RetryConfig{
+		BaseDelay:               time.Millisecond,
+		InfrastructureBaseDelay: time.Millisecond,
+		BreakerThreshold:        3,
+		BreakerCooldown:         time.Minute,
+	})
+	req := &GraphQLRequest{Query: "query { viewer { login } }"}
+	var result map[string]interface{}
+
+	if err := client.ExecuteGraphQL(context.Background(), req, &result); !errors.Is(err, ErrGitHubDown) {
+		t.Fatalf("Expected ErrGitHubDown once the breaker trips, got %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("Expected the breaker to stop retries after 3 requests, got %d", n)
+	}
+
+	if err := client.ExecuteGraphQL(context.Background(), req, &result); !errors.Is(err, ErrGitHubDown) {
+		t.Errorf("Expected ErrGitHubDown while the breaker is open, got %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("Expected no request while the breaker is open, got %d in total", n)
+	}
+}
+
+// TestWithoutBreaker verifies sustained 502s exhaust the retries instead of tripping a disabled breaker
+func TestWithoutBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server).WithRetryConfig(RetryConfig{
+		MaxRetries:              4,
+		BaseDelay:               time.Millisecond,
+		InfrastructureBaseDelay: time.Millisecond,
+		BreakerThreshold:        2,
+	}).WithoutBreaker()
+	req := &GraphQLRequest{Query: "query { viewer { login } }"}
+	var result map[string]interface{}
+
+	err := client.ExecuteGraphQL(context.Background(), req, &result)
+	if err == nil || errors.Is(err, ErrGitHubDown) {
+		t.Fatalf("Expected the last 502 rather than ErrGitHubDown, got %v", err)
+	}
+	if n := requests.Load(); n != 4 {
+		t.Errorf("Expected all 4 attempts, got %d requests", n)
+	}
+}
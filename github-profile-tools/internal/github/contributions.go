@@ -0,0 +1,31 @@
+package github
+
+import "time"
+
+// ContributionWindow is a time range small enough for a single contributionsCollection query
+type ContributionWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// SplitContributionWindows splits [since, until) into consecutive windows of at most one year,
+// since GitHub rejects contributionsCollection queries spanning more than a year.
+// Each window ends one second before the next begins so no contribution day is counted twice.
+func SplitContributionWindows(since, until time.Time) []ContributionWindow {
+	var windows []ContributionWindow
+
+	for start := since; start.Before(until); {
+		end := start.AddDate(1, 0, 0)
+		if end.After(until) {
+			end = until
+		}
+
+		windows = append(windows, ContributionWindow{
+			From: start,
+			To:   end.Add(-time.Second),
+		})
+		start = end
+	}
+
+	return windows
+}
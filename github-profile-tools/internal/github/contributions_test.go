@@ -0,0 +1,64 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSplitContributionWindowsThreeYears verifies a three-year span is split into contiguous one-year windows
+func TestSplitContributionWindowsThreeYears(t *testing.T) {
+	since := time.Date(2020, 3, 15, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	windows := SplitContributionWindows(since, until)
+	if len(windows) != 3 {
+		t.Fatalf("Expected 3 windows, got %d", len(windows))
+	}
+
+	if !windows[0].From.Equal(since) {
+		t.Errorf("Expected first window to start at %s, got %s", since, windows[0].From)
+	}
+	if last := windows[len(windows)-1]; !last.To.Equal(until.Add(-time.Second)) {
+		t.Errorf("Expected last window to end at %s, got %s", until.Add(-time.Second), last.To)
+	}
+
+	for i, w := range windows {
+		if w.To.Sub(w.From) > w.From.AddDate(1, 0, 0).Sub(w.From) {
+			t.Errorf("Window %d spans more than one year: %s - %s", i, w.From, w.To)
+		}
+		if i > 0 && !w.From.Equal(windows[i-1].To.Add(time.Second)) {
+			t.Errorf("Window %d does not start right after window %d: %s vs %s", i, i-1, w.From, windows[i-1].To)
+		}
+	}
+}
+
+// TestSplitContributionWindowsPartialYear verifies the final window is truncated at the end date
+func TestSplitContributionWindowsPartialYear(t *testing.T) {
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	windows := SplitContributionWindows(since, until)
+	if len(windows) != 3 {
+		t.Fatalf("Expected 3 windows, got %d", len(windows))
+	}
+
+	last := windows[2]
+	if !last.From.Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected last window to start on 2023-01-01, got %s", last.From)
+	}
+	if !last.To.Equal(until.Add(-time.Second)) {
+		t.Errorf("Expected last window to end at %s, got %s", until.Add(-time.Second), last.To)
+	}
+}
+
+// TestSplitContributionWindowsEmpty verifies that an empty or inverted range yields no windows
+func TestSplitContributionWindowsEmpty(t *testing.T) {
+	day := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	if windows := SplitContributionWindows(day, day); len(windows) != 0 {
+		t.Errorf("Expected no windows for empty range, got %d", len(windows))
+	}
+	if windows := SplitContributionWindows(day, day.AddDate(0, 0, -1)); len(windows) != 0 {
+		t.Errorf("Expected no windows for inverted range, got %d", len(windows))
+	}
+}
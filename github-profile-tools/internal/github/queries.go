@@ -44,6 +44,13 @@ query($username: String!) {
         createdAt
       }
     }
+    pinnedItems(first: 6, types: REPOSITORY) {
+      nodes {
+        ... on Repository {
+          nameWithOwner
+        }
+      }
+    }
   }
 }`
 
@@ -121,6 +128,112 @@ query($username: String!, $first: Int!, $after: String) {
   }
 }`
 
+// OrganizationProfileQuery fetches basic organization information
+const OrganizationProfileQuery = `
+query($login: String!) {
+  organization(login: $login) {
+    login
+    name
+    description
+    location
+    email
+    websiteUrl
+    avatarUrl
+    createdAt
+    updatedAt
+    membersWithRole {
+      totalCount
+    }
+    repositories(privacy: PUBLIC) {
+      totalCount
+    }
+  }
+}`
+
+// OrganizationRepositoriesQuery fetches an organization's public repositories with pagination,
+// including the commit count of each default branch
+const OrganizationRepositoriesQuery = `
+query($login: String!, $first: Int!, $after: String) {
+  organization(login: $login) {
+    repositories(
+      first: $first
+      after: $after
+      privacy: PUBLIC
+      orderBy: {field: STARGAZERS, direction: DESC}
+    ) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        id
+        name
+        nameWithOwner
+        description
+        url
+        isPrivate
+        isFork
+        isArchived
+        createdAt
+        updatedAt
+        pushedAt
+        stargazerCount
+        forkCount
+        watchers {
+          totalCount
+        }
+        issues {
+          totalCount
+        }
+        pullRequests {
+          totalCount
+        }
+        primaryLanguage {
+          name
+          color
+        }
+        languages(first: 10, orderBy: {field: SIZE, direction: DESC}) {
+          nodes {
+            name
+            color
+          }
+          edges {
+            size
+          }
+        }
+        repositoryTopics(first: 20) {
+          nodes {
+            topic {
+              name
+            }
+          }
+        }
+        licenseInfo {
+          name
+          spdxId
+        }
+        diskUsage
+        defaultBranchRef {
+          target {
+            ... on Commit {
+              history {
+                totalCount
+              }
+            }
+          }
+        }
+        owner {
+          login
+          ... on Organization {
+            name
+            description
+          }
+        }
+      }
+    }
+  }
+}`
+
 // UserContributionsQuery fetches contribution statistics
 const UserContributionsQuery = `
 query($username: String!, $from: DateTime!, $to: DateTime!) {
@@ -321,6 +434,80 @@ query($owner: String!, $name: String!) {
   }
 }`
 
+// UserIDQuery fetches a user's node ID, needed to filter commit history by author
+const UserIDQuery = `
+query($username: String!) {
+  user(login: $username) {
+    id
+  }
+}`
+
+// CommitHistoryQuery fetches line changes for a user's commits on a repository's default branch
+const CommitHistoryQuery = `
+query($owner: String!, $name: String!, $authorId: ID!, $since: GitTimestamp, $until: GitTimestamp, $after: String) {
+  repository(owner: $owner, name: $name) {
+    defaultBranchRef {
+      target {
+        ... on Commit {
+          history(first: 100, after: $after, author: {id: $authorId}, since: $since, until: $until) {
+            totalCount
+            pageInfo {
+              hasNextPage
+              endCursor
+            }
+            nodes {
+              additions
+              deletions
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// RepositoryContributorsQuery fetches the users who can be mentioned in a repository, i.e. its
+// collaborators and the people who took part in its issues and pull requests
+const RepositoryContributorsQuery = `
+query($owner: String!, $name: String!, $first: Int!) {
+  repository(owner: $owner, name: $name) {
+    mentionableUsers(first: $first) {
+      totalCount
+      nodes {
+        login
+      }
+    }
+  }
+}`
+
+// UserPullRequestReviewsQuery fetches the user's most recent pull request reviews of the past year,
+// with the author of each reviewed pull request and their association with the repository
+const UserPullRequestReviewsQuery = `
+query($username: String!, $first: Int!) {
+  user(login: $username) {
+    contributionsCollection {
+      pullRequestReviewContributions(first: $first, orderBy: {direction: DESC}) {
+        totalCount
+        nodes {
+          occurredAt
+          pullRequest {
+            authorAssociation
+            author {
+              login
+            }
+          }
+          repository {
+            nameWithOwner
+            owner {
+              login
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
 // UserPullRequestsQuery fetches user's pull request activity
 const UserPullRequestsQuery = `
 query($username: String!, $first: Int!, $after: String) {
@@ -38,6 +38,11 @@ type UserProfileResponse struct {
 		Organizations struct {
 			Nodes []OrganizationNode `json:"nodes"`
 		} `json:"organizations"`
+		PinnedItems struct {
+			Nodes []struct {
+				NameWithOwner string `json:"nameWithOwner"`
+			} `json:"nodes"`
+		} `json:"pinnedItems"`
 	} `json:"user"`
 }
 
@@ -247,6 +252,118 @@ type RepositoryNode struct {
 	} `json:"releases,omitempty"`
 }
 
+// OrganizationProfileResponse represents the response for the organization profile query
+type OrganizationProfileResponse struct {
+	Organization *struct {
+		Login           string    `json:"login"`
+		Name            string    `json:"name"`
+		Description     string    `json:"description"`
+		Location        string    `json:"location"`
+		Email           string    `json:"email"`
+		WebsiteUrl      string    `json:"websiteUrl"`
+		AvatarUrl       string    `json:"avatarUrl"`
+		CreatedAt       time.Time `json:"createdAt"`
+		UpdatedAt       time.Time `json:"updatedAt"`
+		MembersWithRole struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"membersWithRole"`
+		Repositories struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"repositories"`
+	} `json:"organization"`
+}
+
+// OrganizationRepositoryNode is a repository node of the organization repositories query
+type OrganizationRepositoryNode struct {
+	RepositoryNode
+	DefaultBranchRef *struct {
+		Target struct {
+			History *struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"history,omitempty"`
+		} `json:"target"`
+	} `json:"defaultBranchRef,omitempty"`
+}
+
+// OrganizationRepositoriesResponse represents the response for the organization repositories query
+type OrganizationRepositoriesResponse struct {
+	Organization struct {
+		Repositories struct {
+			PageInfo PageInfo                     `json:"pageInfo"`
+			Nodes    []OrganizationRepositoryNode `json:"nodes"`
+		} `json:"repositories"`
+	} `json:"organization"`
+}
+
+// UserIDResponse represents the response for the user ID query
+type UserIDResponse struct {
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+// CommitHistoryResponse represents the response for the commit history query
+type CommitHistoryResponse struct {
+	Repository struct {
+		DefaultBranchRef *struct {
+			Target struct {
+				History struct {
+					TotalCount int               `json:"totalCount"`
+					PageInfo   PageInfo          `json:"pageInfo"`
+					Nodes      []CommitLineStats `json:"nodes"`
+				} `json:"history"`
+			} `json:"target"`
+		} `json:"defaultBranchRef"`
+	} `json:"repository"`
+}
+
+// RepositoryContributorsResponse represents the response for the repository contributors query
+type RepositoryContributorsResponse struct {
+	Repository struct {
+		MentionableUsers struct {
+			TotalCount int `json:"totalCount"`
+			Nodes      []struct {
+				Login string `json:"login"`
+			} `json:"nodes"`
+		} `json:"mentionableUsers"`
+	} `json:"repository"`
+}
+
+// UserPullRequestReviewsResponse represents the response for the pull request reviews query
+type UserPullRequestReviewsResponse struct {
+	User struct {
+		ContributionsCollection struct {
+			PullRequestReviewContributions struct {
+				TotalCount int                     `json:"totalCount"`
+				Nodes      []PullRequestReviewNode `json:"nodes"`
+			} `json:"pullRequestReviewContributions"`
+		} `json:"contributionsCollection"`
+	} `json:"user"`
+}
+
+// PullRequestReviewNode represents a review of a pull request in GraphQL responses
+type PullRequestReviewNode struct {
+	OccurredAt  time.Time `json:"occurredAt"`
+	PullRequest struct {
+		AuthorAssociation string `json:"authorAssociation"` // e.g. FIRST_TIME_CONTRIBUTOR, MEMBER
+		Author            *struct {
+			Login string `json:"login"`
+		} `json:"author"` // nil for deleted accounts
+	} `json:"pullRequest"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+		Owner         struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
+
+// CommitLineStats represents the line changes of a single commit
+type CommitLineStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
 // PullRequestNode represents a pull request in GraphQL responses
 type PullRequestNode struct {
 	ID           string    `json:"id"`
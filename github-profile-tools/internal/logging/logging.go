@@ -0,0 +1,80 @@
+// Package logging adds levels to the standard logger, so that per-request details
+// only show up when debugging while the output destination and flags stay those of
+// the standard log package.
+package logging
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+)
+
+// Level is the severity of a log message
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// DefaultLevel is the level used until SetLevel is called
+const DefaultLevel = LevelInfo
+
+var currentLevel atomic.Int32
+
+func init() {
+	currentLevel.Store(int32(DefaultLevel))
+}
+
+// SetLevel sets the minimum level of the messages written
+func SetLevel(level Level) {
+	currentLevel.Store(int32(level))
+}
+
+// GetLevel returns the minimum level of the messages written
+func GetLevel() Level {
+	return Level(currentLevel.Load())
+}
+
+// Enabled reports whether messages at level are written
+func Enabled(level Level) bool {
+	return level >= GetLevel()
+}
+
+// prefixes mark messages that are not plain information
+var prefixes = map[Level]string{
+	LevelDebug: "DEBUG ",
+	LevelWarn:  "WARN ",
+	LevelError: "ERROR ",
+}
+
+// output writes a message at level through the standard logger
+func output(level Level, format string, args ...interface{}) {
+	if !Enabled(level) {
+		return
+	}
+	// Skip output and its exported caller so that log.Lshortfile names the logging call site
+	log.Output(3, prefixes[level]+fmt.Sprintf(format, args...))
+}
+
+// Debugf logs per-request and per-item details, written only at debug level
+func Debugf(format string, args ...interface{}) {
+	output(LevelDebug, format, args...)
+}
+
+// Infof logs progress of the analysis
+func Infof(format string, args ...interface{}) {
+	output(LevelInfo, format, args...)
+}
+
+// Warnf logs recoverable problems
+func Warnf(format string, args ...interface{}) {
+	output(LevelWarn, format, args...)
+}
+
+// Errorf logs failures
+func Errorf(format string, args ...interface{}) {
+	output(LevelError, format, args...)
+}
@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+// captureLog redirects the standard logger to a buffer for the duration of the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	writer, flags, level := log.Writer(), log.Flags(), GetLevel()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(writer)
+		log.SetFlags(flags)
+		SetLevel(level)
+	})
+	return &buf
+}
+
+// TestDebugSuppressedAtInfo verifies debug lines are dropped at info level and written at debug level
+func TestDebugSuppressedAtInfo(t *testing.T) {
+	buf := captureLog(t)
+
+	SetLevel(LevelInfo)
+	Debugf("sending request %d", 1)
+	Infof("fetched %d repositories", 3)
+	Warnf("retrying")
+
+	output := buf.String()
+	if strings.Contains(output, "sending request") {
+		t.Errorf("Expected debug line to be suppressed at info level, got %q", output)
+	}
+	if !strings.Contains(output, "fetched 3 repositories\n") || !strings.Contains(output, "WARN retrying\n") {
+		t.Errorf("Expected info and warn lines at info level, got %q", output)
+	}
+
+	buf.Reset()
+	SetLevel(LevelDebug)
+	Debugf("sending request %d", 2)
+	if buf.String() != "DEBUG sending request 2\n" {
+		t.Errorf("Expected debug line at debug level, got %q", buf.String())
+	}
+}
+
+// TestErrorOnlyAtErrorLevel verifies lower levels are dropped at error level
+func TestErrorOnlyAtErrorLevel(t *testing.T) {
+	buf := captureLog(t)
+
+	SetLevel(LevelError)
+	Infof("progress")
+	Warnf("problem")
+	Errorf("failure")
+
+	if buf.String() != "ERROR failure\n" {
+		t.Errorf("Expected only the error line, got %q", buf.String())
+	}
+}
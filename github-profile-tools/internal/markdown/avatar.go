@@ -0,0 +1,54 @@
+package markdown
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxAvatarBytes caps the size of an embedded avatar so it cannot bloat the generated documents
+const maxAvatarBytes = 1 << 20
+
+// FetchAvatarDataURI downloads the image at avatarURL and returns it as a base64 data URI
+// that can be embedded in templates without network access at render time
+func FetchAvatarDataURI(ctx context.Context, client *http.Client, avatarURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, avatarURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create avatar request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch avatar: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch avatar: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAvatarBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read avatar: %w", err)
+	}
+	if len(data) > maxAvatarBytes {
+		return "", fmt.Errorf("avatar exceeds %d bytes", maxAvatarBytes)
+	}
+
+	// GitHub serves avatars with a proper content type; sniff it when a server does not
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = http.DetectContentType(data)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", fmt.Errorf("avatar is not an image: %s", contentType)
+	}
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = strings.TrimSpace(contentType[:i])
+	}
+
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// pngHeader is enough of a PNG file for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// TestFetchAvatarDataURI verifies the downloaded avatar is encoded as a data URI
+func TestFetchAvatarDataURI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(pngHeader)
+	}))
+	defer server.Close()
+
+	dataURI, err := FetchAvatarDataURI(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("FetchAvatarDataURI failed: %v", err)
+	}
+	if dataURI != "data:image/png;base64,iVBORw0KGgoAAAANSUhEUg==" {
+		t.Errorf("Unexpected data URI: %s", dataURI)
+	}
+}
+
+// TestFetchAvatarDataURIFailure verifies missing avatars are reported as errors
+func TestFetchAvatarDataURIFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := FetchAvatarDataURI(context.Background(), server.Client(), server.URL); err == nil {
+		t.Error("Expected an error for a missing avatar")
+	}
+}
+
+// TestAvatarHeader verifies the avatar appears in the resume and executive headers only
+func TestAvatarHeader(t *testing.T) {
+	generator := NewGenerator()
+	generator.SetAvatar("data:image/png;base64,AAAA")
+
+	for _, templateType := range []TemplateType{ResumeTemplate, ExecutiveTemplate, TechnicalTemplate} {
+		content, err := generator.GenerateMarkdown(createSampleProfile(), templateType)
+		if err != nil {
+			t.Fatalf("GenerateMarkdown(%s) failed: %v", templateType, err)
+		}
+		embedded := strings.Contains(content, `<img src="data:image/png;base64,AAAA"`)
+		if expected := templateType != TechnicalTemplate; embedded != expected {
+			t.Errorf("Expected avatar embedded in %s template: %v, got %v", templateType, expected, embedded)
+		}
+	}
+
+	prof := createSampleProfile()
+	prof.Anonymized = true
+	content, err := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if strings.Contains(content, "<img") {
+		t.Error("Expected no avatar in an anonymized resume")
+	}
+}
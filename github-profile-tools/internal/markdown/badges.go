@@ -0,0 +1,69 @@
+package markdown
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/profile"
+)
+
+// shieldsBadgeURL is the shields.io static badge endpoint
+const shieldsBadgeURL = "https://img.shields.io/badge/"
+
+// shieldsEscaper escapes the separators of a static badge path: dashes and underscores are doubled
+// because single ones split the label, message and color
+var shieldsEscaper = strings.NewReplacer("-", "--", "_", "__")
+
+// GenerateProfileBadge returns shields.io markdown badges for years active, total stars, top language
+// and impact score, linking to the user's GitHub profile, to paste into a profile README
+func GenerateProfileBadge(prof *profile.UserProfile) (string, error) {
+	if prof == nil || prof.Username == "" {
+		return "", fmt.Errorf("profile has no username")
+	}
+
+	link := "https://github.com/" + prof.Username
+	stars := 0
+	for _, repo := range prof.Repositories {
+		stars += repo.Stars
+	}
+
+	badges := []string{
+		badge("Years Active", "years active", fmt.Sprint(prof.Contributions.ContributionYears), "blue", "", link),
+		badge("Total Stars", "stars", fmt.Sprint(stars), "yellow", "github", link),
+	}
+	if language := topLanguage(prof); language != "" {
+		badges = append(badges, badge("Top Language", "top language", language, "informational", strings.ToLower(language), link))
+	}
+	badges = append(badges, badge("Impact Score", "impact", fmt.Sprintf("%.1f/10", prof.Insights.OverallImpactScore*10), "brightgreen", "", link))
+
+	return strings.Join(badges, " ") + "\n", nil
+}
+
+// badge returns a markdown image of a shields.io static badge, linked to link.
+// logo names a Simple Icons logo and may be empty.
+func badge(alt, label, message, color, logo, link string) string {
+	path := url.PathEscape(shieldsEscaper.Replace(label)) + "-" + url.PathEscape(shieldsEscaper.Replace(message)) + "-" + color
+	image := shieldsBadgeURL + path
+	if logo != "" {
+		image += "?logo=" + url.QueryEscape(logo)
+	}
+	return fmt.Sprintf("[![%s](%s)](%s)", alt, image, link)
+}
+
+// topLanguage returns the user's first primary language, or the language with the largest share
+// of the code when no primary language was identified
+func topLanguage(prof *profile.UserProfile) string {
+	if len(prof.Skills.PrimaryLanguages) > 0 {
+		return prof.Skills.PrimaryLanguages[0]
+	}
+
+	top := ""
+	best := 0.0
+	for _, lang := range prof.Languages {
+		if lang.Percentage > best {
+			top, best = lang.Language, lang.Percentage
+		}
+	}
+	return top
+}
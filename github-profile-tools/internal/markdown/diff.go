@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/profile"
+)
+
+// GenerateDiffMarkdown renders the changes between two analyses of a profile
+func (g *Generator) GenerateDiffMarkdown(diff *profile.ProfileDiff) string {
+	var md strings.Builder
+
+	md.WriteString("# " + fmt.Sprintf(g.t("diff.title"), diff.Username) + "\n\n")
+	md.WriteString(fmt.Sprintf("*%s → %s*\n\n", diff.From.Format("January 2, 2006"), diff.To.Format("January 2, 2006")))
+
+	// Summary
+	md.WriteString("## " + g.t("diff.summary") + "\n\n")
+	md.WriteString(fmt.Sprintf("- **Stars:** %s → %s (%s)\n",
+		g.formatNumber(diff.StarsBefore), g.formatNumber(diff.StarsAfter), signed(diff.StarsAfter-diff.StarsBefore)))
+	md.WriteString(fmt.Sprintf("- **New Repositories:** %d\n", len(diff.NewRepositories)))
+	if len(diff.RemovedRepositories) > 0 {
+		md.WriteString(fmt.Sprintf("- **Removed Repositories:** %s\n", strings.Join(diff.RemovedRepositories, ", ")))
+	}
+	if len(diff.NewLanguages) > 0 {
+		md.WriteString(fmt.Sprintf("- **New Languages:** %s\n", strings.Join(diff.NewLanguages, ", ")))
+	}
+	if diff.CareerLevelChanged() {
+		md.WriteString(fmt.Sprintf("- **Career Level:** %s → %s\n", strings.Title(diff.CareerLevelBefore), strings.Title(diff.CareerLevelAfter)))
+	} else if diff.CareerLevelAfter != "" {
+		md.WriteString(fmt.Sprintf("- **Career Level:** %s (unchanged)\n", strings.Title(diff.CareerLevelAfter)))
+	}
+	md.WriteString("\n")
+
+	// Contribution growth
+	md.WriteString("## " + g.t("diff.contributions") + "\n\n")
+	md.WriteString(fmt.Sprintf("- **Commits:** %s\n", signed(diff.Contributions.Commits)))
+	md.WriteString(fmt.Sprintf("- **Pull Requests:** %s\n", signed(diff.Contributions.PullRequests)))
+	md.WriteString(fmt.Sprintf("- **Issues:** %s\n", signed(diff.Contributions.Issues)))
+	md.WriteString(fmt.Sprintf("- **Code Reviews:** %s\n", signed(diff.Contributions.CodeReviews)))
+	md.WriteString("\n")
+
+	if len(diff.NewRepositories) > 0 {
+		md.WriteString("## " + g.t("diff.new_repositories") + "\n\n")
+		for _, repo := range diff.NewRepositories {
+			md.WriteString(fmt.Sprintf("- %s\n", repo))
+		}
+		md.WriteString("\n")
+	}
+
+	if len(diff.StarChanges) > 0 {
+		md.WriteString("## " + g.t("diff.star_changes") + "\n\n")
+		for _, change := range diff.StarChanges {
+			md.WriteString(fmt.Sprintf("- **%s:** %d → %d (%s)\n", change.Repository, change.Before, change.After, signed(change.After-change.Before)))
+		}
+		md.WriteString("\n")
+	}
+
+	return md.String()
+}
+
+// signed formats a delta with an explicit sign
+func signed(delta int) string {
+	if delta > 0 {
+		return fmt.Sprintf("+%d", delta)
+	}
+	return fmt.Sprintf("%d", delta)
+}
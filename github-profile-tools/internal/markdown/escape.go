@@ -0,0 +1,35 @@
+package markdown
+
+import "strings"
+
+// markdownEscaper backslash-escapes the characters that let user-sourced text start emphasis, code,
+// links, HTML or table cells. Line breaks are folded so a value cannot end the list item or row it is in.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`|`, `\|`,
+	"\r\n", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+// escape makes text from GitHub (descriptions, bios, names, topics) safe to insert into a template.
+// The ATS template is meant to be read as plain text and is left unescaped.
+func escape(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// escapeAll escapes each of values
+func escapeAll(values []string) []string {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escape(value)
+	}
+	return escaped
+}
@@ -4,18 +4,175 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jenkins/github-profile-tools/internal/profile"
 )
 
+// maxFeaturedRepositories matches the number of repositories GitHub lets users pin
+const maxFeaturedRepositories = 6
+
+// maxTopicGroups limits the topics listed when the technical template groups projects by topic
+const maxTopicGroups = 10
+
+// Grouping selects how the technical template groups the project portfolio
+type Grouping string
+
+const (
+	GroupByLanguage Grouping = "language"
+	GroupByTopic    Grouping = "topic"
+)
+
+// LanguageOrder selects how the programming language sections are sorted
+type LanguageOrder string
+
+const (
+	SortByPercentage  LanguageOrder = "by-percentage"
+	SortByProficiency LanguageOrder = "by-proficiency"
+)
+
+// DefaultMinLanguagePercent is the share of the codebase below which languages are omitted from templates
+const DefaultMinLanguagePercent = 1.0
+
+// Default hosts of the profile links, overridden for GitHub Enterprise or a self-hosted registry
+const (
+	DefaultGitHubURL    = "https://github.com"
+	DefaultDockerHubURL = "https://hub.docker.com"
+)
+
 // Generator handles markdown profile generation
-type Generator struct{}
+type Generator struct {
+	minLanguagePercent   float64
+	messages             map[string]string // section headers of the selected language; nil means English
+	avatar               string            // data URI shown in the resume and executive headers; empty omits it
+	grouping             Grouping          // project portfolio grouping of the technical template
+	languageOrder        LanguageOrder     // sort order of the programming language sections
+	useEmoji             bool              // decorate section headers with emoji; the ATS template never does
+	minSkillConfidence   float64           // skills with a lower confidence are left out of templates
+	mermaid              bool              // draw technical areas as a Mermaid diagram in the technical template
+	privateAggregateOnly bool              // count private repositories in totals but never list them by name
+	minForks             int               // repositories with fewer forks are left out of project listings
+	starDecay            float64           // years since last push at which stars count half in notable ordering; zero disables
+	githubURL            string            // host of the profile and repository links, without trailing slash
+	dockerHubURL         string            // host of the Docker Hub profile link, without trailing slash
+}
 
 // NewGenerator creates a new markdown generator
 func NewGenerator() *Generator {
-	return &Generator{}
+	return &Generator{
+		minLanguagePercent: DefaultMinLanguagePercent,
+		grouping:           GroupByLanguage,
+		languageOrder:      SortByPercentage,
+		useEmoji:           true,
+		githubURL:          DefaultGitHubURL,
+		dockerHubURL:       DefaultDockerHubURL,
+	}
+}
+
+// SetMinLanguagePercent sets the share of the codebase a language needs to be listed in templates
+func (g *Generator) SetMinLanguagePercent(percent float64) {
+	g.minLanguagePercent = percent
+}
+
+// SetMinSkillConfidence sets the confidence (0-1) a framework, database, cloud or DevOps skill needs
+// to be listed in templates, so skills evidenced by a single mention stay off resumes
+func (g *Generator) SetMinSkillConfidence(confidence float64) {
+	g.minSkillConfidence = confidence
+}
+
+// SetPrivateAggregateOnly keeps private repositories out of project listings while their languages
+// and sizes still count toward statistics and totals
+func (g *Generator) SetPrivateAggregateOnly(enabled bool) {
+	g.privateAggregateOnly = enabled
+}
+
+// SetMinForks sets the number of forks a repository needs to appear in project listings, to
+// highlight reused libraries over starred demos; 0 lists every repository
+func (g *Generator) SetMinForks(forks int) {
+	g.minForks = forks
+}
+
+// SetStarDecay ranks notable projects by stars halved for every given number of years since their
+// last push, so recently active work outranks equally starred stale projects; 0 uses raw stars
+func (g *Generator) SetStarDecay(years float64) {
+	g.starDecay = years
+}
+
+// SetGitHubURL sets the host of profile and repository links, e.g. "https://github.example.com" for
+// GitHub Enterprise
+func (g *Generator) SetGitHubURL(url string) {
+	g.githubURL = strings.TrimSuffix(url, "/")
+}
+
+// SetDockerHubURL sets the host of the Docker Hub profile link
+func (g *Generator) SetDockerHubURL(url string) {
+	g.dockerHubURL = strings.TrimSuffix(url, "/")
+}
+
+// listed reports whether repo may appear by name in project listings
+func (g *Generator) listed(repo profile.RepositoryProfile) bool {
+	return !(g.privateAggregateOnly && repo.IsPrivate) && repo.Forks >= g.minForks
+}
+
+// SetGrouping sets how the technical template groups the project portfolio
+func (g *Generator) SetGrouping(grouping Grouping) {
+	g.grouping = grouping
+}
+
+// SetLanguageOrder sets how the programming language sections are sorted: by share of the codebase,
+// or by proficiency score to favor a language the user masters but writes less of
+func (g *Generator) SetLanguageOrder(order LanguageOrder) {
+	g.languageOrder = order
+}
+
+// sortedLanguages returns the languages of prof in the configured order, leaving prof untouched
+func (g *Generator) sortedLanguages(prof *profile.UserProfile) []profile.LanguageStats {
+	if g.languageOrder != SortByProficiency {
+		return prof.Languages // already sorted by percentage by the analyzer
+	}
+
+	languages := make([]profile.LanguageStats, len(prof.Languages))
+	copy(languages, prof.Languages)
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].ProficiencyScore > languages[j].ProficiencyScore
+	})
+	return languages
+}
+
+// SetUseEmoji sets whether section headers carry an emoji, e.g. "## 📊 Contribution Overview".
+// Disabling it keeps the templates' layout, unlike the fully plain ATS template.
+func (g *Generator) SetUseEmoji(useEmoji bool) {
+	g.useEmoji = useEmoji
+}
+
+// sectionTitle returns a "##" header for title, prefixed with emoji unless disabled
+func (g *Generator) sectionTitle(emoji, title string) string {
+	if !g.useEmoji {
+		return "## " + title
+	}
+	return "## " + emoji + " " + title
+}
+
+// SetAvatar sets the avatar image, as a data URI, embedded in the resume, executive and organization headers
+func (g *Generator) SetAvatar(dataURI string) {
+	g.avatar = dataURI
+}
+
+// writeAvatar embeds the avatar below a template title. It is skipped for anonymized profiles,
+// where a photo would identify the user.
+func (g *Generator) writeAvatar(md *strings.Builder, prof *profile.UserProfile) {
+	if g.avatar == "" || prof.Anonymized {
+		return
+	}
+	md.WriteString(fmt.Sprintf("<img src=\"%s\" alt=\"%s\" width=\"120\" />\n\n", g.avatar, prof.Username))
+}
+
+// includeLanguage reports whether a language is significant enough to list.
+// Dockerfile is always kept since containerization expertise rarely shows up as code volume.
+func (g *Generator) includeLanguage(lang profile.LanguageStats) bool {
+	return lang.Percentage >= g.minLanguagePercent || strings.EqualFold(lang.Language, "dockerfile")
 }
 
 // TemplateType represents different markdown template types
@@ -26,10 +183,26 @@ const (
 	TechnicalTemplate TemplateType = "technical"
 	ExecutiveTemplate TemplateType = "executive"
 	ATSTemplate       TemplateType = "ats"
+
+	// OrganizationTemplate renders profiles produced by Analyzer.AnalyzeOrganization
+	OrganizationTemplate TemplateType = "organization"
 )
 
-// GenerateMarkdown generates markdown profile based on template type
+// maxOrganizationRepositories caps the top repositories listed in the organization template
+const maxOrganizationRepositories = 10
+
+// GenerateMarkdown generates markdown profile based on template type.
+// Profiles of interrupted analyses open with a PARTIAL banner.
 func (g *Generator) GenerateMarkdown(prof *profile.UserProfile, templateType TemplateType) (string, error) {
+	content, err := g.generateTemplate(prof, templateType)
+	if err != nil {
+		return "", err
+	}
+	return g.partialBanner(prof) + content, nil
+}
+
+// generateTemplate renders a single template without the PARTIAL banner
+func (g *Generator) generateTemplate(prof *profile.UserProfile, templateType TemplateType) (string, error) {
 	switch templateType {
 	case ResumeTemplate:
 		return g.generateResumeTemplate(prof), nil
@@ -39,26 +212,68 @@ func (g *Generator) GenerateMarkdown(prof *profile.UserProfile, templateType Tem
 		return g.generateExecutiveTemplate(prof), nil
 	case ATSTemplate:
 		return g.generateATSTemplate(prof), nil
+	case OrganizationTemplate:
+		return g.generateOrganizationTemplate(prof), nil
 	default:
 		return "", fmt.Errorf("unknown template type: %s", templateType)
 	}
 }
 
+// partialBanner warns readers that prof comes from an interrupted analysis
+func (g *Generator) partialBanner(prof *profile.UserProfile) string {
+	if !prof.Partial {
+		return ""
+	}
+	return "> ⚠️ **PARTIAL:** " + g.t("partial.banner") + "\n\n"
+}
+
+// GenerateCombinedMarkdown renders several templates into one document with a table of contents,
+// separating each template with a horizontal rule that doubles as a page break
+func (g *Generator) GenerateCombinedMarkdown(prof *profile.UserProfile, templateTypes []TemplateType) (string, error) {
+	var md strings.Builder
+
+	md.WriteString("# " + fmt.Sprintf(g.t("combined.title"), prof.Username) + "\n\n")
+	md.WriteString(g.partialBanner(prof))
+	md.WriteString("## " + g.t("combined.contents") + "\n\n")
+	for i, templateType := range templateTypes {
+		md.WriteString(fmt.Sprintf("%d. [%s](#%s-profile)\n", i+1, g.t("toc."+string(templateType)), templateType))
+	}
+	md.WriteString("\n")
+
+	for _, templateType := range templateTypes {
+		content, err := g.generateTemplate(prof, templateType)
+		if err != nil {
+			return "", err
+		}
+
+		md.WriteString("---\n\n")
+		md.WriteString(fmt.Sprintf("<a id=\"%s-profile\"></a>\n\n", templateType))
+		md.WriteString(content)
+		if !strings.HasSuffix(content, "\n") {
+			md.WriteString("\n")
+		}
+		md.WriteString("\n")
+	}
+
+	return md.String(), nil
+}
+
 // generateResumeTemplate creates a resume-focused markdown profile
 func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 	var md strings.Builder
 
 	// Header
-	md.WriteString(fmt.Sprintf("# GitHub Professional Profile - %s\n\n", prof.Username))
+	md.WriteString("# " + fmt.Sprintf(g.t("resume.title"), prof.Username) + "\n\n")
+	g.writeAvatar(&md, prof)
 
 	if prof.Name != "" {
-		md.WriteString(fmt.Sprintf("**Name:** %s\n", prof.Name))
+		md.WriteString(fmt.Sprintf("**Name:** %s\n", escape(prof.Name)))
 	}
 	if prof.Location != "" {
-		md.WriteString(fmt.Sprintf("**Location:** %s\n", prof.Location))
+		md.WriteString(fmt.Sprintf("**Location:** %s\n", escape(prof.Location)))
 	}
 	if prof.Company != "" {
-		md.WriteString(fmt.Sprintf("**Company:** %s\n", prof.Company))
+		md.WriteString(fmt.Sprintf("**Company:** %s\n", escape(prof.Company)))
 	}
 	if prof.BlogURL != "" {
 		md.WriteString(fmt.Sprintf("**Website:** %s\n", prof.BlogURL))
@@ -66,11 +281,13 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 	md.WriteString("\n")
 
 	if prof.Bio != "" {
-		md.WriteString(fmt.Sprintf("*%s*\n\n", prof.Bio))
+		md.WriteString(fmt.Sprintf("*%s*\n\n", escape(prof.Bio)))
 	}
 
+	g.writeFeaturedProjects(&md, prof, g.sectionTitle("⭐", g.t("resume.featured_projects")))
+
 	// Contribution Overview
-	md.WriteString("## 📊 Contribution Overview\n\n")
+	md.WriteString(g.sectionTitle("📊", g.t("resume.contribution_overview")) + "\n\n")
 	md.WriteString(fmt.Sprintf("- **%d** total contributions across **%.0f** years of active development\n",
 		prof.Contributions.TotalCommits+prof.Contributions.TotalPullRequests+prof.Contributions.TotalIssues,
 		float64(prof.Contributions.ContributionYears)))
@@ -89,14 +306,30 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 			prof.DiscourseProfile.PostCount, prof.DiscourseProfile.SolutionsCount))
 	}
 
+	// Add Stack Overflow reputation if available
+	if prof.StackOverflowProfile != nil {
+		md.WriteString(fmt.Sprintf("- **%s** Stack Overflow reputation with **%s** accepted answers 📚\n",
+			g.formatNumber(prof.StackOverflowProfile.Reputation), answerCount(prof.StackOverflowProfile.AcceptedAnswers, prof.StackOverflowProfile.AnswersCapped)))
+	}
+
 	md.WriteString(fmt.Sprintf("- Active contributor in **%d** organizations\n", len(prof.Organizations)))
 	md.WriteString(fmt.Sprintf("- Proficient in **%d** programming languages\n", len(prof.Languages)))
 	md.WriteString(fmt.Sprintf("- Career Level: **%s**\n", strings.Title(prof.Insights.CareerLevel)))
 	md.WriteString("\n")
 
+	// Community Around My Projects (only with -with-contributors)
+	if contributors, projects := g.getProjectCommunity(prof); contributors > 0 {
+		md.WriteString(g.sectionTitle("🤝", g.t("resume.project_community")) + "\n\n")
+		md.WriteString(fmt.Sprintf("- **%d** contributors across **%d** owned projects\n", contributors, projects))
+		for _, repo := range g.getMostContributedProjects(prof, 3) {
+			md.WriteString(fmt.Sprintf("- **%s:** %d contributors\n", escape(repo.Name), len(repo.Contributors)))
+		}
+		md.WriteString("\n")
+	}
+
 	// Organization Contributions
 	if len(prof.Organizations) > 0 {
-		md.WriteString("## 🏢 Organization Contributions\n\n")
+		md.WriteString(g.sectionTitle("🏢", g.t("resume.organizations")) + "\n\n")
 
 		// Sort organizations by contribution count
 		orgs := make([]profile.OrganizationProfile, len(prof.Organizations))
@@ -110,9 +343,9 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 				continue
 			}
 
-			md.WriteString(fmt.Sprintf("### %s\n", org.Name))
+			md.WriteString(fmt.Sprintf("### %s\n", escape(org.Name)))
 			if org.Description != "" {
-				md.WriteString(fmt.Sprintf("*%s*\n\n", org.Description))
+				md.WriteString(fmt.Sprintf("*%s*\n\n", escape(org.Description)))
 			}
 			md.WriteString(fmt.Sprintf("- **Role:** %s\n", strings.Title(org.Role)))
 			md.WriteString(fmt.Sprintf("- **Contributions:** %d repositories\n", org.ContributionCount))
@@ -123,7 +356,7 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 				if len(topRepos) > 3 {
 					topRepos = topRepos[:3]
 				}
-				md.WriteString(strings.Join(topRepos, ", "))
+				md.WriteString(strings.Join(escapeAll(topRepos), ", "))
 				if len(org.Repositories) > 3 {
 					md.WriteString(fmt.Sprintf(" and %d more", len(org.Repositories)-3))
 				}
@@ -133,12 +366,25 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 		}
 	}
 
+	// Experience Timeline inferred from the activity dates of each organization's repositories
+	if timeline := datedOrganizations(prof.Organizations); len(timeline) > 0 {
+		md.WriteString(g.sectionTitle("🗓", g.t("resume.experience_timeline")) + "\n\n")
+		for _, org := range timeline {
+			name := org.Name
+			if name == "" {
+				name = org.Login
+			}
+			md.WriteString(fmt.Sprintf("- **%s:** %s\n", escape(name), dateRange(org.FirstContribution, org.LastContribution)))
+		}
+		md.WriteString("\n")
+	}
+
 	// Docker Hub Impact Section (if significant)
 	if prof.DockerHubProfile != nil && prof.DockerHubProfile.TotalDownloads > 100000 {
-		md.WriteString("## 🐳 Container Infrastructure Impact\n\n")
+		md.WriteString(g.sectionTitle("🐳", g.t("resume.containers")) + "\n\n")
 
-		md.WriteString(fmt.Sprintf("### Docker Hub Profile: [@%s](https://hub.docker.com/u/%s)\n\n",
-			prof.DockerHubProfile.Username, prof.DockerHubProfile.Username))
+		md.WriteString(fmt.Sprintf("### %s: [@%s](%s/u/%s)\n\n", g.t("resume.docker_hub_profile"),
+			prof.DockerHubProfile.Username, g.dockerHubURL, prof.DockerHubProfile.Username))
 
 		md.WriteString(fmt.Sprintf("- **Total Downloads**: %s across all images\n",
 			g.formatLargeNumber(prof.DockerHubProfile.TotalDownloads)))
@@ -171,9 +417,9 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 
 	// Discourse Community Engagement Section (if active)
 	if prof.DiscourseProfile != nil && prof.DiscourseProfile.PostCount > 50 {
-		md.WriteString("## 💬 Jenkins Community Leadership\n\n")
+		md.WriteString(g.sectionTitle("💬", g.t("resume.community")) + "\n\n")
 
-		md.WriteString(fmt.Sprintf("### Community Profile: [@%s](%s)\n\n",
+		md.WriteString(fmt.Sprintf("### %s: [@%s](%s)\n\n", g.t("resume.community_profile"),
 			prof.DiscourseProfile.Username, prof.DiscourseProfile.ProfileURL))
 
 		md.WriteString(fmt.Sprintf("- **Community Tenure**: %.1f years active (joined %s)\n",
@@ -214,19 +460,42 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 		md.WriteString("\n**Community Leadership**: Active Jenkins community member providing technical guidance and solutions to fellow developers and DevOps practitioners.\n\n")
 	}
 
+	// Stack Overflow Expertise Section (if present)
+	if prof.StackOverflowProfile != nil {
+		so := prof.StackOverflowProfile
+		md.WriteString(g.sectionTitle("📚", g.t("resume.stackoverflow")) + "\n\n")
+
+		md.WriteString(fmt.Sprintf("### %s: [%s](%s)\n\n", g.t("resume.stackoverflow_profile"), so.DisplayName, so.ProfileURL))
+		md.WriteString(fmt.Sprintf("- **Reputation**: %s\n", g.formatNumber(so.Reputation)))
+		md.WriteString(fmt.Sprintf("- **Answers**: %s answers, %s accepted\n",
+			answerCount(so.AnswerCount, so.AnswersCapped), answerCount(so.AcceptedAnswers, so.AnswersCapped)))
+		md.WriteString(fmt.Sprintf("- **Badges**: %d gold, %d silver, %d bronze\n", so.GoldBadges, so.SilverBadges, so.BronzeBadges))
+
+		if len(so.TopTags) > 0 {
+			md.WriteString("\n**Top Tags**:\n")
+			for i, tag := range so.TopTags {
+				if i >= 5 { // Limit to top 5
+					break
+				}
+				md.WriteString(fmt.Sprintf("- `%s`: %d answers (score %d)\n", tag.TagName, tag.AnswerCount, tag.AnswerScore))
+			}
+		}
+		md.WriteString("\n")
+	}
+
 	// Notable Projects
-	md.WriteString("## 💼 Notable Projects\n\n")
+	md.WriteString(g.sectionTitle("💼", g.t("resume.notable_projects")) + "\n\n")
 	notableRepos := g.getNotableRepositories(prof)
 
 	for _, repo := range notableRepos {
-		md.WriteString(fmt.Sprintf("### [%s](%s)", repo.Name, repo.URL))
+		md.WriteString(fmt.Sprintf("### [%s](%s)", escape(repo.Name), repo.URL))
 		if repo.Stars > 0 {
 			md.WriteString(fmt.Sprintf(" ⭐ %d", repo.Stars))
 		}
 		md.WriteString("\n")
 
-		if repo.Description != "" {
-			md.WriteString(fmt.Sprintf("**Description:** %s\n\n", repo.Description))
+		if description := repo.DisplayDescription(); description != "" {
+			md.WriteString(fmt.Sprintf("**Description:** %s\n\n", escape(description)))
 		}
 
 		md.WriteString(fmt.Sprintf("- **Language:** %s", repo.Language))
@@ -236,7 +505,7 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 		md.WriteString("\n")
 
 		if len(repo.Topics) > 0 {
-			md.WriteString(fmt.Sprintf("- **Technologies:** %s\n", strings.Join(repo.Topics, ", ")))
+			md.WriteString(fmt.Sprintf("- **Technologies:** %s\n", strings.Join(escapeAll(repo.Topics), ", ")))
 		}
 
 		if repo.ContributionStats.Commits > 0 {
@@ -251,14 +520,19 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 	}
 
 	// Technical Skills
-	md.WriteString("## 🛠 Technical Skills\n\n")
+	md.WriteString(g.sectionTitle("🛠", g.t("resume.technical_skills")) + "\n\n")
 
 	if len(prof.Skills.PrimaryLanguages) > 0 {
-		md.WriteString("### Programming Languages\n")
-		for i, lang := range prof.Languages {
-			if i >= 8 { // Limit to top 8 languages
+		md.WriteString("### " + g.t("resume.programming_languages") + "\n")
+		listed := 0
+		for _, lang := range g.sortedLanguages(prof) {
+			if !g.includeLanguage(lang) {
+				continue
+			}
+			if listed >= 8 { // Limit to top 8 languages
 				break
 			}
+			listed++
 
 			profLevel := "Intermediate"
 			if lang.Percentage > 25 {
@@ -276,33 +550,42 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 	}
 
 	// Technology Stack
-	if len(prof.Skills.Frameworks) > 0 || len(prof.Skills.Databases) > 0 || len(prof.Skills.CloudPlatforms) > 0 {
-		md.WriteString("### Technology Stack\n")
-
-		if len(prof.Skills.Frameworks) > 0 {
-			frameworks := g.getTechnologyNames(prof.Skills.Frameworks, 5)
+	frameworks := g.getTechnologyNames(prof.Skills.Frameworks, 5)
+	databases := g.getTechnologyNames(prof.Skills.Databases, 5)
+	cloud := g.getTechnologyNames(prof.Skills.CloudPlatforms, 5)
+	devops := g.getTechnologyNames(prof.Skills.DevOpsSkills, 5)
+	if len(frameworks) > 0 || len(databases) > 0 || len(cloud) > 0 {
+		md.WriteString("### " + g.t("resume.technology_stack") + "\n")
+
+		if len(frameworks) > 0 {
 			md.WriteString(fmt.Sprintf("- **Frameworks:** %s\n", strings.Join(frameworks, ", ")))
 		}
 
-		if len(prof.Skills.Databases) > 0 {
-			databases := g.getTechnologyNames(prof.Skills.Databases, 5)
+		if len(databases) > 0 {
 			md.WriteString(fmt.Sprintf("- **Databases:** %s\n", strings.Join(databases, ", ")))
 		}
 
-		if len(prof.Skills.CloudPlatforms) > 0 {
-			cloud := g.getTechnologyNames(prof.Skills.CloudPlatforms, 5)
+		if len(cloud) > 0 {
 			md.WriteString(fmt.Sprintf("- **Cloud Platforms:** %s\n", strings.Join(cloud, ", ")))
 		}
 
-		if len(prof.Skills.DevOpsSkills) > 0 {
-			devops := g.getTechnologyNames(prof.Skills.DevOpsSkills, 5)
+		if len(devops) > 0 {
 			md.WriteString(fmt.Sprintf("- **DevOps & Tools:** %s\n", strings.Join(devops, ", ")))
 		}
 		md.WriteString("\n")
 	}
 
+	// Focus Areas from the topics shared across repositories
+	if len(prof.Insights.FocusAreas) > 0 {
+		md.WriteString("### " + g.t("resume.focus_areas") + "\n")
+		for _, area := range prof.Insights.FocusAreas {
+			md.WriteString(fmt.Sprintf("- **%s:** %d repositories\n", area.Topic, area.RepositoryCount))
+		}
+		md.WriteString("\n")
+	}
+
 	// Professional Insights
-	md.WriteString("## 🤝 Professional Insights\n\n")
+	md.WriteString(g.sectionTitle("🤝", g.t("resume.professional_insights")) + "\n\n")
 
 	totalOSContributions := g.countOpenSourceContributions(prof)
 	md.WriteString(fmt.Sprintf("- **Open Source Contributions:** %d repositories\n", totalOSContributions))
@@ -325,7 +608,7 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 	md.WriteString("\n")
 
 	// Activity Timeline
-	md.WriteString("## 📈 Activity Timeline\n\n")
+	md.WriteString(g.sectionTitle("📈", g.t("resume.activity_timeline")) + "\n\n")
 
 	if prof.Contributions.MostActiveYear > 0 {
 		md.WriteString(fmt.Sprintf("- **Most Active Period:** %d\n", prof.Contributions.MostActiveYear))
@@ -346,8 +629,8 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 
 	// Footer
 	md.WriteString("---\n")
-	md.WriteString(fmt.Sprintf("*Profile generated on %s | GitHub: [@%s](https://github.com/%s)*\n",
-		time.Now().Format("January 2, 2006"), prof.Username, prof.Username))
+	md.WriteString(fmt.Sprintf("*Profile generated on %s | GitHub: [@%s](%s/%s)*\n",
+		time.Now().Format("January 2, 2006"), prof.Username, g.githubURL, prof.Username))
 
 	return md.String()
 }
@@ -356,22 +639,21 @@ func (g *Generator) generateResumeTemplate(prof *profile.UserProfile) string {
 func (g *Generator) generateTechnicalTemplate(prof *profile.UserProfile) string {
 	var md strings.Builder
 
-	md.WriteString(fmt.Sprintf("# Technical Profile - %s\n\n", prof.Username))
+	md.WriteString("# " + fmt.Sprintf(g.t("technical.title"), prof.Username) + "\n\n")
 
 	// Technical Overview
-	md.WriteString("## 🔧 Technical Overview\n\n")
-	md.WriteString("### Language Proficiency Analysis\n\n")
+	md.WriteString(g.sectionTitle("🔧", g.t("technical.overview")) + "\n\n")
+	md.WriteString("### " + g.t("technical.language_proficiency") + "\n\n")
 
-	for _, lang := range prof.Languages {
+	for _, lang := range g.sortedLanguages(prof) {
 		// Skip languages with very low usage, but always include Dockerfile if it exists
-		isDockerfile := strings.ToLower(lang.Language) == "dockerfile"
-		if lang.Percentage < 1 && !isDockerfile {
+		if !g.includeLanguage(lang) {
 			continue
 		}
 
 		yearsUsed := time.Since(lang.FirstUsed).Hours() / (24 * 365.25)
 		md.WriteString(fmt.Sprintf("#### %s\n", lang.Language))
-		md.WriteString(fmt.Sprintf("- **Usage:** %.1f%% of total codebase (%s lines)\n",
+		md.WriteString(fmt.Sprintf("- **Usage:** %.1f%% of total codebase (~%s lines, estimated)\n",
 			lang.Percentage, g.formatNumber(lang.LinesOfCode)))
 		md.WriteString(fmt.Sprintf("- **Experience:** %.1f years (%d projects)\n",
 			yearsUsed, lang.ProjectCount))
@@ -410,7 +692,7 @@ func (g *Generator) generateTechnicalTemplate(prof *profile.UserProfile) string
 	}
 
 	// Repository Analysis
-	md.WriteString("## 📊 Repository Analysis\n\n")
+	md.WriteString(g.sectionTitle("📊", g.t("technical.repository_analysis")) + "\n\n")
 
 	ownedRepos := 0
 	contributedRepos := 0
@@ -429,13 +711,13 @@ func (g *Generator) generateTechnicalTemplate(prof *profile.UserProfile) string
 
 	md.WriteString(fmt.Sprintf("- **Repository Ownership:** %d owned, %d contributed\n", ownedRepos, contributedRepos))
 	md.WriteString(fmt.Sprintf("- **Community Impact:** %d stars, %d forks received\n", totalStars, totalForks))
-	md.WriteString(fmt.Sprintf("- **Code Volume:** %s total lines across %d repositories\n",
+	md.WriteString(fmt.Sprintf("- **Code Volume:** ~%s lines (estimated) across %d repositories\n",
 		g.formatNumber(g.getTotalLinesOfCode(prof)), len(prof.Repositories)))
 	md.WriteString("\n")
 
 	// Technical Areas
 	if len(prof.Skills.TechnicalAreas) > 0 {
-		md.WriteString("### Technical Expertise Areas\n\n")
+		md.WriteString("### " + g.t("technical.expertise_areas") + "\n\n")
 
 		// Sort by competency
 		areas := make([]profile.TechnicalArea, len(prof.Skills.TechnicalAreas))
@@ -449,24 +731,191 @@ func (g *Generator) generateTechnicalTemplate(prof *profile.UserProfile) string
 				strings.Title(area.Area), area.Competency*10, area.ProjectCount, area.YearsActive))
 		}
 		md.WriteString("\n")
+
+		if g.mermaid {
+			g.writeMermaidSkillsMap(&md, prof.Username, areas)
+		}
+	}
+
+	// Languages picked up recently against long-standing ones
+	if note := languageTrendNote(prof.Skills.LanguageTrends); note != "" {
+		md.WriteString("### " + g.t("technical.evolving_skillset") + "\n\n")
+		md.WriteString(note + "\n\n")
 	}
 
 	// Architecture & Design Patterns
 	if len(prof.Insights.ArchitecturalThinking.ArchitecturalPatterns) > 0 {
-		md.WriteString("### Architecture & Design Patterns\n\n")
+		md.WriteString("### " + g.t("technical.architecture") + "\n\n")
 		for _, pattern := range prof.Insights.ArchitecturalThinking.ArchitecturalPatterns {
 			md.WriteString(fmt.Sprintf("- %s\n", pattern))
 		}
 		md.WriteString("\n")
 	}
 
+	// Security practices and how many repositories follow each
+	if practices := prof.Insights.ArchitecturalThinking.SecurityPractices; len(practices) > 0 {
+		md.WriteString("### " + g.t("technical.security_practices") + "\n\n")
+		names := make([]string, 0, len(practices))
+		for name := range practices {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if practices[names[i]] != practices[names[j]] {
+				return practices[names[i]] > practices[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		for _, name := range names {
+			unit := "repositories"
+			if practices[name] == 1 {
+				unit = "repository"
+			}
+			md.WriteString(fmt.Sprintf("- **%s:** %d %s\n", name, practices[name], unit))
+		}
+		md.WriteString("\n")
+	}
+
+	// Work on repositories owned by others
+	if collaborations := g.listedCollaborations(prof); len(collaborations) > 0 {
+		md.WriteString("### " + g.t("technical.collaborations") + "\n\n")
+		for _, collaboration := range collaborations {
+			md.WriteString(fmt.Sprintf("- **[%s](%s/%s):** %s, %s impact\n", escape(collaboration.Repository), g.githubURL,
+				collaboration.Repository, strings.Title(collaboration.CollaborationType), collaboration.ImpactLevel))
+		}
+		md.WriteString("\n")
+	}
+
+	// Licenses of owned repositories, missing ones being a red flag for reuse
+	if licensing := prof.Insights.Licensing; licensing != nil {
+		md.WriteString("### " + g.t("technical.licensing") + "\n\n")
+		md.WriteString(fmt.Sprintf("- **Permissive:** %s\n", repositoryCount(licensing.Permissive)))
+		md.WriteString(fmt.Sprintf("- **Copyleft:** %s\n", repositoryCount(licensing.Copyleft)))
+		if licensing.Other > 0 {
+			md.WriteString(fmt.Sprintf("- **Other:** %s\n", repositoryCount(licensing.Other)))
+		}
+		if licensing.Unlicensed > 0 {
+			md.WriteString(fmt.Sprintf("- **No License:** %s, which others cannot legally reuse\n", repositoryCount(licensing.Unlicensed)))
+		}
+		if licensing.MostUsed != "" {
+			md.WriteString(fmt.Sprintf("- **Most Used:** %s\n", escape(licensing.MostUsed)))
+		}
+		md.WriteString("\n")
+	}
+
 	// Detailed Project Breakdown
-	md.WriteString("## 🚀 Project Portfolio\n\n")
+	md.WriteString(g.sectionTitle("🚀", g.t("technical.project_portfolio")) + "\n\n")
+
+	if g.grouping == GroupByTopic {
+		g.writeTopicProjects(&md, prof)
+	} else {
+		g.writeLanguageProjects(&md, prof)
+	}
+
+	return md.String()
+}
+
+// maxCollaborations bounds the collaborations listed in the technical template
+const maxCollaborations = 10
+
+// listedCollaborations returns the highest impact collaborations whose repository may be named
+func (g *Generator) listedCollaborations(prof *profile.UserProfile) []profile.CollaborationProfile {
+	repos := make(map[string]profile.RepositoryProfile, len(prof.Repositories))
+	for _, repo := range prof.Repositories {
+		repos[repo.FullName] = repo
+	}
+
+	var listed []profile.CollaborationProfile
+	for _, collaboration := range prof.Collaborations {
+		if repo, ok := repos[collaboration.Repository]; ok && !g.listed(repo) {
+			continue
+		}
+		listed = append(listed, collaboration)
+		if len(listed) == maxCollaborations {
+			break
+		}
+	}
+	return listed
+}
+
+// repositoryCount formats n as "1 repository" or "n repositories"
+func repositoryCount(n int) string {
+	if n == 1 {
+		return "1 repository"
+	}
+	return fmt.Sprintf("%d repositories", n)
+}
+
+// licensingNote summarizes licensing as "3 permissive, 1 copyleft, 2 unlicensed (mostly MIT License)",
+// or returns an empty string when the user owns no repository
+func licensingNote(licensing *profile.LicenseSummary) string {
+	if licensing == nil {
+		return ""
+	}
+
+	parts := []string{
+		fmt.Sprintf("%d permissive", licensing.Permissive),
+		fmt.Sprintf("%d copyleft", licensing.Copyleft),
+	}
+	if licensing.Other > 0 {
+		parts = append(parts, fmt.Sprintf("%d other", licensing.Other))
+	}
+	parts = append(parts, fmt.Sprintf("%d unlicensed", licensing.Unlicensed))
+
+	note := strings.Join(parts, ", ")
+	if licensing.MostUsed != "" {
+		note += " (mostly " + escape(licensing.MostUsed) + ")"
+	}
+	return note
+}
+
+// datedOrganizations returns the organizations with activity dates, most recently active first
+func datedOrganizations(orgs []profile.OrganizationProfile) []profile.OrganizationProfile {
+	var dated []profile.OrganizationProfile
+	for _, org := range orgs {
+		if !org.FirstContribution.IsZero() && !org.LastContribution.IsZero() {
+			dated = append(dated, org)
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].LastContribution.After(dated[j].LastContribution)
+	})
+	return dated
+}
+
+// dateRange formats a period as "Mar 2018 - Jun 2024", or a single month when both fall in it
+func dateRange(from, to time.Time) string {
+	if from.Format("Jan 2006") == to.Format("Jan 2006") {
+		return from.Format("Jan 2006")
+	}
+	return from.Format("Jan 2006") + " - " + to.Format("Jan 2006")
+}
+
+// maxLanguageTrends bounds the languages named in the evolving skillset note
+const maxLanguageTrends = 5
+
+// languageTrendNote summarizes language trends as "Adopted Rust in 2023, Go since 2018", or returns
+// an empty string when every language was picked up the same year
+func languageTrendNote(trends []profile.LanguageTrend) string {
+	if len(trends) < 2 || trends[0].FirstYear == trends[len(trends)-1].FirstYear {
+		return ""
+	}
+	if len(trends) > maxLanguageTrends {
+		trends = trends[:maxLanguageTrends]
+	}
+
+	parts := []string{fmt.Sprintf("Adopted %s in %d", trends[0].Language, trends[0].FirstYear)}
+	for _, trend := range trends[1:] {
+		parts = append(parts, fmt.Sprintf("%s since %d", trend.Language, trend.FirstYear))
+	}
+	return strings.Join(parts, ", ") + "."
+}
 
+// writeLanguageProjects lists the top projects of each primary language
+func (g *Generator) writeLanguageProjects(md *strings.Builder, prof *profile.UserProfile) {
 	// Group repositories by language
 	langRepos := make(map[string][]profile.RepositoryProfile)
 	for _, repo := range prof.Repositories {
-		if repo.Language != "" {
+		if repo.Language != "" && g.listed(repo) {
 			langRepos[repo.Language] = append(langRepos[repo.Language], repo)
 		}
 	}
@@ -474,50 +923,92 @@ func (g *Generator) generateTechnicalTemplate(prof *profile.UserProfile) string
 	// Show top projects for each primary language
 	for _, lang := range prof.Skills.PrimaryLanguages {
 		if repos, exists := langRepos[lang]; exists {
-			// Sort by stars
-			sort.Slice(repos, func(i, j int) bool {
-				return repos[i].Stars > repos[j].Stars
-			})
+			g.writeProjectGroup(md, fmt.Sprintf(g.t("technical.language_projects"), lang), repos)
+		}
+	}
+}
 
-			md.WriteString(fmt.Sprintf("### %s Projects\n\n", lang))
+// writeTopicProjects lists the top projects of the most used GitHub topics.
+// A repository tagged with several topics appears under each of them.
+func (g *Generator) writeTopicProjects(md *strings.Builder, prof *profile.UserProfile) {
+	topicRepos := make(map[string][]profile.RepositoryProfile)
+	for _, repo := range prof.Repositories {
+		if !g.listed(repo) {
+			continue
+		}
+		for _, topic := range repo.Topics {
+			topicRepos[topic] = append(topicRepos[topic], repo)
+		}
+	}
 
-			count := 0
-			for _, repo := range repos {
-				if count >= 5 { // Limit to top 5 per language
-					break
-				}
+	// Most used topics first, alphabetically on ties so output is stable
+	topics := make([]string, 0, len(topicRepos))
+	for topic := range topicRepos {
+		topics = append(topics, topic)
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if len(topicRepos[topics[i]]) != len(topicRepos[topics[j]]) {
+			return len(topicRepos[topics[i]]) > len(topicRepos[topics[j]])
+		}
+		return topics[i] < topics[j]
+	})
+	if len(topics) > maxTopicGroups {
+		topics = topics[:maxTopicGroups]
+	}
 
-				md.WriteString(fmt.Sprintf("- **[%s](%s)**", repo.Name, repo.URL))
-				if repo.Stars > 0 {
-					md.WriteString(fmt.Sprintf(" ⭐ %d", repo.Stars))
-				}
-				md.WriteString("\n")
+	for _, topic := range topics {
+		g.writeProjectGroup(md, fmt.Sprintf(g.t("technical.topic_projects"), escape(topic)), topicRepos[topic])
+	}
+}
 
-				if repo.Description != "" {
-					md.WriteString(fmt.Sprintf("  - %s\n", repo.Description))
-				}
+// writeProjectGroup writes the most starred repositories of a group under header
+func (g *Generator) writeProjectGroup(md *strings.Builder, header string, repos []profile.RepositoryProfile) {
+	// Sort by stars
+	sort.Slice(repos, func(i, j int) bool {
+		return repos[i].Stars > repos[j].Stars
+	})
 
-				if len(repo.Topics) > 0 {
-					md.WriteString(fmt.Sprintf("  - Technologies: %s\n", strings.Join(repo.Topics, ", ")))
-				}
+	md.WriteString("### " + header + "\n\n")
 
-				count++
-			}
-			md.WriteString("\n")
+	count := 0
+	for _, repo := range repos {
+		if count >= 5 { // Limit to top 5 per group
+			break
 		}
-	}
 
-	return md.String()
+		md.WriteString(fmt.Sprintf("- **[%s](%s)**", escape(repo.Name), repo.URL))
+		if repo.Stars > 0 {
+			md.WriteString(fmt.Sprintf(" ⭐ %d", repo.Stars))
+		}
+		if repo.IsMonorepo {
+			md.WriteString(" *(monorepo)*")
+		}
+		md.WriteString("\n")
+
+		if description := repo.DisplayDescription(); description != "" {
+			md.WriteString(fmt.Sprintf("  - %s\n", escape(description)))
+		}
+
+		if len(repo.Topics) > 0 {
+			md.WriteString(fmt.Sprintf("  - Technologies: %s\n", strings.Join(escapeAll(repo.Topics), ", ")))
+		}
+
+		count++
+	}
+	md.WriteString("\n")
 }
 
 // generateExecutiveTemplate creates an executive summary focused template
 func (g *Generator) generateExecutiveTemplate(prof *profile.UserProfile) string {
 	var md strings.Builder
 
-	md.WriteString(fmt.Sprintf("# Executive Technical Summary - %s\n\n", prof.Username))
+	md.WriteString("# " + fmt.Sprintf(g.t("executive.title"), prof.Username) + "\n\n")
+	g.writeAvatar(&md, prof)
+
+	g.writeFeaturedProjects(&md, prof, "## "+g.t("executive.featured_projects"))
 
 	// Executive Summary
-	md.WriteString("## Executive Summary\n\n")
+	md.WriteString("## " + g.t("executive.summary") + "\n\n")
 
 	md.WriteString(fmt.Sprintf("%s-level software professional with %.0f years of active development experience. ",
 		strings.Title(prof.Insights.CareerLevel), float64(prof.Contributions.ContributionYears)))
@@ -537,7 +1028,7 @@ func (g *Generator) generateExecutiveTemplate(prof *profile.UserProfile) string
 	md.WriteString(fmt.Sprintf("Overall technical impact score: %.1f/10.\n\n", prof.Insights.OverallImpactScore*10))
 
 	// Leadership & Impact
-	md.WriteString("## Leadership & Impact\n\n")
+	md.WriteString("## " + g.t("executive.leadership") + "\n\n")
 
 	if len(prof.Insights.LeadershipIndicators) > 0 {
 		for _, indicator := range prof.Insights.LeadershipIndicators {
@@ -553,6 +1044,27 @@ func (g *Generator) generateExecutiveTemplate(prof *profile.UserProfile) string
 		md.WriteString(fmt.Sprintf("- **Open Source Leadership:** %d public repositories contributing to the developer community\n", osContributions))
 	}
 
+	if contributors, projects := g.getProjectCommunity(prof); contributors > 0 {
+		md.WriteString(fmt.Sprintf("- **%s:** %d contributors across %d owned projects\n",
+			g.t("executive.project_community"), contributors, projects))
+	}
+
+	if note := licensingNote(prof.Insights.Licensing); note != "" {
+		md.WriteString(fmt.Sprintf("- **%s:** %s\n", g.t("executive.licensing"), note))
+	}
+
+	// Pull request reviews for other contributors (-with-reviews)
+	if reviews := prof.ReviewActivity; reviews != nil && reviews.ReviewsOfOthers > 0 {
+		md.WriteString(fmt.Sprintf("- **Code Review Mentorship:** %d reviews of other contributors' pull requests", reviews.ReviewsOfOthers))
+		if reviews.FirstTimeContributors > 0 {
+			md.WriteString(fmt.Sprintf(", %d for first-time contributors", reviews.FirstTimeContributors))
+		}
+		if len(reviews.Organizations) > 1 {
+			md.WriteString(fmt.Sprintf(", across %d organizations", len(reviews.Organizations)))
+		}
+		md.WriteString("\n")
+	}
+
 	// Jenkins Community Leadership
 	if prof.DiscourseProfile != nil && prof.DiscourseProfile.TrustLevel >= 2 {
 		md.WriteString(fmt.Sprintf("- **Community Leadership:** Trust level %d in Jenkins community with %d solutions provided\n",
@@ -564,17 +1076,23 @@ func (g *Generator) generateExecutiveTemplate(prof *profile.UserProfile) string
 		}
 	}
 
+	// Stack Overflow knowledge sharing
+	if prof.StackOverflowProfile != nil && prof.StackOverflowProfile.AcceptedAnswers > 0 {
+		md.WriteString(fmt.Sprintf("- **Knowledge Sharing:** %s Stack Overflow reputation with %s accepted answers\n",
+			g.formatNumber(prof.StackOverflowProfile.Reputation), answerCount(prof.StackOverflowProfile.AcceptedAnswers, prof.StackOverflowProfile.AnswersCapped)))
+	}
+
 	md.WriteString("\n")
 
 	// Strategic Technical Focus
-	md.WriteString("## Strategic Technical Focus\n\n")
+	md.WriteString("## " + g.t("executive.technical_focus") + "\n\n")
 
 	if len(prof.Insights.TechnicalFocus) > 0 {
-		md.WriteString("### Core Technology Stack\n")
+		md.WriteString("### " + g.t("executive.core_stack") + "\n")
 		for _, tech := range prof.Insights.TechnicalFocus {
 			// Find the corresponding language stats
 			for _, lang := range prof.Languages {
-				if strings.EqualFold(lang.Language, tech) {
+				if strings.EqualFold(lang.Language, tech) && g.includeLanguage(lang) {
 					md.WriteString(fmt.Sprintf("- **%s:** %.1f%% of codebase, %d projects, %.1f years experience\n",
 						tech, lang.Percentage, lang.ProjectCount,
 						time.Since(lang.FirstUsed).Hours()/(24*365.25)))
@@ -587,7 +1105,7 @@ func (g *Generator) generateExecutiveTemplate(prof *profile.UserProfile) string
 
 	// Organizational Impact
 	if len(prof.Organizations) > 0 {
-		md.WriteString("### Organizational Contributions\n")
+		md.WriteString("### " + g.t("executive.organizations") + "\n")
 
 		// Sort organizations by contribution count
 		orgs := make([]profile.OrganizationProfile, len(prof.Organizations))
@@ -598,14 +1116,14 @@ func (g *Generator) generateExecutiveTemplate(prof *profile.UserProfile) string
 
 		for _, org := range orgs[:min(5, len(orgs))] { // Top 5 organizations
 			md.WriteString(fmt.Sprintf("- **%s:** %s role, %d project contributions\n",
-				org.Name, strings.Title(org.Role), org.ContributionCount))
+				escape(org.Name), strings.Title(org.Role), org.ContributionCount))
 		}
 		md.WriteString("\n")
 	}
 
 	// Recommended Executive Roles
 	if len(prof.Insights.RecommendedRoles) > 0 {
-		md.WriteString("## Recommended Leadership Roles\n\n")
+		md.WriteString("## " + g.t("executive.recommended_roles") + "\n\n")
 
 		// Filter for senior/leadership roles
 		var leadershipRoles []string
@@ -629,7 +1147,7 @@ func (g *Generator) generateExecutiveTemplate(prof *profile.UserProfile) string
 	}
 
 	// Key Performance Metrics
-	md.WriteString("## Key Performance Metrics\n\n")
+	md.WriteString("## " + g.t("executive.metrics") + "\n\n")
 
 	md.WriteString(fmt.Sprintf("- **Technical Productivity:** %d commits, %d pull requests, %d issues resolved\n",
 		prof.Contributions.TotalCommits, prof.Contributions.TotalPullRequests, prof.Contributions.TotalIssues))
@@ -643,9 +1161,64 @@ func (g *Generator) generateExecutiveTemplate(prof *profile.UserProfile) string
 	md.WriteString(fmt.Sprintf("- **Technical Breadth:** %d programming languages, %d technology areas\n",
 		len(prof.Languages), len(prof.Skills.TechnicalAreas)))
 
+	if years, counts := yearlySeries(prof.Contributions.YearlyContributions); len(years) > 0 {
+		low, high := counts[0], counts[0]
+		for _, count := range counts {
+			low, high = min(low, count), max(high, count)
+		}
+		md.WriteString(fmt.Sprintf("- **Contribution Trend:** %s (%d-%d, %s-%s contributions per year)\n",
+			sparkline(counts), years[0], years[len(years)-1], g.formatNumber(low), g.formatNumber(high)))
+	}
+
 	return md.String()
 }
 
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as a row of block characters scaled to the largest value
+func sparkline(values []int) string {
+	highest := 0
+	for _, value := range values {
+		highest = max(highest, value)
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if highest > 0 && value > 0 {
+			level = value * (len(sparkBlocks) - 1) / highest
+		}
+		line.WriteRune(sparkBlocks[level])
+	}
+	return line.String()
+}
+
+// yearlySeries orders yearly contributions by year, filling years without contributions with zero
+func yearlySeries(yearly map[string]int) ([]int, []int) {
+	var first, last int
+	for key := range yearly {
+		year, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		if first == 0 || year < first {
+			first = year
+		}
+		last = max(last, year)
+	}
+	if first == 0 {
+		return nil, nil
+	}
+
+	var years, counts []int
+	for year := first; year <= last; year++ {
+		years = append(years, year)
+		counts = append(counts, yearly[strconv.Itoa(year)])
+	}
+	return years, counts
+}
+
 // generateATSTemplate creates an ATS-optimized profile
 func (g *Generator) generateATSTemplate(prof *profile.UserProfile) string {
 	var md strings.Builder
@@ -669,31 +1242,28 @@ func (g *Generator) generateATSTemplate(prof *profile.UserProfile) string {
 
 	md.WriteString("Programming Languages: ")
 	var languages []string
-	for _, lang := range prof.Languages {
-		if lang.Percentage > 1 { // Only include languages with >1% usage
+	for _, lang := range g.sortedLanguages(prof) {
+		if g.includeLanguage(lang) {
 			languages = append(languages, lang.Language)
 		}
 	}
 	md.WriteString(strings.Join(languages, ", "))
 	md.WriteString("\n\n")
 
-	if len(prof.Skills.Frameworks) > 0 {
+	if frameworks := g.getTechnologyNames(prof.Skills.Frameworks, 10); len(frameworks) > 0 {
 		md.WriteString("Frameworks and Libraries: ")
-		frameworks := g.getTechnologyNames(prof.Skills.Frameworks, 10)
 		md.WriteString(strings.Join(frameworks, ", "))
 		md.WriteString("\n\n")
 	}
 
-	if len(prof.Skills.Databases) > 0 {
+	if databases := g.getTechnologyNames(prof.Skills.Databases, 10); len(databases) > 0 {
 		md.WriteString("Databases: ")
-		databases := g.getTechnologyNames(prof.Skills.Databases, 10)
 		md.WriteString(strings.Join(databases, ", "))
 		md.WriteString("\n\n")
 	}
 
-	if len(prof.Skills.CloudPlatforms) > 0 {
+	if cloud := g.getTechnologyNames(prof.Skills.CloudPlatforms, 10); len(cloud) > 0 {
 		md.WriteString("Cloud Platforms: ")
-		cloud := g.getTechnologyNames(prof.Skills.CloudPlatforms, 10)
 		md.WriteString(strings.Join(cloud, ", "))
 		md.WriteString("\n\n")
 	}
@@ -756,8 +1326,8 @@ func (g *Generator) generateATSTemplate(prof *profile.UserProfile) string {
 		}
 
 		md.WriteString(fmt.Sprintf("%s\n", repo.Name))
-		if repo.Description != "" {
-			md.WriteString(fmt.Sprintf("Description: %s\n", repo.Description))
+		if description := repo.DisplayDescription(); description != "" {
+			md.WriteString(fmt.Sprintf("Description: %s\n", description))
 		}
 		md.WriteString(fmt.Sprintf("Technology: %s\n", repo.Language))
 		if repo.Stars > 0 {
@@ -769,7 +1339,16 @@ func (g *Generator) generateATSTemplate(prof *profile.UserProfile) string {
 	// Education/Certifications equivalent
 	md.WriteString("TECHNICAL CERTIFICATIONS AND EXPERTISE\n\n")
 
-	for _, lang := range prof.Languages[:min(5, len(prof.Languages))] {
+	certified := 0
+	for _, lang := range g.sortedLanguages(prof) {
+		if !g.includeLanguage(lang) {
+			continue
+		}
+		if certified >= 5 {
+			break
+		}
+		certified++
+
 		profLevel := "Intermediate"
 		if lang.Percentage > 25 {
 			profLevel = "Advanced"
@@ -785,6 +1364,90 @@ func (g *Generator) generateATSTemplate(prof *profile.UserProfile) string {
 	return md.String()
 }
 
+// generateOrganizationTemplate creates an organization profile: overview, top repositories by stars
+// and the language breakdown across all public repositories
+func (g *Generator) generateOrganizationTemplate(prof *profile.UserProfile) string {
+	var md strings.Builder
+
+	md.WriteString("# " + fmt.Sprintf(g.t("organization.title"), prof.Username) + "\n\n")
+	g.writeAvatar(&md, prof)
+
+	if prof.Name != "" {
+		md.WriteString(fmt.Sprintf("**%s**\n\n", escape(prof.Name)))
+	}
+	if prof.Bio != "" {
+		md.WriteString(escape(prof.Bio) + "\n\n")
+	}
+
+	// Overview
+	totalForks := 0
+	for _, repo := range prof.Repositories {
+		totalForks += repo.Forks
+	}
+
+	md.WriteString("## " + g.t("organization.overview") + "\n\n")
+	md.WriteString(fmt.Sprintf("- **Public Repositories:** %d\n", len(prof.Repositories)))
+	md.WriteString(fmt.Sprintf("- **Total Stars:** %s\n", g.formatNumber(g.getTotalStars(prof))))
+	md.WriteString(fmt.Sprintf("- **Total Forks:** %s\n", g.formatNumber(totalForks)))
+	if prof.MemberCount > 0 {
+		md.WriteString(fmt.Sprintf("- **Members:** %d\n", prof.MemberCount))
+	}
+	md.WriteString(fmt.Sprintf("- **Commits:** %s on default branches\n", g.formatNumber(prof.Contributions.TotalCommits)))
+	md.WriteString(fmt.Sprintf("- **Pull Requests:** %s\n", g.formatNumber(prof.Contributions.TotalPullRequests)))
+	if prof.Location != "" {
+		md.WriteString(fmt.Sprintf("- **Location:** %s\n", escape(prof.Location)))
+	}
+	if prof.BlogURL != "" {
+		md.WriteString(fmt.Sprintf("- **Website:** %s\n", prof.BlogURL))
+	}
+	md.WriteString("\n")
+
+	// Top repositories
+	repos := make([]profile.RepositoryProfile, len(prof.Repositories))
+	copy(repos, prof.Repositories)
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].Stars > repos[j].Stars
+	})
+	if len(repos) > maxOrganizationRepositories {
+		repos = repos[:maxOrganizationRepositories]
+	}
+
+	if len(repos) > 0 {
+		md.WriteString("## " + g.t("organization.top_repositories") + "\n\n")
+		for _, repo := range repos {
+			md.WriteString(fmt.Sprintf("### [%s](%s)\n", escape(repo.Name), repo.URL))
+			if description := repo.DisplayDescription(); description != "" {
+				md.WriteString(escape(description) + "\n\n")
+			}
+			md.WriteString(fmt.Sprintf("- **Stars:** %d | **Forks:** %d", repo.Stars, repo.Forks))
+			if repo.Language != "" {
+				md.WriteString(fmt.Sprintf(" | **Language:** %s", repo.Language))
+			}
+			md.WriteString("\n\n")
+		}
+	}
+
+	// Language breakdown
+	var languages []profile.LanguageStats
+	for _, lang := range prof.Languages {
+		if g.includeLanguage(lang) {
+			languages = append(languages, lang)
+		}
+	}
+
+	if len(languages) > 0 {
+		md.WriteString("## " + g.t("organization.languages") + "\n\n")
+		md.WriteString("| Language | Share | Repositories |\n")
+		md.WriteString("|----------|-------|--------------|\n")
+		for _, lang := range languages {
+			md.WriteString(fmt.Sprintf("| %s | %.1f%% | %d |\n", lang.Language, lang.Percentage, lang.ProjectCount))
+		}
+		md.WriteString("\n")
+	}
+
+	return md.String()
+}
+
 // Helper functions
 
 func (g *Generator) getTotalStars(prof *profile.UserProfile) int {
@@ -795,6 +1458,40 @@ func (g *Generator) getTotalStars(prof *profile.UserProfile) int {
 	return total
 }
 
+// getProjectCommunity returns the distinct contributors to the user's owned repositories and the
+// number of owned repositories that have any
+func (g *Generator) getProjectCommunity(prof *profile.UserProfile) (int, int) {
+	contributors := make(map[string]bool)
+	projects := 0
+	for _, repo := range prof.Repositories {
+		if !repo.IsOwner || len(repo.Contributors) == 0 {
+			continue
+		}
+		projects++
+		for _, login := range repo.Contributors {
+			contributors[strings.ToLower(login)] = true
+		}
+	}
+	return len(contributors), projects
+}
+
+// getMostContributedProjects returns up to limit owned repositories with the most contributors
+func (g *Generator) getMostContributedProjects(prof *profile.UserProfile, limit int) []profile.RepositoryProfile {
+	var repos []profile.RepositoryProfile
+	for _, repo := range prof.Repositories {
+		if repo.IsOwner && len(repo.Contributors) > 0 && g.listed(repo) {
+			repos = append(repos, repo)
+		}
+	}
+	sort.SliceStable(repos, func(i, j int) bool {
+		return len(repos[i].Contributors) > len(repos[j].Contributors)
+	})
+	if len(repos) > limit {
+		repos = repos[:limit]
+	}
+	return repos
+}
+
 func (g *Generator) getTotalLinesOfCode(prof *profile.UserProfile) int {
 	total := 0
 	for _, lang := range prof.Languages {
@@ -807,16 +1504,20 @@ func (g *Generator) getNotableRepositories(prof *profile.UserProfile) []profile.
 	var notable []profile.RepositoryProfile
 
 	for _, repo := range prof.Repositories {
+		if !g.listed(repo) {
+			continue
+		}
 		// Consider notable if: has stars, is owned by user, or has significant size
 		if repo.Stars > 0 || repo.IsOwner || repo.Size > 1000 {
 			notable = append(notable, repo)
 		}
 	}
 
-	// Sort by stars, then by size
+	// Sort by stars, decayed for stale repositories with -star-decay, then by size
 	sort.Slice(notable, func(i, j int) bool {
-		if notable[i].Stars != notable[j].Stars {
-			return notable[i].Stars > notable[j].Stars
+		si, sj := notable[i].DecayedStars(g.starDecay), notable[j].DecayedStars(g.starDecay)
+		if si != sj {
+			return si > sj
 		}
 		return notable[i].Size > notable[j].Size
 	})
@@ -829,6 +1530,69 @@ func (g *Generator) getNotableRepositories(prof *profile.UserProfile) []profile.
 	return notable
 }
 
+// writeFeaturedProjects lists the featured repositories under header, one line each
+func (g *Generator) writeFeaturedProjects(md *strings.Builder, prof *profile.UserProfile, header string) {
+	featured := g.getFeaturedRepositories(prof)
+	if len(featured) == 0 {
+		return
+	}
+
+	md.WriteString(header + "\n\n")
+	for _, repo := range featured {
+		md.WriteString(fmt.Sprintf("- **[%s](%s)**", escape(repo.Name), repo.URL))
+		if repo.Stars > 0 {
+			md.WriteString(fmt.Sprintf(" ⭐ %d", repo.Stars))
+		}
+		if repo.IsMonorepo {
+			md.WriteString(" *(monorepo)*")
+		}
+		if description := repo.DisplayDescription(); description != "" {
+			md.WriteString(" - " + escape(description))
+		}
+		md.WriteString("\n")
+	}
+	md.WriteString("\n")
+}
+
+// getFeaturedRepositories returns the repositories pinned by the user, in their pinned order,
+// falling back to the most notable repositories when nothing is pinned.
+// Pinned repositories that were not analyzed are still listed, linked by name only.
+func (g *Generator) getFeaturedRepositories(prof *profile.UserProfile) []profile.RepositoryProfile {
+	if len(prof.PinnedRepositories) == 0 {
+		notable := g.getNotableRepositories(prof)
+		if len(notable) > maxFeaturedRepositories {
+			notable = notable[:maxFeaturedRepositories]
+		}
+		return notable
+	}
+
+	analyzed := make(map[string]profile.RepositoryProfile, len(prof.Repositories))
+	for _, repo := range prof.Repositories {
+		analyzed[strings.ToLower(repo.FullName)] = repo
+	}
+
+	var featured []profile.RepositoryProfile
+	for _, fullName := range prof.PinnedRepositories {
+		if len(featured) == maxFeaturedRepositories {
+			break
+		}
+		if repo, ok := analyzed[strings.ToLower(fullName)]; ok {
+			if g.listed(repo) {
+				featured = append(featured, repo)
+			}
+			continue
+		}
+		name := fullName[strings.LastIndex(fullName, "/")+1:]
+		featured = append(featured, profile.RepositoryProfile{
+			Name:     name,
+			FullName: fullName,
+			URL:      g.githubURL + "/" + fullName,
+		})
+	}
+
+	return featured
+}
+
 func (g *Generator) getRecentRepositories(prof *profile.UserProfile, days int) []profile.RepositoryProfile {
 	cutoff := time.Now().AddDate(0, 0, -days)
 	var recent []profile.RepositoryProfile
@@ -842,6 +1606,8 @@ func (g *Generator) getRecentRepositories(prof *profile.UserProfile, days int) [
 	return recent
 }
 
+// getTechnologyNames returns up to limit skill names, most confident first, leaving out skills
+// below the minimum confidence
 func (g *Generator) getTechnologyNames(skills []profile.TechnologySkill, limit int) []string {
 	var names []string
 
@@ -850,10 +1616,21 @@ func (g *Generator) getTechnologyNames(skills []profile.TechnologySkill, limit i
 		return skills[i].Confidence > skills[j].Confidence
 	})
 
-	for i, skill := range skills {
-		if i >= limit {
+	// Skip repeated names, which profiles analyzed before skill normalization may contain
+	seen := make(map[string]bool)
+	for _, skill := range skills {
+		if len(names) >= limit {
+			break
+		}
+		if skill.Confidence < g.minSkillConfidence {
+			// Sorted by confidence, so no later skill qualifies either
 			break
 		}
+		key := strings.ToLower(skill.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
 		names = append(names, skill.Name)
 	}
 
@@ -896,6 +1673,15 @@ func (g *Generator) getAverageStars(prof *profile.UserProfile) float64 {
 	return float64(totalStars) / float64(ownedRepos)
 }
 
+// answerCount formats a Stack Overflow answer count, with a "+" when counting stopped at the
+// answer page cap and the count is only a lower bound
+func answerCount(count int, capped bool) string {
+	if capped {
+		return strconv.Itoa(count) + "+"
+	}
+	return strconv.Itoa(count)
+}
+
 func (g *Generator) formatNumber(num int) string {
 	if num < 1000 {
 		return fmt.Sprintf("%d", num)
@@ -0,0 +1,870 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/profile"
+)
+
+// createSampleProfile creates a minimal analyzed profile for template tests
+func createSampleProfile() *profile.UserProfile {
+	return &profile.UserProfile{
+		Username:  "testuser",
+		Name:      "Test User",
+		CreatedAt: time.Now().AddDate(-5, 0, 0),
+		Languages: []profile.LanguageStats{
+			{Language: "Go", Bytes: 300000, Percentage: 80, LinesOfCode: 10000, FirstUsed: time.Now().AddDate(-4, 0, 0)},
+			{Language: "Python", Bytes: 75000, Percentage: 20, LinesOfCode: 3000, FirstUsed: time.Now().AddDate(-2, 0, 0)},
+		},
+		Repositories: []profile.RepositoryProfile{
+			{Name: "tool", FullName: "testuser/tool", Language: "Go", Stars: 42, IsOwner: true},
+		},
+		Insights: profile.UserInsights{CareerLevel: "senior"},
+	}
+}
+
+// TestGenerateCombinedMarkdown verifies the combined document contains every selected template
+func TestGenerateCombinedMarkdown(t *testing.T) {
+	generator := NewGenerator()
+
+	content, err := generator.GenerateCombinedMarkdown(createSampleProfile(), []TemplateType{ResumeTemplate, TechnicalTemplate})
+	if err != nil {
+		t.Fatalf("GenerateCombinedMarkdown failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"# Combined Profile - testuser",
+		"[Resume](#resume-profile)",
+		"[Technical Profile](#technical-profile)",
+		"# GitHub Professional Profile - testuser",
+		"## 🔧 Technical Overview",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected combined output to contain %q", expected)
+		}
+	}
+
+	if strings.Count(content, "\n---\n") < 2 {
+		t.Errorf("Expected a separator before each template")
+	}
+	if strings.Index(content, "# GitHub Professional Profile") > strings.Index(content, "## 🔧 Technical Overview") {
+		t.Errorf("Expected templates in the requested order")
+	}
+}
+
+// TestGenerateCombinedMarkdownUnknownTemplate verifies unknown templates are rejected
+func TestGenerateCombinedMarkdownUnknownTemplate(t *testing.T) {
+	generator := NewGenerator()
+
+	if _, err := generator.GenerateCombinedMarkdown(createSampleProfile(), []TemplateType{"poster"}); err == nil {
+		t.Error("Expected error for unknown template type")
+	}
+}
+
+// createProfileWithMinorLanguage returns a sample profile with a language at 0.5% of the codebase
+func createProfileWithMinorLanguage() *profile.UserProfile {
+	prof := createSampleProfile()
+	prof.Languages = append(prof.Languages, profile.LanguageStats{
+		Language: "Makefile", Bytes: 2000, Percentage: 0.5, LinesOfCode: 80, FirstUsed: time.Now().AddDate(-1, 0, 0),
+	})
+	prof.Skills.PrimaryLanguages = []string{"Go", "Python"}
+	prof.Insights.TechnicalFocus = []string{"Go", "Makefile"}
+	return prof
+}
+
+// TestMinLanguagePercentDefault verifies a 0.5% language is omitted from every template by default
+func TestMinLanguagePercentDefault(t *testing.T) {
+	generator := NewGenerator()
+	prof := createProfileWithMinorLanguage()
+
+	for _, templateType := range []TemplateType{ResumeTemplate, TechnicalTemplate, ATSTemplate} {
+		content, err := generator.GenerateMarkdown(prof, templateType)
+		if err != nil {
+			t.Fatalf("GenerateMarkdown(%s) failed: %v", templateType, err)
+		}
+		if strings.Contains(content, "Makefile") {
+			t.Errorf("Expected %s template to omit a 0.5%% language at the default threshold", templateType)
+		}
+		if !strings.Contains(content, "Go") {
+			t.Errorf("Expected %s template to list Go", templateType)
+		}
+	}
+
+	// The technical focus section must honor the same threshold
+	content, _ := generator.GenerateMarkdown(prof, ExecutiveTemplate)
+	if strings.Contains(content, "**Makefile:**") {
+		t.Errorf("Expected technical focus to omit Makefile at the default threshold")
+	}
+}
+
+// TestMinLanguagePercentLowered verifies a 0.5% language is listed once the threshold is 0.1%
+func TestMinLanguagePercentLowered(t *testing.T) {
+	generator := NewGenerator()
+	generator.SetMinLanguagePercent(0.1)
+	prof := createProfileWithMinorLanguage()
+
+	for _, templateType := range []TemplateType{ResumeTemplate, TechnicalTemplate, ATSTemplate} {
+		content, err := generator.GenerateMarkdown(prof, templateType)
+		if err != nil {
+			t.Fatalf("GenerateMarkdown(%s) failed: %v", templateType, err)
+		}
+		if !strings.Contains(content, "Makefile") {
+			t.Errorf("Expected %s template to list a 0.5%% language at a 0.1%% threshold", templateType)
+		}
+	}
+
+	// The technical focus section must honor the same threshold
+	content, _ := generator.GenerateMarkdown(prof, ExecutiveTemplate)
+	if !strings.Contains(content, "**Makefile:** 0.5%") {
+		t.Errorf("Expected technical focus to include Makefile at a 0.1%% threshold")
+	}
+}
+
+// TestGetTechnologyNamesSkipsDuplicates verifies a skill listed twice is named once
+func TestGetTechnologyNamesSkipsDuplicates(t *testing.T) {
+	generator := NewGenerator()
+	skills := []profile.TechnologySkill{
+		{Name: "Docker", Confidence: 0.9},
+		{Name: "docker", Confidence: 0.5},
+		{Name: "AWS", Confidence: 0.7},
+	}
+
+	names := generator.getTechnologyNames(skills, 10)
+	if strings.Join(names, ",") != "Docker,AWS" {
+		t.Errorf("Expected [Docker AWS], got %v", names)
+	}
+}
+
+// TestAnonymizedResume verifies email and name are absent from the resume of an anonymized profile
+func TestAnonymizedResume(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Email = "test.user@example.com"
+	prof.Location = "Springfield"
+	prof.TwitterUsername = "testuser_tweets"
+
+	profile.Anonymize(prof)
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	for _, personal := range []string{"Test User", "test.user@example.com", "Springfield", "testuser_tweets"} {
+		if strings.Contains(content, personal) {
+			t.Errorf("Expected anonymized resume not to contain %q", personal)
+		}
+	}
+}
+
+// TestStackOverflowCappedAnswers verifies capped answer counts are shown as lower bounds
+func TestStackOverflowCappedAnswers(t *testing.T) {
+	prof := createSampleProfile()
+	prof.StackOverflowProfile = &profile.StackOverflowProfile{
+		DisplayName:     "Test User",
+		AnswerCount:     1000,
+		AcceptedAnswers: 400,
+		AnswersCapped:   true,
+	}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(content, "1000+ answers, 400+ accepted") {
+		t.Errorf("Expected capped counts marked as lower bounds:\n%s", content)
+	}
+
+	prof.StackOverflowProfile.AnswersCapped = false
+	content, err = NewGenerator().GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(content, "1000 answers, 400 accepted") {
+		t.Errorf("Expected exact counts when not capped:\n%s", content)
+	}
+}
+
+// createProfileWithPinnedRepository creates a profile whose pinned repository is less notable than another
+func createProfileWithPinnedRepository() *profile.UserProfile {
+	prof := createSampleProfile()
+	prof.Repositories = append(prof.Repositories,
+		profile.RepositoryProfile{Name: "side-project", FullName: "testuser/side-project", URL: "https://github.com/testuser/side-project", Description: "Pinned on purpose"},
+		profile.RepositoryProfile{Name: "popular", FullName: "testuser/popular", URL: "https://github.com/testuser/popular", Stars: 500, IsOwner: true},
+	)
+	prof.PinnedRepositories = []string{"testuser/side-project", "other/unanalyzed"}
+	return prof
+}
+
+// TestFeaturedRepositoriesPinned verifies pinned repositories are featured in pinned order, ahead of notable ones
+func TestFeaturedRepositoriesPinned(t *testing.T) {
+	featured := NewGenerator().getFeaturedRepositories(createProfileWithPinnedRepository())
+
+	if len(featured) != 2 {
+		t.Fatalf("Expected only the 2 pinned repositories, got %+v", featured)
+	}
+	if featured[0].Name != "side-project" || featured[0].Description != "Pinned on purpose" {
+		t.Errorf("Expected the analyzed pinned repository first, got %+v", featured[0])
+	}
+	if featured[1].Name != "unanalyzed" || featured[1].URL != "https://github.com/other/unanalyzed" {
+		t.Errorf("Expected the unanalyzed pinned repository to be linked by name, got %+v", featured[1])
+	}
+}
+
+// TestFeaturedRepositoriesFallback verifies notable repositories are featured when nothing is pinned
+func TestFeaturedRepositoriesFallback(t *testing.T) {
+	prof := createProfileWithPinnedRepository()
+	prof.PinnedRepositories = nil
+
+	featured := NewGenerator().getFeaturedRepositories(prof)
+	if len(featured) == 0 || featured[0].Name != "popular" {
+		t.Errorf("Expected the most starred repository first, got %+v", featured)
+	}
+}
+
+// TestFeaturedProjectsSection verifies the resume and executive templates open with the pinned repositories
+func TestFeaturedProjectsSection(t *testing.T) {
+	generator := NewGenerator()
+	for _, templateType := range []TemplateType{ResumeTemplate, ExecutiveTemplate} {
+		content, err := generator.GenerateMarkdown(createProfileWithPinnedRepository(), templateType)
+		if err != nil {
+			t.Fatalf("GenerateMarkdown(%s) failed: %v", templateType, err)
+		}
+
+		section := strings.Index(content, "Featured Projects")
+		pinned := strings.Index(content, "[side-project]")
+		popular := strings.Index(content, "[popular]")
+		if section < 0 || pinned < section {
+			t.Errorf("Expected the pinned repository in the %s Featured Projects section:\n%s", templateType, content)
+		}
+		if popular >= 0 && popular < pinned {
+			t.Errorf("Expected the pinned repository ahead of the notable one in the %s template", templateType)
+		}
+	}
+}
+
+// TestReadmeSummaryInTemplates verifies README excerpts stand in for empty repository descriptions
+func TestReadmeSummaryInTemplates(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Repositories[0].ReadmeSummary = "A command-line tool that summarizes GitHub activity."
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(content, "**Description:** A command-line tool that summarizes GitHub activity.") {
+		t.Errorf("Expected the README excerpt as description:\n%s", content)
+	}
+}
+
+// TestGroupByTopic verifies a repository tagged with two topics is listed under both topic groups
+func TestGroupByTopic(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Repositories = []profile.RepositoryProfile{
+		{Name: "operator", URL: "https://github.com/testuser/operator", Language: "Go", Stars: 10, Topics: []string{"kubernetes", "machine-learning"}},
+		{Name: "charts", URL: "https://github.com/testuser/charts", Stars: 3, Topics: []string{"kubernetes"}},
+	}
+
+	generator := NewGenerator()
+	generator.SetGrouping(GroupByTopic)
+	content, err := generator.GenerateMarkdown(prof, TechnicalTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	portfolio := content[strings.Index(content, "Project Portfolio"):]
+	kubernetes := strings.Index(portfolio, "### Topic: kubernetes")
+	machineLearning := strings.Index(portfolio, "### Topic: machine-learning")
+	if kubernetes < 0 || machineLearning < 0 {
+		t.Fatalf("Expected both topic groups in the portfolio:\n%s", portfolio)
+	}
+	if kubernetes > machineLearning {
+		t.Error("Expected the topic with more repositories first")
+	}
+	if strings.Count(portfolio, "[operator]") != 2 {
+		t.Errorf("Expected the repository under both topics:\n%s", portfolio)
+	}
+	if strings.Contains(portfolio, "Go Projects") {
+		t.Error("Expected no language groups when grouping by topic")
+	}
+}
+
+// TestSparkline verifies values are scaled to one block per value, zero to the lowest and the maximum to the highest
+func TestSparkline(t *testing.T) {
+	line := sparkline([]int{0, 10, 35, 70})
+	if got := len([]rune(line)); got != 4 {
+		t.Fatalf("Expected 4 blocks, got %d in %q", got, line)
+	}
+	if line != "▁▂▄█" {
+		t.Errorf("Unexpected sparkline %q", line)
+	}
+	if line := sparkline([]int{0, 0}); line != "▁▁" {
+		t.Errorf("Expected flat sparkline for no contributions, got %q", line)
+	}
+}
+
+// TestContributionTrend verifies the executive metrics show the yearly sparkline, filling gaps between years
+func TestContributionTrend(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Contributions.YearlyContributions = map[string]int{"2020": 100, "2022": 400, "2023": 800}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ExecutiveTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(content, "- **Contribution Trend:** ▁▁▄█ (2020-2023, 0-800 contributions per year)") {
+		t.Errorf("Expected the contribution trend in the executive metrics:\n%s", content)
+	}
+}
+
+// TestOrganizationTemplate verifies the organization template lists repositories by stars with the language breakdown
+func TestOrganizationTemplate(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Username = "acme"
+	prof.IsOrganization = true
+	prof.MemberCount = 12
+	prof.Repositories = []profile.RepositoryProfile{
+		{Name: "cli", URL: "https://github.com/acme/cli", Stars: 30, Forks: 2},
+		{Name: "api", URL: "https://github.com/acme/api", Stars: 120, Forks: 10, Language: "Go"},
+	}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, OrganizationTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"# Organization Profile - acme",
+		"- **Total Stars:** 150",
+		"- **Members:** 12",
+		"| Go | 80.0% |",
+		"| Python | 20.0% |",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in organization profile:\n%s", expected, content)
+		}
+	}
+	if api, cli := strings.Index(content, "[api]"), strings.Index(content, "[cli]"); api < 0 || api > cli {
+		t.Error("Expected the most starred repository first")
+	}
+}
+
+// TestGenerateDiffMarkdown verifies deltas are rendered with explicit signs
+func TestGenerateDiffMarkdown(t *testing.T) {
+	diff := &profile.ProfileDiff{
+		Username:          "testuser",
+		NewRepositories:   []string{"testuser/operator"},
+		StarChanges:       []profile.StarChange{{Repository: "testuser/tool", Before: 10, After: 25}},
+		StarsBefore:       16,
+		StarsAfter:        33,
+		Contributions:     profile.ContributionGrowth{Commits: 150},
+		CareerLevelBefore: "mid",
+		CareerLevelAfter:  "senior",
+	}
+
+	content := NewGenerator().GenerateDiffMarkdown(diff)
+	for _, expected := range []string{
+		"# Profile Changes - testuser",
+		"- **Stars:** 16 → 33 (+17)",
+		"- **Career Level:** Mid → Senior",
+		"- **Commits:** +150",
+		"- **testuser/tool:** 10 → 25 (+15)",
+		"- testuser/operator",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in diff:\n%s", expected, content)
+		}
+	}
+}
+
+// TestEscapeUserText verifies a description containing "|" and "_" cannot split a table row or start emphasis
+func TestEscapeUserText(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Repositories[0].Name = "a_b_c"
+	prof.Repositories[0].Description = "Parses *.csv | *.tsv files into snake_case_columns"
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(content, `**Description:** Parses \*.csv \| \*.tsv files into snake\_case\_columns`) {
+		t.Errorf("Expected the description to be escaped:\n%s", content)
+	}
+	if !strings.Contains(content, `[a\_b\_c]`) {
+		t.Errorf("Expected the repository name to be escaped:\n%s", content)
+	}
+
+	// The escaped text keeps a table row at its two cells
+	row := "| " + escape(prof.Repositories[0].Description) + " | Go |"
+	cells := 0
+	for i := 0; i < len(row); i++ {
+		if row[i] == '|' && (i == 0 || row[i-1] != '\\') {
+			cells++
+		}
+	}
+	if cells != 3 {
+		t.Errorf("Expected 3 unescaped pipes delimiting 2 cells, got %d in %q", cells, row)
+	}
+	if escape("line one\nline two") != "line one line two" {
+		t.Error("Expected line breaks to be folded")
+	}
+}
+
+// TestPartialBanner verifies profiles of interrupted analyses are flagged once per document
+func TestPartialBanner(t *testing.T) {
+	prof := createSampleProfile()
+	generator := NewGenerator()
+
+	content, err := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if strings.Contains(content, "PARTIAL") {
+		t.Errorf("Expected no banner for a complete profile:\n%s", content)
+	}
+
+	prof.Partial = true
+	content, err = generator.GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.HasPrefix(content, "> ⚠️ **PARTIAL:**") {
+		t.Errorf("Expected the document to open with the PARTIAL banner:\n%s", content)
+	}
+
+	combined, err := generator.GenerateCombinedMarkdown(prof, []TemplateType{ResumeTemplate, TechnicalTemplate})
+	if err != nil {
+		t.Fatalf("GenerateCombinedMarkdown failed: %v", err)
+	}
+	if count := strings.Count(combined, "**PARTIAL:**"); count != 1 {
+		t.Errorf("Expected a single banner in the combined document, got %d", count)
+	}
+}
+
+// TestProjectCommunity verifies contributors to owned repositories are aggregated without duplicates
+func TestProjectCommunity(t *testing.T) {
+	prof := createSampleProfile()
+	generator := NewGenerator()
+
+	if content, _ := generator.GenerateMarkdown(prof, ResumeTemplate); strings.Contains(content, "Community Around My Projects") {
+		t.Errorf("Expected no community section without contributors:\n%s", content)
+	}
+
+	prof.Repositories = append(prof.Repositories,
+		profile.RepositoryProfile{Name: "tool-a", IsOwner: true, Contributors: []string{"hubot", "monalisa"}},
+		profile.RepositoryProfile{Name: "tool-b", IsOwner: true, Contributors: []string{"monalisa", "mona"}},
+		profile.RepositoryProfile{Name: "upstream", IsOwner: false, Contributors: []string{"someone"}},
+	)
+
+	resume, err := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(resume, "## 🤝 Community Around My Projects") || !strings.Contains(resume, "- **3** contributors across **2** owned projects") {
+		t.Errorf("Expected 3 distinct contributors across 2 owned projects in the resume:\n%s", resume)
+	}
+
+	executive, err := generator.GenerateMarkdown(prof, ExecutiveTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(executive, "- **Community Around My Projects:** 3 contributors across 2 owned projects") {
+		t.Errorf("Expected the community note in the executive summary:\n%s", executive)
+	}
+}
+
+// TestMonorepoTag verifies repositories detected as monorepos are labeled in project listings
+func TestMonorepoTag(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Repositories[0].IsMonorepo = true
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(content, "⭐ 42 *(monorepo)*") {
+		t.Errorf("Expected the monorepo to be labeled:\n%s", content)
+	}
+}
+
+// TestNoEmojiHeaders verifies disabling emoji leaves plain section headers in every template
+func TestNoEmojiHeaders(t *testing.T) {
+	prof := createSampleProfile()
+	generator := NewGenerator()
+
+	content, _ := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if !strings.Contains(content, "## 📊 Contribution Overview\n") {
+		t.Errorf("Expected emoji headers by default:\n%s", content)
+	}
+
+	generator.SetUseEmoji(false)
+	for _, templateType := range []TemplateType{ResumeTemplate, TechnicalTemplate, ExecutiveTemplate} {
+		content, err := generator.GenerateMarkdown(prof, templateType)
+		if err != nil {
+			t.Fatalf("GenerateMarkdown failed: %v", err)
+		}
+		for _, line := range strings.Split(content, "\n") {
+			if strings.HasPrefix(line, "## ") && strings.ContainsAny(line, "⭐📊🤝🏢🐳💬📚💼🛠📈🔧🚀") {
+				t.Errorf("%s: expected no emoji in header %q", templateType, line)
+			}
+		}
+		if templateType == ResumeTemplate && !strings.Contains(content, "## Contribution Overview\n") {
+			t.Errorf("Expected a plain Contribution Overview header:\n%s", content)
+		}
+	}
+}
+
+// TestMinSkillConfidence verifies weakly evidenced skills are left out of the resume
+func TestMinSkillConfidence(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Skills.Frameworks = []profile.TechnologySkill{
+		{Name: "Gin", Confidence: 0.8},
+		{Name: "Flask", Confidence: 0.1},
+	}
+	prof.Skills.Databases = []profile.TechnologySkill{{Name: "Redis", Confidence: 0.2}}
+
+	generator := NewGenerator()
+	if content, _ := generator.GenerateMarkdown(prof, ResumeTemplate); !strings.Contains(content, "- **Frameworks:** Gin, Flask\n") {
+		t.Errorf("Expected every framework without a threshold:\n%s", content)
+	}
+
+	generator.SetMinSkillConfidence(0.3)
+	content, err := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if !strings.Contains(content, "- **Frameworks:** Gin\n") {
+		t.Errorf("Expected the 0.1-confidence framework to be excluded at 0.3:\n%s", content)
+	}
+	if strings.Contains(content, "Databases") {
+		t.Errorf("Expected the databases line to be omitted when no database qualifies:\n%s", content)
+	}
+}
+
+// TestMermaidSkillsMap verifies -mermaid draws a node per technical area, sharing common technologies
+func TestMermaidSkillsMap(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Skills.TechnicalAreas = []profile.TechnicalArea{
+		{Area: "backend development", Competency: 0.8, Technologies: []string{"Go", "PostgreSQL"}},
+		{Area: "devops", Competency: 0.6, Technologies: []string{"Docker", "Go"}},
+	}
+
+	generator := NewGenerator()
+	if content, _ := generator.GenerateMarkdown(prof, TechnicalTemplate); strings.Contains(content, "```mermaid") {
+		t.Errorf("Expected no diagram without -mermaid:\n%s", content)
+	}
+
+	generator.SetMermaid(true)
+	content, err := generator.GenerateMarkdown(prof, TechnicalTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	for _, expected := range []string{
+		"```mermaid\ngraph LR\n",
+		`user --> area0["Backend Development"]`,
+		`user --> area1["Devops"]`,
+		`area0 --> tech0("Go")`,
+		"area1 --> tech0\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in the diagram:\n%s", expected, content)
+		}
+	}
+}
+
+// TestSecurityPractices verifies the technical template lists security practices by repository count
+func TestSecurityPractices(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Insights.ArchitecturalThinking.SecurityMindedness = true
+	prof.Insights.ArchitecturalThinking.SecurityPractices = map[string]int{
+		"Dependabot updates": 1,
+		"Security policy":    3,
+	}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, TechnicalTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	expected := "### Security Practices\n\n- **Security policy:** 3 repositories\n- **Dependabot updates:** 1 repository\n"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected security practices section %q:\n%s", expected, content)
+	}
+}
+
+// TestCodeReviewMentorship verifies the executive template credits reviews of others' pull requests
+func TestCodeReviewMentorship(t *testing.T) {
+	prof := createSampleProfile()
+	prof.ReviewActivity = &profile.ReviewActivity{ReviewsOfOthers: 12, FirstTimeContributors: 3, Organizations: []string{"jenkinsci", "kubernetes"}}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ExecutiveTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	expected := "- **Code Review Mentorship:** 12 reviews of other contributors' pull requests, 3 for first-time contributors, across 2 organizations\n"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected %q:\n%s", expected, content)
+	}
+}
+
+// TestFocusAreas verifies the resume lists focus areas from repository topics
+func TestFocusAreas(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Insights.FocusAreas = []profile.FocusArea{
+		{Topic: "jenkins", RepositoryCount: 3},
+		{Topic: "kubernetes", RepositoryCount: 2},
+	}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	expected := "### Focus Areas\n- **jenkins:** 3 repositories\n- **kubernetes:** 2 repositories\n"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected focus areas %q:\n%s", expected, content)
+	}
+}
+
+// TestLicensing verifies the technical template breaks licenses down and the executive one summarizes them
+func TestLicensing(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Insights.Licensing = &profile.LicenseSummary{Permissive: 2, Copyleft: 1, Unlicensed: 1, MostUsed: "MIT License"}
+
+	generator := NewGenerator()
+	technical, err := generator.GenerateMarkdown(prof, TechnicalTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	expected := "### Licensing\n\n- **Permissive:** 2 repositories\n- **Copyleft:** 1 repository\n" +
+		"- **No License:** 1 repository, which others cannot legally reuse\n- **Most Used:** MIT License\n"
+	if !strings.Contains(technical, expected) {
+		t.Errorf("Expected licensing %q:\n%s", expected, technical)
+	}
+
+	executive, err := generator.GenerateMarkdown(prof, ExecutiveTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	expected = "- **Open Source Licensing:** 2 permissive, 1 copyleft, 1 unlicensed (mostly MIT License)\n"
+	if !strings.Contains(executive, expected) {
+		t.Errorf("Expected licensing %q:\n%s", expected, executive)
+	}
+}
+
+// TestEvolvingSkillset verifies the technical template notes the newest adopted language first
+func TestEvolvingSkillset(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Skills.LanguageTrends = []profile.LanguageTrend{
+		{Language: "Rust", FirstYear: 2023, LastYear: 2024},
+		{Language: "Go", FirstYear: 2018, LastYear: 2024},
+	}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, TechnicalTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	expected := "### Evolving Skillset\n\nAdopted Rust in 2023, Go since 2018.\n"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected evolving skillset note %q:\n%s", expected, content)
+	}
+}
+
+// TestPrivateAggregateOnly verifies a private repository counts toward languages and totals but is
+// never named in the resume
+func TestPrivateAggregateOnly(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Repositories = append(prof.Repositories, profile.RepositoryProfile{
+		Name: "secret-project", FullName: "testuser/secret-project", URL: "https://github.com/testuser/secret-project",
+		Language: "Python", Size: 5000, IsOwner: true, IsPrivate: true,
+	})
+	prof.PinnedRepositories = []string{"testuser/secret-project", "testuser/tool"}
+	prof.Skills.PrimaryLanguages = []string{"Go", "Python"}
+
+	generator := NewGenerator()
+	if content, _ := generator.GenerateMarkdown(prof, ResumeTemplate); !strings.Contains(content, "secret-project") {
+		t.Fatalf("Expected the private repository to be listed by default:\n%s", content)
+	}
+
+	generator.SetPrivateAggregateOnly(true)
+	content, err := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	if strings.Contains(content, "secret-project") {
+		t.Errorf("Expected the private repository never to be named:\n%s", content)
+	}
+	for _, expected := range []string{"**2** repositories", "Proficient in **2** programming languages", "Python", "[tool]("} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in the resume:\n%s", expected, content)
+		}
+	}
+}
+
+// TestMinForks verifies repositories below the fork threshold are excluded from the notable set
+func TestMinForks(t *testing.T) {
+	prof := &profile.UserProfile{
+		Username: "testuser",
+		Repositories: []profile.RepositoryProfile{
+			{Name: "library", Stars: 10, Forks: 12, IsOwner: true},
+			{Name: "demo", Stars: 200, Forks: 1, IsOwner: true},
+		},
+	}
+
+	generator := NewGenerator()
+	if notable := generator.getNotableRepositories(prof); len(notable) != 2 {
+		t.Fatalf("Expected both repositories to be notable by default, got %d", len(notable))
+	}
+
+	generator.SetMinForks(5)
+	notable := generator.getNotableRepositories(prof)
+	if len(notable) != 1 || notable[0].Name != "library" {
+		t.Errorf("Expected only library to be notable, got %+v", notable)
+	}
+}
+
+// TestStarDecay verifies a recently active repository outranks an equally starred stale one in
+// notable ordering once star decay is enabled
+func TestStarDecay(t *testing.T) {
+	prof := &profile.UserProfile{
+		Username: "testuser",
+		Repositories: []profile.RepositoryProfile{
+			{Name: "legacy", Stars: 500, Size: 9000, IsOwner: true, PushedAt: time.Now().AddDate(-9, 0, 0)},
+			{Name: "current", Stars: 500, Size: 100, IsOwner: true, PushedAt: time.Now().AddDate(0, -1, 0)},
+		},
+	}
+
+	generator := NewGenerator()
+	if notable := generator.getNotableRepositories(prof); notable[0].Name != "legacy" {
+		t.Fatalf("Expected the larger repository first without decay, got %s", notable[0].Name)
+	}
+
+	generator.SetStarDecay(2)
+	if notable := generator.getNotableRepositories(prof); notable[0].Name != "current" {
+		t.Errorf("Expected the recently active repository first, got %s", notable[0].Name)
+	}
+}
+
+// TestExperienceTimeline verifies the resume maps dated organizations to their periods, most recent first
+func TestExperienceTimeline(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Organizations = []profile.OrganizationProfile{
+		{Name: "Kubernetes", Login: "kubernetes", FirstContribution: time.Date(2016, time.July, 1, 0, 0, 0, 0, time.UTC),
+			LastContribution: time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "Jenkins", Login: "jenkinsci", FirstContribution: time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC),
+			LastContribution: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "Apache", Login: "apache"},
+	}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	expected := "Experience Timeline\n\n- **Jenkins:** Mar 2018 - May 2024\n- **Kubernetes:** Jul 2016 - Feb 2020\n\n"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected timeline %q:\n%s", expected, content)
+	}
+}
+
+// TestEnterpriseProfileURLs verifies the resume footer and Docker Hub links use the configured hosts
+func TestEnterpriseProfileURLs(t *testing.T) {
+	prof := createSampleProfile()
+	prof.DockerHubProfile = &profile.DockerHubProfile{Username: "testuser", TotalDownloads: 500000}
+
+	generator := NewGenerator()
+	generator.SetGitHubURL("https://github.example.com/")
+	generator.SetDockerHubURL("https://registry.example.com")
+	content, err := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	for _, expected := range []string{"GitHub: [@testuser](https://github.example.com/testuser)", "[@testuser](https://registry.example.com/u/testuser)"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in the resume:\n%s", expected, content)
+		}
+	}
+	if strings.Contains(content, "https://github.com/testuser)") {
+		t.Errorf("Expected no public GitHub profile link:\n%s", content)
+	}
+}
+
+// TestSortLanguagesByProficiency verifies a high-proficiency language with a lower share of the codebase
+// is listed first once languages are sorted by proficiency
+func TestSortLanguagesByProficiency(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Skills.PrimaryLanguages = []string{"JavaScript", "Rust"}
+	prof.Languages = []profile.LanguageStats{
+		{Language: "JavaScript", Percentage: 70, ProficiencyScore: 0.4, RepositoryCount: 6},
+		{Language: "Rust", Percentage: 30, ProficiencyScore: 0.9, RepositoryCount: 2},
+	}
+
+	generator := NewGenerator()
+	content, _ := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if strings.Index(content, "- **JavaScript:**") > strings.Index(content, "- **Rust:**") {
+		t.Fatalf("Expected JavaScript first by percentage:\n%s", content)
+	}
+
+	generator.SetLanguageOrder(SortByProficiency)
+	content, err := generator.GenerateMarkdown(prof, ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+	if strings.Index(content, "- **Rust:**") > strings.Index(content, "- **JavaScript:**") {
+		t.Errorf("Expected Rust first by proficiency:\n%s", content)
+	}
+	if prof.Languages[0].Language != "JavaScript" {
+		t.Errorf("Expected the profile's languages to stay sorted by percentage, got %s first", prof.Languages[0].Language)
+	}
+}
+
+// TestCollaborationHighlights verifies the technical template lists collaborations with their role and impact
+func TestCollaborationHighlights(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Collaborations = []profile.CollaborationProfile{
+		{Repository: "jenkinsci/git-plugin", CollaborationType: "maintainer", ImpactLevel: "high"},
+	}
+
+	content, err := NewGenerator().GenerateMarkdown(prof, TechnicalTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	expected := "### Collaboration Highlights\n\n- **[jenkinsci/git-plugin](https://github.com/jenkinsci/git-plugin):** Maintainer, high impact\n"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected collaborations %q:\n%s", expected, content)
+	}
+}
+
+// TestGenerateProfileBadge verifies the badges include a shields.io URL encoding the primary language
+func TestGenerateProfileBadge(t *testing.T) {
+	prof := createSampleProfile()
+	prof.Skills.PrimaryLanguages = []string{"C++", "Go"}
+	prof.Contributions.ContributionYears = 5
+	prof.Insights.OverallImpactScore = 0.75
+
+	badges, err := GenerateProfileBadge(prof)
+	if err != nil {
+		t.Fatalf("GenerateProfileBadge failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"(https://img.shields.io/badge/top%20language-C++-informational?logo=c%2B%2B)",
+		"(https://img.shields.io/badge/years%20active-5-blue)",
+		"(https://img.shields.io/badge/stars-42-yellow?logo=github)",
+		"(https://img.shields.io/badge/impact-7.5%2F10-brightgreen)",
+		"](https://github.com/testuser)",
+	} {
+		if !strings.Contains(badges, expected) {
+			t.Errorf("Expected %q in the badges:\n%s", expected, badges)
+		}
+	}
+
+	if _, err := GenerateProfileBadge(&profile.UserProfile{}); err == nil {
+		t.Error("Expected an error for a profile without username")
+	}
+}
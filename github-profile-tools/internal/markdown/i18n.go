@@ -0,0 +1,78 @@
+package markdown
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultLanguage is the locale whose catalog reproduces the original English templates
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// SupportedLanguages returns the locales with an embedded message catalog
+func SupportedLanguages() []string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		return []string{DefaultLanguage}
+	}
+
+	var languages []string
+	for _, entry := range entries {
+		languages = append(languages, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// loadCatalog reads the embedded message catalog for lang
+func loadCatalog(lang string) (map[string]string, error) {
+	data, err := localeFiles.ReadFile(path.Join("locales", lang+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported language: %s (valid options: %s)", lang, strings.Join(SupportedLanguages(), ", "))
+	}
+
+	var catalog map[string]string
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("invalid message catalog for %s: %w", lang, err)
+	}
+	return catalog, nil
+}
+
+// SetLanguage selects the locale used for section headers. Missing translations fall back to English.
+func (g *Generator) SetLanguage(lang string) error {
+	catalog, err := loadCatalog(lang)
+	if err != nil {
+		return err
+	}
+	g.messages = catalog
+	return nil
+}
+
+// t returns the message for key in the selected language, falling back to English and then to the key
+func (g *Generator) t(key string) string {
+	if message, ok := g.messages[key]; ok {
+		return message
+	}
+	if message, ok := defaultMessages[key]; ok {
+		return message
+	}
+	return key
+}
+
+// defaultMessages is the English catalog, used when a translation is missing
+var defaultMessages = mustLoadCatalog(DefaultLanguage)
+
+// mustLoadCatalog loads a catalog that is embedded at build time and therefore must exist
+func mustLoadCatalog(lang string) map[string]string {
+	catalog, err := loadCatalog(lang)
+	if err != nil {
+		panic(err)
+	}
+	return catalog
+}
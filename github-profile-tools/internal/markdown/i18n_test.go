@@ -0,0 +1,55 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestResumeInFrench verifies section headers are translated while data values are kept
+func TestResumeInFrench(t *testing.T) {
+	generator := NewGenerator()
+	if err := generator.SetLanguage("fr"); err != nil {
+		t.Fatalf("SetLanguage failed: %v", err)
+	}
+
+	content, err := generator.GenerateMarkdown(createSampleProfile(), ResumeTemplate)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	for _, expected := range []string{"# Profil professionnel GitHub - testuser", "## 💼 Projets notables", "tool"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected French resume to contain %q", expected)
+		}
+	}
+	if strings.Contains(content, "Notable Projects") {
+		t.Error("Expected English section header to be translated")
+	}
+}
+
+// TestSetLanguageUnsupported verifies unknown locales are rejected
+func TestSetLanguageUnsupported(t *testing.T) {
+	if err := NewGenerator().SetLanguage("xx"); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+}
+
+// TestCatalogsComplete verifies every locale translates every English message
+func TestCatalogsComplete(t *testing.T) {
+	for _, lang := range SupportedLanguages() {
+		catalog, err := loadCatalog(lang)
+		if err != nil {
+			t.Fatalf("loadCatalog(%s) failed: %v", lang, err)
+		}
+		for key, message := range defaultMessages {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("Locale %s is missing %q", lang, key)
+				continue
+			}
+			if strings.Count(translated, "%s") != strings.Count(message, "%s") {
+				t.Errorf("Locale %s has mismatched placeholders for %q", lang, key)
+			}
+		}
+	}
+}
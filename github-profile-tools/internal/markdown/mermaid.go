@@ -0,0 +1,51 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/profile"
+)
+
+// maxMermaidTechnologies limits the technologies drawn per technical area so the map stays legible
+const maxMermaidTechnologies = 8
+
+// SetMermaid sets whether the technical template includes a Mermaid map of technical areas,
+// which GitHub renders as a diagram
+func (g *Generator) SetMermaid(enabled bool) {
+	g.mermaid = enabled
+}
+
+// writeMermaidSkillsMap draws the user, their technical areas and each area's technologies as a
+// Mermaid graph. Technologies supporting several areas are drawn once.
+func (g *Generator) writeMermaidSkillsMap(md *strings.Builder, username string, areas []profile.TechnicalArea) {
+	md.WriteString("```mermaid\n")
+	md.WriteString("graph LR\n")
+	md.WriteString(fmt.Sprintf("    user((%s))\n", mermaidLabel(username)))
+
+	technologyNodes := make(map[string]string)
+	for i, area := range areas {
+		areaNode := fmt.Sprintf("area%d", i)
+		md.WriteString(fmt.Sprintf("    user --> %s[%s]\n", areaNode, mermaidLabel(strings.Title(area.Area))))
+
+		for j, technology := range area.Technologies {
+			if j >= maxMermaidTechnologies {
+				break
+			}
+			key := strings.ToLower(technology)
+			if node, ok := technologyNodes[key]; ok {
+				md.WriteString(fmt.Sprintf("    %s --> %s\n", areaNode, node))
+				continue
+			}
+			node := fmt.Sprintf("tech%d", len(technologyNodes))
+			technologyNodes[key] = node
+			md.WriteString(fmt.Sprintf("    %s --> %s(%s)\n", areaNode, node, mermaidLabel(technology)))
+		}
+	}
+	md.WriteString("```\n\n")
+}
+
+// mermaidLabel quotes text for use as a Mermaid node label
+func mermaidLabel(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,161 @@
+// Package metrics keeps process-wide counters of analyses, cache lookups and GitHub requests, and
+// serves them in the Prometheus text exposition format so long runs can be monitored.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+// Counter is a value that only goes up
+type Counter struct {
+	value atomic.Int64
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Value returns the current count
+func (c *Counter) Value() int64 {
+	return c.value.Load()
+}
+
+// Gauge is a value that can go up and down
+type Gauge struct {
+	value atomic.Int64
+}
+
+// Set replaces the gauge value
+func (g *Gauge) Set(value int64) {
+	g.value.Store(value)
+}
+
+// Value returns the current gauge value
+func (g *Gauge) Value() int64 {
+	return g.value.Load()
+}
+
+// Process-wide metrics, updated by the analyzer, the cache and the GitHub client
+var (
+	UsersAnalyzed       Counter // analyses completed, from cache or GitHub
+	AnalysisErrors      Counter // analyses that failed or were interrupted
+	AnalysisCacheHits   Counter // completed analyses served from the analysis cache
+	AnalysisCacheMisses Counter // analyses not found in the analysis cache
+	EntryCacheHits      Counter // cache manager lookups that found an entry
+	EntryCacheMisses    Counter // cache manager lookups that found no entry
+	GitHubRequests      Counter // HTTP requests sent to GitHub, retries included
+	GitHubRequestErrors Counter // GitHub requests that failed or returned an error status
+	RateLimitRemaining  Gauge   // remaining requests reported by the last GitHub response
+)
+
+// sample is one value of a metric, with its Prometheus label set (e.g. `{layer="entry"}`) if any
+type sample struct {
+	labels string
+	value  interface{}
+}
+
+// Label sets of the cache metrics: the analysis cache holds completed analyses, while the entry
+// cache holds the API responses the cache manager stores
+const (
+	analysisLayer = `{layer="analysis"}`
+	entryLayer    = `{layer="entry"}`
+)
+
+// hitRatio returns the share of lookups that were hits, or zero before any lookup
+func hitRatio(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// WriteText writes all metrics to w in the Prometheus text exposition format
+func WriteText(w io.Writer) error {
+	analysisHits, analysisMisses := AnalysisCacheHits.Value(), AnalysisCacheMisses.Value()
+	entryHits, entryMisses := EntryCacheHits.Value(), EntryCacheMisses.Value()
+
+	metrics := []struct {
+		name, kind, help string
+		samples          []sample
+	}{
+		{"github_profile_users_analyzed_total", "counter", "Analyses completed, from cache or GitHub.", []sample{{"", UsersAnalyzed.Value()}}},
+		{"github_profile_analysis_errors_total", "counter", "Analyses that failed or were interrupted.", []sample{{"", AnalysisErrors.Value()}}},
+		{"github_profile_cache_hits_total", "counter", "Cache lookups that found an entry, by cache layer.",
+			[]sample{{analysisLayer, analysisHits}, {entryLayer, entryHits}}},
+		{"github_profile_cache_misses_total", "counter", "Cache lookups that found no entry, by cache layer.",
+			[]sample{{analysisLayer, analysisMisses}, {entryLayer, entryMisses}}},
+		{"github_profile_cache_hit_ratio", "gauge", "Share of cache lookups that found an entry, by cache layer.",
+			[]sample{{analysisLayer, hitRatio(analysisHits, analysisMisses)}, {entryLayer, hitRatio(entryHits, entryMisses)}}},
+		{"github_profile_github_requests_total", "counter", "HTTP requests sent to GitHub, retries included.", []sample{{"", GitHubRequests.Value()}}},
+		{"github_profile_github_request_errors_total", "counter", "GitHub requests that failed or returned an error status.", []sample{{"", GitHubRequestErrors.Value()}}},
+		{"github_profile_rate_limit_remaining", "gauge", "Remaining GitHub requests reported by the last response.", []sample{{"", RateLimitRemaining.Value()}}},
+	}
+
+	for _, metric := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind); err != nil {
+			return err
+		}
+		for _, s := range metric.samples {
+			if _, err := fmt.Fprintf(w, "%s%s %v\n", metric.name, s.labels, s.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Handler serves the metrics in the Prometheus text exposition format
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WriteText(w)
+	})
+}
+
+// Server exposes the metrics on /metrics until it is shut down
+type Server struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+// Start listens on addr, e.g. ":9090" or "127.0.0.1:0", and serves /metrics in the background
+func Start(addr string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	s := &Server{
+		server:   &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second},
+		listener: listener,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Warnf("Metrics server stopped: %v", err)
+		}
+	}()
+	return s, nil
+}
+
+// Addr returns the address the server listens on, with the actual port when addr used port 0
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Shutdown stops the server, waiting for in-flight scrapes until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestServerExposesMetrics verifies /metrics serves every metric in the Prometheus text format,
+// including the cache hit ratio of each layer derived from its hit and miss counters
+func TestServerExposesMetrics(t *testing.T) {
+	server, err := Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer server.Shutdown(t.Context())
+
+	// Counters start at zero in the test process
+	EntryCacheHits.Inc()
+	EntryCacheMisses.Inc()
+	AnalysisCacheMisses.Inc()
+	RateLimitRemaining.Set(4321)
+
+	resp, err := http.Get("http://" + server.Addr() + "/metrics")
+	if err != nil {
+		t.Fatalf("Scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("Expected a text/plain response, got %q", resp.Header.Get("Content-Type"))
+	}
+	for _, expected := range []string{
+		"github_profile_cache_hit_ratio{layer=\"entry\"} 0.5\n",
+		"github_profile_cache_hit_ratio{layer=\"analysis\"} 0\n",
+		"github_profile_cache_misses_total{layer=\"analysis\"} 1\n",
+		"# TYPE github_profile_users_analyzed_total counter\n",
+		"# TYPE github_profile_rate_limit_remaining gauge\n",
+		"github_profile_rate_limit_remaining 4321\n",
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Expected %q in the scrape:\n%s", expected, body)
+		}
+	}
+}
+
+// TestServerShutdown verifies the server stops accepting scrapes once shut down
+func TestServerShutdown(t *testing.T) {
+	server, err := Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := server.Shutdown(t.Context()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if _, err := http.Get("http://" + server.Addr() + "/metrics"); err == nil {
+		t.Error("Expected scrapes to fail after shutdown")
+	}
+}
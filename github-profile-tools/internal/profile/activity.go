@@ -0,0 +1,23 @@
+package profile
+
+import "time"
+
+// ActiveView returns a copy of prof limited to repositories pushed to since cutoff, with languages
+// and skills recomputed over them, so stale projects do not dominate the templates. prof itself
+// keeps every repository. Repositories without any activity date are kept.
+func (a *Analyzer) ActiveView(prof *UserProfile, cutoff time.Time) *UserProfile {
+	view := *prof
+	view.Repositories = make([]RepositoryProfile, 0, len(prof.Repositories))
+	for _, repo := range prof.Repositories {
+		lastActivity := repo.PushedAt
+		if lastActivity.IsZero() {
+			lastActivity = repo.UpdatedAt
+		}
+		if lastActivity.IsZero() || !lastActivity.Before(cutoff) {
+			view.Repositories = append(view.Repositories, repo)
+		}
+	}
+
+	a.analyzeLanguages(&view)
+	return &view
+}
@@ -0,0 +1,34 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+// TestActiveView verifies a repository last pushed 3 years ago is left out of the view and its
+// languages with a 2-year cutoff, while the original profile keeps it
+func TestActiveView(t *testing.T) {
+	now := time.Now()
+	prof := &UserProfile{
+		Username: "testuser",
+		Repositories: []RepositoryProfile{
+			{FullName: "testuser/current", PushedAt: now.AddDate(0, -2, 0), CreatedAt: now.AddDate(-1, 0, 0), Languages: map[string]int{"Go": 5000}},
+			{FullName: "testuser/abandoned", PushedAt: now.AddDate(-3, 0, 0), CreatedAt: now.AddDate(-6, 0, 0), Languages: map[string]int{"Perl": 90000}},
+		},
+	}
+	analyzer := NewAnalyzer("test-token")
+	analyzer.analyzeLanguages(prof)
+
+	view := analyzer.ActiveView(prof, now.AddDate(-2, 0, 0))
+
+	if len(view.Repositories) != 1 || view.Repositories[0].FullName != "testuser/current" {
+		t.Errorf("Expected only testuser/current to be active, got %+v", view.Repositories)
+	}
+	if len(view.Languages) != 1 || view.Languages[0].Language != "Go" || view.Languages[0].Percentage != 100 {
+		t.Errorf("Expected Go as the only language of the active view, got %+v", view.Languages)
+	}
+	if len(prof.Repositories) != 2 || len(prof.Languages) != 2 {
+		t.Errorf("Expected the original profile to keep every repository and language, got %d and %d",
+			len(prof.Repositories), len(prof.Languages))
+	}
+}
@@ -3,8 +3,8 @@ package profile
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -14,28 +14,144 @@ import (
 	"github.com/jenkins/github-profile-tools/internal/github"
 	"github.com/jenkins/github-profile-tools/internal/docker"
 	"github.com/jenkins/github-profile-tools/internal/discourse"
+	"github.com/jenkins/github-profile-tools/internal/logging"
+	"github.com/jenkins/github-profile-tools/internal/metrics"
+	"github.com/jenkins/github-profile-tools/internal/stackoverflow"
+)
+
+const (
+	// DefaultProgressMaxAge is how long saved progress of an interrupted analysis can be resumed
+	DefaultProgressMaxAge = 24 * time.Hour
+
+	// DefaultAnalysisMaxAge is how long a completed analysis is reused before re-analyzing
+	DefaultAnalysisMaxAge = 7 * 24 * time.Hour
+
+	// DefaultRepoPageSize is the number of repositories fetched per GraphQL page
+	DefaultRepoPageSize = 50
+
+	// MaxRepoPageSize is the largest page size GitHub accepts for the `first` argument
+	MaxRepoPageSize = 100
+
+	skillsAnalysisInterval   = 150 // repositories fetched between incremental skills analyses
+	lineStatsTopRepositories = 10 // repositories queried for additions/deletions
+	lineStatsMaxPages        = 10 // commit history pages (100 commits each) per repository
 )
 
 // Analyzer handles the analysis of GitHub user profiles
 type Analyzer struct {
-	client          *github.Client
-	dockerClient    *docker.Client
-	discourseClient *discourse.Client
-	saveProgressDir string
-	cacheDir        string
+	client              *github.Client
+	dockerClient        *docker.Client
+	discourseClient     *discourse.Client
+	stackOverflowClient *stackoverflow.Client
+	stackOverflowUser   string         // optional; Stack Overflow analysis is skipped when empty
+	contribSince        time.Time      // zero means one year before contribUntil
+	contribUntil        time.Time      // zero means now
+	withLineStats       bool           // fetch per-commit additions/deletions (API-expensive)
+	withContributors    bool           // fetch the contributors of top owned repositories (API-expensive)
+	withSecurityScan    bool           // detect security practices from repository files (API-expensive)
+	withReviews         bool           // fetch recent pull request reviews for mentorship signals
+	bytesPerLine        map[string]int // nil means DefaultBytesPerLine
+	recencyHalfLife     float64        // years; zero disables recency weighting
+	starDecayHalfLife   float64        // years since last push; zero counts stars at face value
+	botLogins           map[string]bool // lowercase logins left out of contributor counts; nil means DefaultBotLogins
+	saveProgressDir     string
+	cacheDir            string
+	progressMaxAge      time.Duration
+	analysisMaxAge      time.Duration
+	repoPageSize        int // zero means DefaultRepoPageSize
+	maxRepos            int // zero means unlimited
+	languageFallback    bool // fetch languages over REST when GraphQL returns none
+	monorepoAware       bool // down-weight monorepos in language percentages
+	incremental         bool
+	anonymize           bool // strip personal information before caching and returning profiles
+	repoBaseline        map[string]RepositoryProfile // previous per-repository results, set during incremental runs
+	roleRules           *RoleRules // nil means DefaultRoleRules
+	impactConfig        *ImpactConfig // nil means DefaultImpactConfig
+	profileCache        *ProfileCacheManager // caches Docker Hub analyses; nil disables, set by WrapWithCache
 }
 
 // NewAnalyzer creates a new profile analyzer
 func NewAnalyzer(githubToken string) *Analyzer {
 	return &Analyzer{
-		client:          github.NewClient(githubToken),
-		dockerClient:    docker.NewClient(),
-		discourseClient: discourse.NewClient(),
-		saveProgressDir: "./data/progress",
-		cacheDir:        "./data/cache",
+		client:              github.NewClient(githubToken),
+		dockerClient:        docker.NewClient(),
+		discourseClient:     discourse.NewClient(),
+		stackOverflowClient: stackoverflow.NewClient(),
+		saveProgressDir:     "./data/progress",
+		cacheDir:            "./data/cache",
+		progressMaxAge:      DefaultProgressMaxAge,
+		analysisMaxAge:      DefaultAnalysisMaxAge,
+		repoPageSize:        DefaultRepoPageSize,
+		recencyHalfLife:     DefaultRecencyHalfLife,
 	}
 }
 
+// SetGitHubClient replaces the GitHub client, e.g. with one allowed a higher request rate
+func (a *Analyzer) SetGitHubClient(client *github.Client) {
+	a.client = client
+}
+
+// SetDiscourseClient replaces the Discourse client, e.g. with one that authenticates via API key
+func (a *Analyzer) SetDiscourseClient(client *discourse.Client) {
+	a.discourseClient = client
+}
+
+// SetContributionWindow restricts contribution analysis to [since, until); zero values fall back to the last year
+func (a *Analyzer) SetContributionWindow(since, until time.Time) {
+	a.contribSince = since
+	a.contribUntil = until
+}
+
+// SetLineStatsEnabled enables fetching commit additions/deletions for the user's top repositories
+func (a *Analyzer) SetLineStatsEnabled(enabled bool) {
+	a.withLineStats = enabled
+}
+
+// SetProgressMaxAge sets how old saved progress may be and still be resumed
+func (a *Analyzer) SetProgressMaxAge(maxAge time.Duration) {
+	a.progressMaxAge = maxAge
+}
+
+// SetAnalysisMaxAge sets how old a completed analysis may be and still be reused
+func (a *Analyzer) SetAnalysisMaxAge(maxAge time.Duration) {
+	a.analysisMaxAge = maxAge
+}
+
+// SetRepoPageSize sets how many repositories are fetched per page (1 to MaxRepoPageSize).
+// Larger pages need fewer requests; smaller pages save progress more often.
+func (a *Analyzer) SetRepoPageSize(size int) {
+	a.repoPageSize = size
+}
+
+// repositoryPageSize returns the configured page size, falling back to the default
+func (a *Analyzer) repositoryPageSize() int {
+	if a.repoPageSize <= 0 || a.repoPageSize > MaxRepoPageSize {
+		return DefaultRepoPageSize
+	}
+	return a.repoPageSize
+}
+
+// SetMaxRepos caps how many repositories are fetched, in the query's order; zero means unlimited.
+// Language and skill analysis then only cover the capped set.
+func (a *Analyzer) SetMaxRepos(max int) {
+	a.maxRepos = max
+}
+
+// repositoryCapReached reports whether fetched repositories reach the -max-repos cap
+func (a *Analyzer) repositoryCapReached(fetched int) bool {
+	return a.maxRepos > 0 && fetched >= a.maxRepos
+}
+
+// SetIncremental enables reusing the previous analysis for repositories not pushed to since
+func (a *Analyzer) SetIncremental(enabled bool) {
+	a.incremental = enabled
+}
+
+// SetStackOverflowUser sets the Stack Overflow user ID, profile URL or display name to analyze
+func (a *Analyzer) SetStackOverflowUser(user string) {
+	a.stackOverflowUser = user
+}
+
 // AnalyzeUser performs comprehensive analysis of a GitHub user
 func (a *Analyzer) AnalyzeUser(ctx context.Context, username string) (*UserProfile, error) {
 	return a.AnalyzeUserWithDockerUsername(ctx, username, username)
@@ -46,14 +162,33 @@ func (a *Analyzer) AnalyzeUserWithDockerUsername(ctx context.Context, username,
 	return a.AnalyzeUserWithCustomUsernames(ctx, username, dockerUsername, "")
 }
 
+// ErrPartialAnalysis is returned along with a partial profile when the context is cancelled mid-analysis
+var ErrPartialAnalysis = errors.New("analysis interrupted before completion")
+
+// AnalyzeUserWithCustomUsernames performs comprehensive analysis with separate Docker and Discourse
+// usernames, counting completed and failed analyses in the process metrics
 func (a *Analyzer) AnalyzeUserWithCustomUsernames(ctx context.Context, username, dockerUsername, discourseUsername string) (*UserProfile, error) {
-	log.Printf("Starting analysis for user: %s", username)
+	profile, err := a.analyzeUser(ctx, username, dockerUsername, discourseUsername)
+	if err != nil {
+		metrics.AnalysisErrors.Inc()
+	} else {
+		metrics.UsersAnalyzed.Inc()
+	}
+	return profile, err
+}
+
+// analyzeUser runs the analysis steps of AnalyzeUserWithCustomUsernames, serving a completed
+// analysis from cache and resuming saved progress when possible
+func (a *Analyzer) analyzeUser(ctx context.Context, username, dockerUsername, discourseUsername string) (*UserProfile, error) {
+	logging.Infof("Starting analysis for user: %s", username)
 
 	// First, try to load from cache (completed analysis)
 	if cachedProfile := a.tryLoadFromCache(username); cachedProfile != nil {
-		log.Printf("Using cached analysis for user: %s (analyzed at %s)", username, cachedProfile.LastAnalyzed.Format("2006-01-02 15:04:05"))
+		metrics.AnalysisCacheHits.Inc()
+		logging.Infof("Using cached analysis for user: %s (analyzed at %s)", username, cachedProfile.LastAnalyzed.Format("2006-01-02 15:04:05"))
 		return cachedProfile, nil
 	}
+	metrics.AnalysisCacheMisses.Inc()
 
 	// If no cache, try to resume from saved progress
 	profile, resumeStep := a.tryResumeProgress(username, dockerUsername, discourseUsername)
@@ -71,41 +206,61 @@ func (a *Analyzer) AnalyzeUserWithCustomUsernames(ctx context.Context, username,
 			return nil, fmt.Errorf("failed to fetch user basic info: %w", err)
 		}
 		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 1); err != nil {
-			log.Printf("Warning: Failed to save progress after step 1: %v", err)
+			logging.Warnf("Failed to save progress after step 1: %v", err)
 		}
 	}
 
 	// Step 2: Fetch user repositories (incremental, continues with partial data on error)
 	if resumeStep <= 2 {
 		if err := a.fetchUserRepositories(ctx, username, dockerUsername, discourseUsername, profile); err != nil {
-			log.Printf("Warning: Repository fetching encountered issues: %v", err)
-			log.Printf("Continuing with %d repositories already fetched", len(profile.Repositories))
+			logging.Warnf("Repository fetching encountered issues: %v", err)
+			logging.Infof("Continuing with %d repositories already fetched", len(profile.Repositories))
 			// Don't return error - continue with whatever repositories we have
 		}
+		if ctx.Err() != nil {
+			return a.finishPartialAnalysis(ctx, profile)
+		}
 		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 2); err != nil {
-			log.Printf("Warning: Failed to save progress after step 2: %v", err)
+			logging.Warnf("Failed to save progress after step 2: %v", err)
 		}
 	}
 
 	// Step 3: Fetch organizations (non-critical, continue on failure)
 	if resumeStep <= 3 {
 		if err := a.fetchUserOrganizations(ctx, username, profile); err != nil {
-			log.Printf("Warning: Failed to fetch organizations (continuing): %v", err)
+			logging.Warnf("Failed to fetch organizations (continuing): %v", err)
 			// Continue without organizations data
 		}
+		if ctx.Err() != nil {
+			return a.finishPartialAnalysis(ctx, profile)
+		}
 		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 3); err != nil {
-			log.Printf("Warning: Failed to save progress after step 3: %v", err)
+			logging.Warnf("Failed to save progress after step 3: %v", err)
 		}
 	}
 
 	// Step 4: Fetch contribution data (non-critical, continue on failure)
 	if resumeStep <= 4 {
 		if err := a.fetchUserContributions(ctx, username, profile); err != nil {
-			log.Printf("Warning: Failed to fetch contributions (continuing): %v", err)
+			logging.Warnf("Failed to fetch contributions (continuing): %v", err)
 			// Continue without detailed contribution data
 		}
+		if a.withLineStats {
+			if err := a.fetchLineStats(ctx, username, profile); err != nil {
+				logging.Warnf("Failed to fetch line statistics (continuing): %v", err)
+			}
+		}
+		if a.withContributors {
+			a.fetchContributors(ctx, username, profile)
+		}
+		if a.withReviews {
+			a.fetchReviews(ctx, username, profile)
+		}
+		if ctx.Err() != nil {
+			return a.finishPartialAnalysis(ctx, profile)
+		}
 		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 4); err != nil {
-			log.Printf("Warning: Failed to save progress after step 4: %v", err)
+			logging.Warnf("Failed to save progress after step 4: %v", err)
 		}
 	}
 
@@ -113,55 +268,104 @@ func (a *Analyzer) AnalyzeUserWithCustomUsernames(ctx context.Context, username,
 	if resumeStep <= 5 {
 		a.analyzeLanguages(profile)
 		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 5); err != nil {
-			log.Printf("Warning: Failed to save progress after step 5: %v", err)
+			logging.Warnf("Failed to save progress after step 5: %v", err)
 		}
 	}
 
 	// Step 6: Analyze Docker Hub profile (optional - may not exist for all users)
 	if resumeStep <= 6 {
 		if err := a.analyzeDockerHub(ctx, dockerUsername, profile); err != nil {
-			log.Printf("Docker Hub analysis failed (this is optional): %v", err)
+			logging.Warnf("Docker Hub analysis failed (this is optional): %v", err)
 			// Continue without Docker Hub data - not all users have Docker Hub profiles
 		}
+		if ctx.Err() != nil {
+			return a.finishPartialAnalysis(ctx, profile)
+		}
 		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 6); err != nil {
-			log.Printf("Warning: Failed to save progress after step 6: %v", err)
+			logging.Warnf("Failed to save progress after step 6: %v", err)
 		}
 	}
 
 	// Step 7: Analyze Discourse community engagement (optional - for Jenkins community members)
 	if resumeStep <= 7 {
 		if err := a.analyzeDiscourseProfile(ctx, username, discourseUsername, profile); err != nil {
-			log.Printf("Discourse analysis failed (this is optional): %v", err)
+			logging.Warnf("Discourse analysis failed (this is optional): %v", err)
 			// Continue without Discourse data - not all users are active in Jenkins community
 		}
+		if ctx.Err() != nil {
+			return a.finishPartialAnalysis(ctx, profile)
+		}
 		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 7); err != nil {
-			log.Printf("Warning: Failed to save progress after step 7: %v", err)
+			logging.Warnf("Failed to save progress after step 7: %v", err)
 		}
 	}
 
-	// Step 8: Generate insights
+	// Step 8: Analyze Stack Overflow reputation (optional - only when a Stack Overflow user is given)
 	if resumeStep <= 8 {
+		if a.stackOverflowUser != "" {
+			if err := a.analyzeStackOverflow(ctx, a.stackOverflowUser, profile); err != nil {
+				logging.Warnf("Stack Overflow analysis failed (this is optional): %v", err)
+				// Continue without Stack Overflow data
+			}
+		}
+		if ctx.Err() != nil {
+			return a.finishPartialAnalysis(ctx, profile)
+		}
+		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 8); err != nil {
+			logging.Warnf("Failed to save progress after step 8: %v", err)
+		}
+	}
+
+	// Step 9: Generate insights
+	if resumeStep <= 9 {
 		a.generateInsights(profile)
 	}
 
 	// Provide analysis summary
 	a.logAnalysisSummary(profile)
 
+	if a.anonymize {
+		Anonymize(profile)
+	}
+
 	// Save completed analysis to cache for future template generation
 	if err := a.saveToCache(username, profile); err != nil {
-		log.Printf("Warning: Failed to save analysis to cache: %v", err)
+		logging.Warnf("Failed to save analysis to cache: %v", err)
+	}
+	if err := a.saveSnapshot(username, profile); err != nil {
+		logging.Warnf("Failed to save snapshot for incremental updates: %v", err)
+	}
+	if err := a.saveHistorySnapshot(username, profile); err != nil {
+		logging.Warnf("Failed to save history snapshot: %v", err)
 	}
 
 	// Clean up progress file on successful completion
 	a.cleanupProgress(username, dockerUsername, discourseUsername)
 
-	log.Printf("Analysis completed for user: %s", username)
+	logging.Infof("Analysis completed for user: %s", username)
 	return profile, nil
 }
 
+// finishPartialAnalysis derives languages, skills and insights from the data collected before ctx was
+// cancelled. The interrupted step is not recorded, so saved progress resumes it, and the partial
+// profile is not cached as a completed analysis.
+func (a *Analyzer) finishPartialAnalysis(ctx context.Context, profile *UserProfile) (*UserProfile, error) {
+	logging.Warnf("Analysis interrupted (%v), building a partial profile from %d repositories", ctx.Err(), len(profile.Repositories))
+
+	profile.Partial = true
+	a.analyzeLanguages(profile)
+	a.generateInsights(profile)
+
+	if a.anonymize {
+		Anonymize(profile)
+	}
+
+	return profile, fmt.Errorf("%w: %v", ErrPartialAnalysis, ctx.Err())
+}
+
 // fetchUserBasicInfo fetches basic user information
 func (a *Analyzer) fetchUserBasicInfo(ctx context.Context, username string, profile *UserProfile) error {
-	log.Printf("Fetching basic info for user: %s", username)
+	logging.Infof("Fetching basic info for user: %s", username)
 
 	req := &github.GraphQLRequest{
 		Query: github.UserProfileQuery,
@@ -172,7 +376,19 @@ func (a *Analyzer) fetchUserBasicInfo(ctx context.Context, username string, prof
 
 	var resp github.UserProfileResponse
 	if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
-		return fmt.Errorf("GraphQL query failed: %w", err)
+		if github.IsNotFoundError(err) {
+			return fmt.Errorf("%w: %s", github.ErrUserNotFound, username)
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("GraphQL query failed: %w", err)
+		}
+
+		// A token lacking a scope should not abort the analysis: carry on with the REST basics
+		logging.Warnf("GraphQL query for basic info failed, falling back to REST: %v", err)
+		if restErr := a.fetchUserBasicInfoREST(ctx, username, profile); restErr != nil {
+			return fmt.Errorf("GraphQL query failed: %w (REST fallback: %v)", err, restErr)
+		}
+		return nil
 	}
 
 	user := resp.User
@@ -183,6 +399,7 @@ func (a *Analyzer) fetchUserBasicInfo(ctx context.Context, username string, prof
 	profile.Email = user.Email
 	profile.BlogURL = user.WebsiteUrl
 	profile.TwitterUsername = user.TwitterUsername
+	profile.AvatarURL = user.AvatarUrl
 	profile.CreatedAt = user.CreatedAt
 	profile.UpdatedAt = user.UpdatedAt
 	profile.PublicRepos = user.Repositories.TotalCount
@@ -190,6 +407,13 @@ func (a *Analyzer) fetchUserBasicInfo(ctx context.Context, username string, prof
 	profile.Followers = user.Followers.TotalCount
 	profile.Following = user.Following.TotalCount
 
+	// Pinned repositories are the ones the user chose to showcase on their GitHub profile
+	for _, pinned := range user.PinnedItems.Nodes {
+		if pinned.NameWithOwner != "" {
+			profile.PinnedRepositories = append(profile.PinnedRepositories, pinned.NameWithOwner)
+		}
+	}
+
 	// Initialize contributions summary
 	profile.Contributions = ContributionSummary{
 		TotalCommits:                user.ContributionsCollection.TotalCommitContributions,
@@ -215,22 +439,67 @@ func (a *Analyzer) fetchUserBasicInfo(ctx context.Context, username string, prof
 	return nil
 }
 
+// fetchUserBasicInfoREST fills the basic profile fields from the REST API, without the pinned
+// repositories and contribution totals only GraphQL provides
+func (a *Analyzer) fetchUserBasicInfoREST(ctx context.Context, username string, profile *UserProfile) error {
+	user, err := a.client.FetchUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	profile.Name = user.Name
+	profile.Bio = user.Bio
+	profile.Company = user.Company
+	profile.Location = user.Location
+	profile.Email = user.Email
+	profile.BlogURL = user.Blog
+	profile.TwitterUsername = user.TwitterUsername
+	profile.AvatarURL = user.AvatarURL
+	profile.CreatedAt = user.CreatedAt
+	profile.UpdatedAt = user.UpdatedAt
+	profile.PublicRepos = user.PublicRepos
+	profile.PublicGists = user.PublicGists
+	profile.Followers = user.Followers
+	profile.Following = user.Following
+
+	profile.Contributions = ContributionSummary{
+		YearlyContributions:  make(map[string]int),
+		MonthlyContributions: make(map[string]int),
+	}
+
+	return nil
+}
+
 // fetchUserRepositories fetches user's repositories with pagination
 func (a *Analyzer) fetchUserRepositories(ctx context.Context, username, dockerUsername, discourseUsername string, profile *UserProfile) error {
-	log.Printf("Starting incremental repository fetching for user: %s", username)
+	logging.Infof("Starting incremental repository fetching for user: %s", username)
 
 	// Initialize repositories if not already present
 	if profile.Repositories == nil {
 		profile.Repositories = []RepositoryProfile{}
 	}
 
+	// Load the previous analysis so unchanged repositories can skip expensive re-analysis
+	if a.incremental && a.repoBaseline == nil {
+		a.repoBaseline = a.loadRepositoryBaseline(username)
+	}
+
 	var cursor string
-	const pageSize = 50 // Smaller page size for better incremental processing
+	pageSize := a.repositoryPageSize()
 	pageNum := 1
 	totalFetched := len(profile.Repositories)
 
 	for {
-		log.Printf("Fetching repository page %d for user: %s (cursor: %s)", pageNum, username, cursor)
+		if a.repositoryCapReached(totalFetched) {
+			logging.Warnf("Repository cap reached: analyzing the first %d repositories of %s, skipping the rest (-max-repos)", a.maxRepos, username)
+			break
+		}
+		// Do not request more repositories than the cap leaves room for
+		if a.maxRepos > 0 {
+			pageSize = min(pageSize, a.maxRepos-totalFetched)
+		}
+
+		logging.Debugf("Fetching repository page %d for user: %s (cursor: %s)", pageNum, username, cursor)
 
 		req := &github.GraphQLRequest{
 			Query: github.UserRepositoriesQuery,
@@ -242,28 +511,31 @@ func (a *Analyzer) fetchUserRepositories(ctx context.Context, username, dockerUs
 		}
 
 		var resp github.UserRepositoriesResponse
-		log.Printf("Executing GraphQL query for page %d...", pageNum)
+		logging.Debugf("Executing GraphQL query for page %d...", pageNum)
 		if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
 			// Save progress with whatever we have so far
 			if len(profile.Repositories) > 0 {
-				log.Printf("GraphQL error after fetching %d repositories, saving progress: %v", len(profile.Repositories), err)
+				logging.Warnf("GraphQL error after fetching %d repositories, saving progress: %v", len(profile.Repositories), err)
 				if saveErr := a.saveProgress(username, dockerUsername, discourseUsername, profile, 2); saveErr != nil {
-					log.Printf("Warning: Failed to save progress during repository fetching: %v", saveErr)
+					logging.Warnf("Failed to save progress during repository fetching: %v", saveErr)
 				}
 				// Continue with partial data rather than failing completely
-				log.Printf("Continuing analysis with %d repositories fetched so far", len(profile.Repositories))
+				logging.Infof("Continuing analysis with %d repositories fetched so far", len(profile.Repositories))
 				return nil
 			}
 			return fmt.Errorf("GraphQL query failed on first page: %w", err)
 		}
-		log.Printf("GraphQL query completed for page %d, got %d repositories in response", pageNum, len(resp.User.Repositories.Nodes))
+		logging.Debugf("GraphQL query completed for page %d, got %d repositories in response", pageNum, len(resp.User.Repositories.Nodes))
 
 		// Process repositories from this page immediately
-		log.Printf("Starting to process %d repositories from page %d", len(resp.User.Repositories.Nodes), pageNum)
+		logging.Debugf("Starting to process %d repositories from page %d", len(resp.User.Repositories.Nodes), pageNum)
 		newReposThisPage := 0
 		for i, repoNode := range resp.User.Repositories.Nodes {
+			if a.repositoryCapReached(totalFetched + newReposThisPage) {
+				break
+			}
 			if i > 0 && i%10 == 0 {
-				log.Printf("Processed %d/%d repositories on page %d", i, len(resp.User.Repositories.Nodes), pageNum)
+				logging.Debugf("Processed %d/%d repositories on page %d", i, len(resp.User.Repositories.Nodes), pageNum)
 			}
 			repo := a.convertRepositoryNode(ctx, repoNode, username)
 			profile.Repositories = append(profile.Repositories, repo)
@@ -271,31 +543,32 @@ func (a *Analyzer) fetchUserRepositories(ctx context.Context, username, dockerUs
 
 			// Check if context was cancelled during processing
 			if ctx.Err() != nil {
-				log.Printf("Context cancelled during repository processing, saving progress with %d repositories", totalFetched+newReposThisPage)
+				logging.Warnf("Context cancelled during repository processing, saving progress with %d repositories", totalFetched+newReposThisPage)
 				if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 2); err != nil {
-					log.Printf("Warning: Failed to save progress: %v", err)
+					logging.Warnf("Failed to save progress: %v", err)
 				}
 				return ctx.Err()
 			}
 		}
-		log.Printf("Finished processing all %d repositories from page %d", newReposThisPage, pageNum)
+		logging.Debugf("Finished processing all %d repositories from page %d", newReposThisPage, pageNum)
 
 		totalFetched += newReposThisPage
-		log.Printf("Processed page %d: %d repositories (%d total fetched)", pageNum, newReposThisPage, totalFetched)
+		logging.Infof("Processed page %d: %d repositories (%d total fetched)", pageNum, newReposThisPage, totalFetched)
 
 		// Save progress after each page
 		if err := a.saveProgress(username, dockerUsername, discourseUsername, profile, 2); err != nil {
-			log.Printf("Warning: Failed to save progress after page %d: %v", pageNum, err)
+			logging.Warnf("Failed to save progress after page %d: %v", pageNum, err)
 		}
 
-		// Update skills analysis incrementally every few pages for better progress tracking
-		if pageNum%3 == 0 {
-			log.Printf("Running incremental skills analysis after page %d", pageNum)
+		// Update skills analysis incrementally every few pages for better progress tracking.
+		// The interval counts repositories so it does not depend on the page size.
+		if totalFetched/skillsAnalysisInterval > (totalFetched-newReposThisPage)/skillsAnalysisInterval {
+			logging.Infof("Running incremental skills analysis after page %d (%d repositories)", pageNum, totalFetched)
 			a.analyzeSkills(profile)
 		}
 
 		if !resp.User.Repositories.PageInfo.HasNextPage {
-			log.Printf("Completed repository fetching: %d total repositories", totalFetched)
+			logging.Infof("Completed repository fetching: %d total repositories", totalFetched)
 			break
 		}
 
@@ -306,18 +579,18 @@ func (a *Analyzer) fetchUserRepositories(ctx context.Context, username, dockerUs
 		select {
 		case <-time.After(100 * time.Millisecond):
 		case <-ctx.Done():
-			log.Printf("Context cancelled, saving progress with %d repositories", totalFetched)
+			logging.Warnf("Context cancelled, saving progress with %d repositories", totalFetched)
 			return ctx.Err()
 		}
 	}
 
-	log.Printf("Successfully fetched %d repositories for user: %s", len(profile.Repositories), username)
+	logging.Infof("Successfully fetched %d repositories for user: %s", len(profile.Repositories), username)
 	return nil
 }
 
 // fetchUserOrganizations fetches user's organizations
 func (a *Analyzer) fetchUserOrganizations(ctx context.Context, username string, profile *UserProfile) error {
-	log.Printf("Fetching organizations for user: %s", username)
+	logging.Infof("Fetching organizations for user: %s", username)
 
 	req := &github.GraphQLRequest{
 		Query: github.UserOrganizationsQuery,
@@ -386,46 +659,69 @@ func (a *Analyzer) fetchUserOrganizations(ctx context.Context, username string,
 		}
 	}
 
+	// Date each organization from the repositories already fetched in step 2
+	dateOrganizations(orgs, profile.Repositories)
+
 	profile.Organizations = orgs
-	log.Printf("Fetched %d organizations for user: %s", len(orgs), username)
+	logging.Infof("Fetched %d organizations for user: %s", len(orgs), username)
 	return nil
 }
 
+// contributionPeriod returns the configured contribution window, defaulting to the last year
+func (a *Analyzer) contributionPeriod() (time.Time, time.Time) {
+	until := a.contribUntil
+	if until.IsZero() {
+		until = time.Now()
+	}
+	since := a.contribSince
+	if since.IsZero() {
+		since = until.AddDate(-1, 0, 0)
+	}
+	return since, until
+}
+
 // fetchUserContributions fetches detailed contribution data
 func (a *Analyzer) fetchUserContributions(ctx context.Context, username string, profile *UserProfile) error {
-	log.Printf("Fetching contributions for user: %s", username)
+	since, until := a.contributionPeriod()
 
-	// Fetch contributions for the last year
-	now := time.Now()
-	oneYearAgo := now.AddDate(-1, 0, 0)
+	// GitHub limits each contributionsCollection query to one year, so longer windows are chunked
+	windows := github.SplitContributionWindows(since, until)
+	logging.Infof("Fetching contributions for user: %s (%s to %s, %d queries)",
+		username, since.Format("2006-01-02"), until.Format("2006-01-02"), len(windows))
 
-	req := &github.GraphQLRequest{
-		Query: github.UserContributionsQuery,
-		Variables: map[string]interface{}{
-			"username": username,
-			"from":     oneYearAgo.Format(time.RFC3339),
-			"to":       now.Format(time.RFC3339),
-		},
-	}
+	profile.Contributions.PeriodStart = since
+	profile.Contributions.PeriodEnd = until
 
-	var resp github.UserContributionsResponse
-	if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
-		return fmt.Errorf("GraphQL query failed: %w", err)
-	}
+	weeklyPattern := make([]int, 7)
+	monthlyContributions := make(map[string]int)
+	totalContributions := 0
 
-	contrib := resp.User.ContributionsCollection
+	for _, window := range windows {
+		req := &github.GraphQLRequest{
+			Query: github.UserContributionsQuery,
+			Variables: map[string]interface{}{
+				"username": username,
+				"from":     window.From.Format(time.RFC3339),
+				"to":       window.To.Format(time.RFC3339),
+			},
+		}
+
+		var resp github.UserContributionsResponse
+		if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
+			return fmt.Errorf("GraphQL query failed for %s to %s: %w",
+				window.From.Format("2006-01-02"), window.To.Format("2006-01-02"), err)
+		}
 
-	// Update contribution summary
-	profile.Contributions.TotalCommits = contrib.TotalCommitContributions
-	profile.Contributions.TotalIssues = contrib.TotalIssueContributions
-	profile.Contributions.TotalPullRequests = contrib.TotalPullRequestContributions
-	profile.Contributions.TotalCodeReviews = contrib.TotalPullRequestReviewContributions
+		contrib := resp.User.ContributionsCollection
 
-	// Process contribution calendar
-	if contrib.ContributionCalendar.TotalContributions > 0 {
-		weeklyPattern := make([]int, 7)
-		monthlyContributions := make(map[string]int)
+		// Update contribution summary
+		profile.Contributions.TotalCommits += contrib.TotalCommitContributions
+		profile.Contributions.TotalIssues += contrib.TotalIssueContributions
+		profile.Contributions.TotalPullRequests += contrib.TotalPullRequestContributions
+		profile.Contributions.TotalCodeReviews += contrib.TotalPullRequestReviewContributions
 
+		// Process contribution calendar
+		totalContributions += contrib.ContributionCalendar.TotalContributions
 		for _, week := range contrib.ContributionCalendar.Weeks {
 			for _, day := range week.ContributionDays {
 				if day.ContributionCount > 0 {
@@ -442,6 +738,25 @@ func (a *Analyzer) fetchUserContributions(ctx context.Context, username string,
 			}
 		}
 
+		// Process repository contributions for repository-specific stats
+		for _, repoContrib := range contrib.CommitContributionsByRepository {
+			repoName := repoContrib.Repository.NameWithOwner
+
+			// Find the repository in profile and update stats
+			for i, repo := range profile.Repositories {
+				if repo.FullName == repoName {
+					for _, contrib := range repoContrib.Contributions.Nodes {
+						if contrib.User.Login == username {
+							profile.Repositories[i].ContributionStats.Commits += contrib.CommitCount
+						}
+					}
+					break
+				}
+			}
+		}
+	}
+
+	if totalContributions > 0 {
 		profile.Contributions.WeeklyPattern = weeklyPattern
 		profile.Contributions.MonthlyContributions = monthlyContributions
 
@@ -449,26 +764,121 @@ func (a *Analyzer) fetchUserContributions(ctx context.Context, username string,
 		profile.Contributions.ConsistencyScore = a.calculateConsistencyScore(weeklyPattern)
 	}
 
-	// Process repository contributions for repository-specific stats
-	for _, repoContrib := range contrib.CommitContributionsByRepository {
-		repoName := repoContrib.Repository.NameWithOwner
+	return nil
+}
 
-		// Find the repository in profile and update stats
-		for i, repo := range profile.Repositories {
-			if repo.FullName == repoName {
-				for _, contrib := range repoContrib.Contributions.Nodes {
-					if contrib.User.Login == username {
-						profile.Repositories[i].ContributionStats.Commits += contrib.CommitCount
-					}
-				}
+// fetchLineStats fetches commit additions/deletions for the user's top repositories
+func (a *Analyzer) fetchLineStats(ctx context.Context, username string, profile *UserProfile) error {
+	var idResp github.UserIDResponse
+	idReq := &github.GraphQLRequest{
+		Query:     github.UserIDQuery,
+		Variables: map[string]interface{}{"username": username},
+	}
+	if err := a.client.ExecuteGraphQL(ctx, idReq, &idResp); err != nil {
+		return fmt.Errorf("failed to fetch user ID: %w", err)
+	}
+
+	targets := selectLineStatsRepositories(profile.Repositories, lineStatsTopRepositories)
+	logging.Infof("Fetching line statistics for %d repositories of user: %s", len(targets), username)
+
+	for _, i := range targets {
+		repo := &profile.Repositories[i]
+		parts := strings.SplitN(repo.FullName, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		var commits []github.CommitLineStats
+		var cursor interface{}
+		for page := 0; page < lineStatsMaxPages; page++ {
+			variables := map[string]interface{}{
+				"owner":    parts[0],
+				"name":     parts[1],
+				"authorId": idResp.User.ID,
+				"after":    cursor,
+			}
+			if !profile.Contributions.PeriodStart.IsZero() {
+				variables["since"] = profile.Contributions.PeriodStart.Format(time.RFC3339)
+			}
+			if !profile.Contributions.PeriodEnd.IsZero() {
+				variables["until"] = profile.Contributions.PeriodEnd.Format(time.RFC3339)
+			}
+
+			var resp github.CommitHistoryResponse
+			req := &github.GraphQLRequest{Query: github.CommitHistoryQuery, Variables: variables}
+			if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
+				logging.Warnf("Failed to fetch commit history for %s: %v", repo.FullName, err)
 				break
 			}
+
+			// Empty repositories have no default branch
+			if resp.Repository.DefaultBranchRef == nil {
+				break
+			}
+
+			history := resp.Repository.DefaultBranchRef.Target.History
+			commits = append(commits, history.Nodes...)
+			if !history.PageInfo.HasNextPage {
+				break
+			}
+			cursor = history.PageInfo.EndCursor
 		}
+
+		repo.ContributionStats.Additions, repo.ContributionStats.Deletions = sumCommitLineStats(commits)
 	}
 
+	aggregateLineStats(profile)
+	logging.Infof("Line statistics complete: +%d/-%d lines",
+		profile.Contributions.TotalAdditions, profile.Contributions.TotalDeletions)
+
 	return nil
 }
 
+// selectLineStatsRepositories returns the indexes of the repositories worth querying for line statistics,
+// preferring those with the most commits by the user and then the most stars
+func selectLineStatsRepositories(repos []RepositoryProfile, limit int) []int {
+	indexes := make([]int, 0, len(repos))
+	for i, repo := range repos {
+		if repo.IsFork {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+
+	sort.SliceStable(indexes, func(x, y int) bool {
+		rx, ry := repos[indexes[x]], repos[indexes[y]]
+		if rx.ContributionStats.Commits != ry.ContributionStats.Commits {
+			return rx.ContributionStats.Commits > ry.ContributionStats.Commits
+		}
+		return rx.Stars > ry.Stars
+	})
+
+	if len(indexes) > limit {
+		indexes = indexes[:limit]
+	}
+	return indexes
+}
+
+// sumCommitLineStats totals the additions and deletions of a commit history
+func sumCommitLineStats(commits []github.CommitLineStats) (int, int) {
+	additions, deletions := 0, 0
+	for _, commit := range commits {
+		additions += commit.Additions
+		deletions += commit.Deletions
+	}
+	return additions, deletions
+}
+
+// aggregateLineStats rolls per-repository additions/deletions up into the contribution summary
+func aggregateLineStats(profile *UserProfile) {
+	profile.Contributions.TotalAdditions = 0
+	profile.Contributions.TotalDeletions = 0
+	for _, repo := range profile.Repositories {
+		profile.Contributions.TotalAdditions += repo.ContributionStats.Additions
+		profile.Contributions.TotalDeletions += repo.ContributionStats.Deletions
+	}
+}
+
 // convertRepositoryNode converts a GitHub repository node to our RepositoryProfile
 func (a *Analyzer) convertRepositoryNode(ctx context.Context, node github.RepositoryNode, username string) RepositoryProfile {
 	repo := RepositoryProfile{
@@ -503,6 +913,11 @@ func (a *Analyzer) convertRepositoryNode(ctx context.Context, node github.Reposi
 		}
 	}
 
+	// Some repositories report no languages over GraphQL, depending on permissions
+	if len(repo.Languages) == 0 && a.languageFallback {
+		a.fetchLanguagesFallback(ctx, &repo)
+	}
+
 	// Set topics
 	if node.RepositoryTopics != nil {
 		for _, topicNode := range node.RepositoryTopics.Nodes {
@@ -524,10 +939,14 @@ func (a *Analyzer) convertRepositoryNode(ctx context.Context, node github.Reposi
 		repo.OpenIssues = node.Issues.TotalCount
 	}
 
-	// Analyze Docker configuration
-	dockerConfig := a.analyzeDockerConfig(ctx, repo.FullName)
-	if dockerConfig != nil {
-		repo.DockerConfig = dockerConfig
+	// Analyze Docker, CI, security and documentation, reusing the previous result if nothing was pushed since
+	if previous, ok := a.unchangedRepository(repo); ok {
+		repo.DockerConfig = previous.DockerConfig
+		repo.CIConfig = previous.CIConfig
+		repo.SecurityPractices = previous.SecurityPractices
+		repo.HasDocumentation = previous.HasDocumentation
+	} else {
+		a.analyzeRepositoryContents(ctx, &repo)
 	}
 	// Collaborators data not accessible due to permission restrictions
 	repo.CollaboratorCount = 0
@@ -544,17 +963,24 @@ func (a *Analyzer) convertRepositoryNode(ctx context.Context, node github.Reposi
 
 // analyzeLanguages analyzes programming languages used by the user
 func (a *Analyzer) analyzeLanguages(profile *UserProfile) {
-	log.Printf("Analyzing languages for user: %s", profile.Username)
+	logging.Infof("Analyzing languages for user: %s", profile.Username)
 
 	languageStats := make(map[string]*LanguageStats)
-	totalBytes := 0
+
+	// Percentages use weighted bytes, so monorepos can count for less than their size
+	detectMonorepos(profile.Repositories)
+	weightedBytes := make(map[string]float64)
+	totalWeighted := 0.0
 
 	// Aggregate language data from all repositories
 	for _, repo := range profile.Repositories {
+		weight := a.languageWeight(repo)
 		for language, bytes := range repo.Languages {
 			if bytes == 0 {
 				continue
 			}
+			weightedBytes[language] += float64(bytes) * weight
+			totalWeighted += float64(bytes) * weight
 
 			if languageStats[language] == nil {
 				languageStats[language] = &LanguageStats{
@@ -568,7 +994,6 @@ func (a *Analyzer) analyzeLanguages(profile *UserProfile) {
 			stats.Bytes += bytes
 			stats.RepositoryCount++
 			stats.ProjectCount++
-			totalBytes += bytes
 
 			// Update first/last used dates
 			if repo.CreatedAt.Before(stats.FirstUsed) {
@@ -583,10 +1008,13 @@ func (a *Analyzer) analyzeLanguages(profile *UserProfile) {
 	// Convert to slice and calculate percentages
 	var languages []LanguageStats
 	for _, stats := range languageStats {
-		if totalBytes > 0 {
-			stats.Percentage = float64(stats.Bytes) / float64(totalBytes) * 100
+		if totalWeighted > 0 {
+			stats.Percentage = weightedBytes[stats.Language] / totalWeighted * 100
 		}
 
+		// Estimated from bytes, since GitHub does not report line counts
+		stats.LinesOfCode = a.estimateLinesOfCode(stats.Language, stats.Bytes)
+
 		// Calculate proficiency score based on usage
 		yearsUsed := time.Since(stats.FirstUsed).Hours() / (24 * 365.25)
 		repoFactor := float64(stats.RepositoryCount) / float64(len(profile.Repositories))
@@ -595,6 +1023,8 @@ func (a *Analyzer) analyzeLanguages(profile *UserProfile) {
 		if stats.ProficiencyScore > 1 {
 			stats.ProficiencyScore = 1
 		}
+		// Languages not touched for years count less than current ones
+		stats.ProficiencyScore *= a.recencyFactor(stats.LastUsed)
 
 		languages = append(languages, *stats)
 	}
@@ -612,7 +1042,7 @@ func (a *Analyzer) analyzeLanguages(profile *UserProfile) {
 
 // analyzeSkills infers technical skills from repositories and languages
 func (a *Analyzer) analyzeSkills(profile *UserProfile) {
-	log.Printf("Analyzing skills for user: %s", profile.Username)
+	logging.Infof("Analyzing skills for user: %s", profile.Username)
 
 	skills := SkillProfile{
 		Frameworks:     []TechnologySkill{},
@@ -649,8 +1079,20 @@ func (a *Analyzer) analyzeSkills(profile *UserProfile) {
 		if repo.DockerConfig != nil {
 			a.categorizeDockerSkills(repo, technologyMap, &skills)
 		}
+
+		if repo.CIConfig != nil {
+			for _, system := range repo.CIConfig.CISystems {
+				a.addTechnologySkill(system, "devops", repo, technologyMap, &skills.DevOpsSkills)
+			}
+		}
 	}
 
+	a.addCIMaturityAreas(profile.Repositories, &skills)
+	skills.LanguageTrends = calculateLanguageTrends(profile.Repositories)
+
+	// The same technology can be detected per repository and in several categories
+	normalizeSkills(&skills)
+
 	profile.Skills = skills
 }
 
@@ -746,6 +1188,8 @@ func (a *Analyzer) addTechnologySkill(name, category string, repo RepositoryProf
 	if skill.Confidence > 1 {
 		skill.Confidence = 1
 	}
+	// Abandoned stacks should not rank as high as current ones
+	skill.Confidence *= a.recencyFactor(skill.LastUsed)
 
 	// Set proficiency level
 	if skill.Confidence < 0.3 {
@@ -842,7 +1286,10 @@ func (a *Analyzer) calculateConsistencyScore(weeklyPattern []int) float64 {
 
 // generateInsights generates AI-powered insights about the user
 func (a *Analyzer) generateInsights(profile *UserProfile) {
-	log.Printf("Generating insights for user: %s", profile.Username)
+	logging.Infof("Generating insights for user: %s", profile.Username)
+
+	// Work on repositories owned by others
+	profile.Collaborations = calculateCollaborations(profile)
 
 	insights := UserInsights{
 		LeadershipIndicators: []LeadershipIndicator{},
@@ -863,6 +1310,15 @@ func (a *Analyzer) generateInsights(profile *UserProfile) {
 		}
 	}
 
+	// Reviews of other people's pull requests
+	insights.MentorshipSigns = append(insights.MentorshipSigns, reviewMentorshipSigns(profile.ReviewActivity)...)
+
+	// Domains the user works in, from repository topics
+	insights.FocusAreas = calculateFocusAreas(profile.Repositories)
+
+	// Open-source posture from the licenses of owned repositories
+	insights.Licensing = summarizeLicenses(profile.Repositories)
+
 	// Analyze leadership indicators
 	insights.LeadershipIndicators = a.analyzeLeadershipIndicators(profile)
 
@@ -875,6 +1331,12 @@ func (a *Analyzer) generateInsights(profile *UserProfile) {
 	// Identify strengths and growth areas
 	insights.StrengthAreas, insights.GrowthAreas = a.identifyStrengthsAndGrowthAreas(profile)
 
+	// Count the repositories following each security practice
+	insights.ArchitecturalThinking.SecurityPractices = countSecurityPractices(profile.Repositories)
+	insights.ArchitecturalThinking.SecurityMindedness = len(insights.ArchitecturalThinking.SecurityPractices) > 0
+
+	insights.CommunityImpact.DocumentationContrib = countDocumentationRepositories(profile.Repositories)
+
 	profile.Insights = insights
 }
 
@@ -978,27 +1440,31 @@ func (a *Analyzer) analyzeLeadershipIndicators(profile *UserProfile) []Leadershi
 
 // calculateImpactScore calculates an overall impact score for the user
 func (a *Analyzer) calculateImpactScore(profile *UserProfile) float64 {
+	config := DefaultImpactConfig
+	if a.impactConfig != nil {
+		config = *a.impactConfig
+	}
 	score := 0.0
 
 	// Contribution volume
 	totalContributions := profile.Contributions.TotalCommits + profile.Contributions.TotalPullRequests + profile.Contributions.TotalIssues
-	score += float64(totalContributions) / 1000 // Normalize
+	score += config.Contributions.score(float64(totalContributions), config.LogScale)
 
-	// Repository impact (stars received)
-	totalStars := 0
+	// Repository impact (stars received, decayed for stale repositories with -star-decay)
+	totalStars := 0.0
 	for _, repo := range profile.Repositories {
-		totalStars += repo.Stars
+		totalStars += repo.DecayedStars(a.starDecayHalfLife)
 	}
-	score += float64(totalStars) / 100
+	score += config.Stars.score(totalStars, config.LogScale)
 
 	// Language diversity
-	score += float64(len(profile.Languages)) / 10
+	score += config.Languages.score(float64(len(profile.Languages)), config.LogScale)
 
 	// Organization involvement
-	score += float64(len(profile.Organizations)) / 5
+	score += config.Organizations.score(float64(len(profile.Organizations)), config.LogScale)
 
 	// Consistency
-	score += profile.Contributions.ConsistencyScore
+	score += config.ConsistencyWeight * profile.Contributions.ConsistencyScore
 
 	// Normalize to 0-1 scale
 	if score > 1 {
@@ -1008,91 +1474,6 @@ func (a *Analyzer) calculateImpactScore(profile *UserProfile) float64 {
 	return score
 }
 
-// recommendRoles suggests suitable roles based on the profile
-func (a *Analyzer) recommendRoles(profile *UserProfile) []string {
-	var roles []string
-
-	careerLevel := profile.Insights.CareerLevel
-	if careerLevel == "" {
-		careerLevel = "mid" // Default fallback if career level is empty
-	}
-	primaryLangs := profile.Skills.PrimaryLanguages
-
-	// Backend roles
-	backendLangs := []string{"Go", "Python", "Java", "C#", "Node.js", "Rust"}
-	if a.hasAnyLanguage(primaryLangs, backendLangs) {
-		switch careerLevel {
-		case "principal":
-			roles = append(roles, "Principal Engineer", "Staff Engineer", "Engineering Manager")
-		case "senior":
-			roles = append(roles, "Senior Backend Engineer", "Backend Team Lead", "Staff Engineer")
-		case "junior":
-			roles = append(roles, "Junior Backend Developer", "Software Engineer")
-		default:
-			roles = append(roles, "Backend Developer", "Software Engineer")
-		}
-	}
-
-	// Frontend roles
-	frontendLangs := []string{"JavaScript", "TypeScript", "React", "Vue", "Angular"}
-	if a.hasAnyLanguage(primaryLangs, frontendLangs) {
-		switch careerLevel {
-		case "principal":
-			roles = append(roles, "Principal Frontend Engineer", "Frontend Architect")
-		case "senior":
-			roles = append(roles, "Senior Frontend Engineer", "Frontend Team Lead")
-		case "junior":
-			roles = append(roles, "Junior Frontend Developer", "UI Developer")
-		default:
-			roles = append(roles, "Frontend Developer", "UI Developer")
-		}
-	}
-
-	// Full-stack roles
-	if a.hasAnyLanguage(primaryLangs, backendLangs) && a.hasAnyLanguage(primaryLangs, frontendLangs) {
-		switch careerLevel {
-		case "principal":
-			roles = append(roles, "Principal Engineer", "Full-Stack Architect", "Technical Lead")
-		case "senior":
-			roles = append(roles, "Senior Full-Stack Engineer", "Technical Lead")
-		case "junior":
-			roles = append(roles, "Junior Full-Stack Developer", "Software Engineer")
-		default:
-			roles = append(roles, "Full-Stack Developer", "Software Engineer")
-		}
-	}
-
-	// DevOps roles with career level consideration
-	if len(profile.Skills.DevOpsSkills) >= 3 {
-		switch careerLevel {
-		case "principal":
-			roles = append(roles, "Principal SRE", "DevOps Architect", "Platform Lead")
-		case "senior":
-			roles = append(roles, "Senior DevOps Engineer", "Senior SRE", "Platform Engineer")
-		case "junior":
-			roles = append(roles, "Junior DevOps Engineer", "Platform Engineer")
-		default:
-			roles = append(roles, "DevOps Engineer", "Platform Engineer", "SRE")
-		}
-	}
-
-	// Ensure we always have at least some role recommendations
-	if len(roles) == 0 {
-		switch careerLevel {
-		case "principal":
-			roles = append(roles, "Principal Engineer", "Staff Engineer", "Technical Lead")
-		case "senior":
-			roles = append(roles, "Senior Software Engineer", "Technical Lead")
-		case "junior":
-			roles = append(roles, "Junior Software Engineer", "Software Developer")
-		default:
-			roles = append(roles, "Software Engineer", "Software Developer")
-		}
-	}
-
-	return roles
-}
-
 // identifyStrengthsAndGrowthAreas identifies user's strengths and areas for growth
 func (a *Analyzer) identifyStrengthsAndGrowthAreas(profile *UserProfile) ([]string, []string) {
 	var strengths, growthAreas []string
@@ -1120,6 +1501,12 @@ func (a *Analyzer) identifyStrengthsAndGrowthAreas(profile *UserProfile) ([]stri
 		strengths = append(strengths, "Cross-team collaboration")
 	}
 
+	// Strengths based on documented repositories
+	documented := countDocumentationRepositories(profile.Repositories)
+	if documented >= documentationStrengthThreshold {
+		strengths = append(strengths, "Documentation")
+	}
+
 	// Growth areas based on missing common skills
 	commonSkills := []string{"testing", "documentation", "ci/cd", "monitoring"}
 	hasSkill := make(map[string]bool)
@@ -1130,6 +1517,19 @@ func (a *Analyzer) identifyStrengthsAndGrowthAreas(profile *UserProfile) ([]stri
 		}
 	}
 
+	// CI and test setups detected in repository contents count like topics
+	for _, area := range profile.Skills.TechnicalAreas {
+		switch area.Area {
+		case ciArea:
+			hasSkill["ci/cd"] = true
+		case testingArea:
+			hasSkill["testing"] = true
+		}
+	}
+	if documented > 0 {
+		hasSkill["documentation"] = true
+	}
+
 	for _, skill := range commonSkills {
 		if !hasSkill[skill] && !hasSkill[skill+"-testing"] && !hasSkill[skill+"-automation"] {
 			growthAreas = append(growthAreas, strings.Title(skill))
@@ -1153,13 +1553,28 @@ func (a *Analyzer) hasAnyLanguage(userLangs, targetLangs []string) bool {
 
 // analyzeDockerHub analyzes the user's Docker Hub profile
 func (a *Analyzer) analyzeDockerHub(ctx context.Context, username string, profile *UserProfile) error {
-	log.Printf("Analyzing Docker Hub profile for user: %s", username)
+	if a.profileCache != nil {
+		if cached, hit := a.profileCache.GetDockerHubProfile(username); hit {
+			logging.Infof("Using cached Docker Hub profile for user: %s", username)
+			profile.DockerHubProfile = cached
+			return nil
+		}
+	}
+
+	logging.Infof("Analyzing Docker Hub profile for user: %s", username)
 
 	// Analyze Docker Hub profile
 	dockerProfile, err := a.dockerClient.AnalyzeDockerProfile(ctx, username)
 	if err != nil {
 		return fmt.Errorf("failed to analyze Docker Hub profile: %w", err)
 	}
+	if a.profileCache != nil {
+		defer func() {
+			if err := a.profileCache.SetDockerHubProfile(username, profile.DockerHubProfile); err != nil {
+				logging.Warnf("Failed to cache Docker Hub profile for %s: %v", username, err)
+			}
+		}()
+	}
 
 	// Convert to simplified profile structure for integration
 	if dockerProfile != nil && len(dockerProfile.Repositories) > 0 {
@@ -1181,7 +1596,7 @@ func (a *Analyzer) analyzeDockerHub(ctx context.Context, username string, profil
 			}
 		}
 
-		log.Printf("Docker Hub analysis complete: %d images, %d total downloads",
+		logging.Infof("Docker Hub analysis complete: %d images, %d total downloads",
 			dockerProfile.TotalImages, dockerProfile.ImpactMetrics.TotalDownloads)
 	}
 
@@ -1194,9 +1609,9 @@ func (a *Analyzer) analyzeDiscourseProfile(ctx context.Context, username, discou
 	targetUsername := username
 	if discourseUsername != "" {
 		targetUsername = discourseUsername
-		log.Printf("Analyzing Discourse profile for user: %s (using custom Discourse username: %s)", username, discourseUsername)
+		logging.Infof("Analyzing Discourse profile for user: %s (using custom Discourse username: %s)", username, discourseUsername)
 	} else {
-		log.Printf("Analyzing Discourse profile for user: %s", username)
+		logging.Infof("Analyzing Discourse profile for user: %s", username)
 	}
 
 	// For Jenkins community, try common username variations
@@ -1222,10 +1637,10 @@ func (a *Analyzer) analyzeDiscourseProfile(ctx context.Context, username, discou
 	for _, tryUsername := range usernamesToTry {
 		discourseProfile, err = a.discourseClient.AnalyzeDiscourseProfile(ctx, tryUsername)
 		if err == nil && discourseProfile != nil {
-			log.Printf("Found Discourse profile for username: %s", tryUsername)
+			logging.Infof("Found Discourse profile for username: %s", tryUsername)
 			break
 		}
-		log.Printf("Discourse profile not found for username: %s (%v)", tryUsername, err)
+		logging.Debugf("Discourse profile not found for username: %s (%v)", tryUsername, err)
 	}
 
 	if discourseProfile == nil {
@@ -1255,12 +1670,44 @@ func (a *Analyzer) analyzeDiscourseProfile(ctx context.Context, username, discou
 		CategoryActivity:    discourseProfile.CategoryActivity,
 	}
 
-	log.Printf("Discourse analysis complete: %d posts, %d solutions, trust level %d",
+	logging.Infof("Discourse analysis complete: %d posts, %d solutions, trust level %d",
 		discourseProfile.PostCount, discourseProfile.SolutionsCount, discourseProfile.TrustLevel)
 
 	return nil
 }
 
+// analyzeStackOverflow analyzes the user's Stack Overflow reputation and answers
+func (a *Analyzer) analyzeStackOverflow(ctx context.Context, user string, profile *UserProfile) error {
+	logging.Infof("Analyzing Stack Overflow profile for user: %s", user)
+
+	soProfile, err := a.stackOverflowClient.AnalyzeStackOverflowProfile(ctx, user)
+	if err != nil {
+		return fmt.Errorf("failed to analyze Stack Overflow profile: %w", err)
+	}
+
+	// Convert to simplified profile structure for integration
+	profile.StackOverflowProfile = &StackOverflowProfile{
+		UserID:          soProfile.UserID,
+		DisplayName:     soProfile.DisplayName,
+		ProfileURL:      soProfile.ProfileURL,
+		Reputation:      soProfile.Reputation,
+		GoldBadges:      soProfile.BadgeCounts.Gold,
+		SilverBadges:    soProfile.BadgeCounts.Silver,
+		BronzeBadges:    soProfile.BadgeCounts.Bronze,
+		AnswerCount:     soProfile.AnswerCount,
+		AcceptedAnswers: soProfile.AcceptedAnswers,
+		AnswersCapped:   soProfile.AnswersCapped,
+		TopTags:         soProfile.TopTags,
+		JoinedDate:      soProfile.JoinedDate,
+		LastActivity:    soProfile.LastActivity,
+	}
+
+	logging.Infof("Stack Overflow analysis complete: %d reputation, %d accepted answers",
+		soProfile.Reputation, soProfile.AcceptedAnswers)
+
+	return nil
+}
+
 // ProgressData represents saved analysis progress
 type ProgressData struct {
 	Username          string       `json:"username"`
@@ -1268,6 +1715,7 @@ type ProgressData struct {
 	DiscourseUsername string       `json:"discourseUsername,omitempty"`
 	LastStep          int          `json:"lastStep"`
 	SavedAt           time.Time    `json:"savedAt"`
+	SchemaVersion     string       `json:"schemaVersion"`
 	UserProfile       *UserProfile `json:"userProfile"`
 }
 
@@ -1290,7 +1738,7 @@ func (a *Analyzer) getProgressFilename(username, dockerUsername, discourseUserna
 		effectiveDiscourseUser := defaultToGitHubUsername(discourseUsername, username)
 		scopedName = fmt.Sprintf("%s_docker_%s_discourse_%s", username, effectiveDockerUser, effectiveDiscourseUser)
 	}
-	return filepath.Join(a.saveProgressDir, fmt.Sprintf("%s_progress.json", scopedName))
+	return filepath.Join(a.saveProgressDir, scopedName+progressFileSuffix)
 }
 
 // saveProgress saves the current analysis progress
@@ -1306,6 +1754,7 @@ func (a *Analyzer) saveProgress(username, dockerUsername, discourseUsername stri
 		DiscourseUsername: discourseUsername,
 		LastStep:          step,
 		SavedAt:           time.Now(),
+		SchemaVersion:     ProfileSchemaVersion,
 		UserProfile:       profile,
 	}
 
@@ -1315,11 +1764,11 @@ func (a *Analyzer) saveProgress(username, dockerUsername, discourseUsername stri
 		return fmt.Errorf("failed to marshal progress data: %w", err)
 	}
 
-	if err := os.WriteFile(filename, data, 0644); err != nil {
+	if err := WriteFileAtomic(filename, data, 0644); err != nil {
 		return fmt.Errorf("failed to write progress file: %w", err)
 	}
 
-	log.Printf("Progress saved after step %d for user: %s", step, username)
+	logging.Debugf("Progress saved after step %d for user: %s", step, username)
 	return nil
 }
 
@@ -1339,7 +1788,7 @@ func (a *Analyzer) tryResumeProgress(username, dockerUsername, discourseUsername
 			data, err = os.ReadFile(unscopedFilename)
 			if err == nil {
 				filename = unscopedFilename
-				log.Printf("Found unscoped progress file, will validate usernames")
+				logging.Debugf("Found unscoped progress file, will validate usernames")
 			}
 		}
 	}
@@ -1351,7 +1800,7 @@ func (a *Analyzer) tryResumeProgress(username, dockerUsername, discourseUsername
 
 	var progressData ProgressData
 	if err := json.Unmarshal(data, &progressData); err != nil {
-		log.Printf("Warning: Failed to parse progress file, starting from beginning: %v", err)
+		logging.Warnf("Failed to parse progress file, starting from beginning: %v", err)
 		return nil, 1
 	}
 
@@ -1363,26 +1812,36 @@ func (a *Analyzer) tryResumeProgress(username, dockerUsername, discourseUsername
 	savedDiscourse := defaultToGitHubUsername(progressData.DiscourseUsername, username)
 
 	if requestedDocker != savedDocker || requestedDiscourse != savedDiscourse {
-		log.Printf("Progress file username mismatch (saved Docker: %s, requested: %s; saved Discourse: %s, requested: %s), starting fresh",
+		logging.Warnf("Progress file username mismatch (saved Docker: %s, requested: %s; saved Discourse: %s, requested: %s), starting fresh",
 			savedDocker, requestedDocker, savedDiscourse, requestedDiscourse)
 		// Delete the file that was actually read (could be scoped or unscoped)
 		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
-			log.Printf("Warning: Failed to clean up mismatched progress file %s: %v", filename, err)
+			logging.Warnf("Failed to clean up mismatched progress file %s: %v", filename, err)
 		}
 		return nil, 1
 	}
 
-	// Check if progress file is too old (older than 1 day)
-	if time.Since(progressData.SavedAt) > 24*time.Hour {
-		log.Printf("Progress file is older than 24 hours, starting fresh")
+	// Discard progress written by a version of the analyzer with a different profile layout
+	if progressData.SchemaVersion != ProfileSchemaVersion {
+		logging.Warnf("Progress file schema version %q does not match %q, starting fresh",
+			progressData.SchemaVersion, ProfileSchemaVersion)
+		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			logging.Warnf("Failed to clean up outdated progress file %s: %v", filename, err)
+		}
+		return nil, 1
+	}
+
+	// Check if progress file is too old
+	if time.Since(progressData.SavedAt) > a.progressMaxAge {
+		logging.Infof("Progress file is older than %v, starting fresh", a.progressMaxAge)
 		// Delete the file that was actually read
 		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
-			log.Printf("Warning: Failed to clean up old progress file %s: %v", filename, err)
+			logging.Warnf("Failed to clean up old progress file %s: %v", filename, err)
 		}
 		return nil, 1
 	}
 
-	log.Printf("Resuming analysis for user %s from step %d (saved at %s)",
+	logging.Infof("Resuming analysis for user %s from step %d (saved at %s)",
 		username, progressData.LastStep+1, progressData.SavedAt.Format("2006-01-02 15:04:05"))
 
 	return progressData.UserProfile, progressData.LastStep + 1
@@ -1392,9 +1851,9 @@ func (a *Analyzer) tryResumeProgress(username, dockerUsername, discourseUsername
 func (a *Analyzer) cleanupProgress(username, dockerUsername, discourseUsername string) {
 	filename := a.getProgressFilename(username, dockerUsername, discourseUsername)
 	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
-		log.Printf("Warning: Failed to clean up progress file: %v", err)
+		logging.Warnf("Failed to clean up progress file: %v", err)
 	} else {
-		log.Printf("Progress file cleaned up for user: %s", username)
+		logging.Debugf("Progress file cleaned up for user: %s", username)
 	}
 }
 
@@ -1406,16 +1865,17 @@ func (a *Analyzer) saveToCache(username string, profile *UserProfile) error {
 	}
 
 	filename := filepath.Join(a.cacheDir, fmt.Sprintf("%s_analysis.json", username))
+	profile.SchemaVersion = ProfileSchemaVersion
 	data, err := json.MarshalIndent(profile, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal profile to JSON: %w", err)
 	}
 
-	if err := os.WriteFile(filename, data, 0644); err != nil {
+	if err := WriteFileAtomic(filename, data, 0644); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
 
-	log.Printf("Analysis cached for user: %s", username)
+	logging.Infof("Analysis cached for user: %s", username)
 	return nil
 }
 
@@ -1431,13 +1891,25 @@ func (a *Analyzer) tryLoadFromCache(username string) *UserProfile {
 
 	var profile UserProfile
 	if err := json.Unmarshal(data, &profile); err != nil {
-		log.Printf("Warning: Failed to parse cache file, will re-analyze: %v", err)
+		logging.Warnf("Failed to parse cache file, will re-analyze: %v", err)
+		return nil
+	}
+
+	// Older layouts may unmarshal without error but silently lose fields
+	if profile.SchemaVersion != ProfileSchemaVersion {
+		logging.Warnf("Cache schema version %q does not match %q, will re-analyze", profile.SchemaVersion, ProfileSchemaVersion)
+		return nil
+	}
+
+	// An anonymized analysis cannot produce a regular profile
+	if profile.Anonymized && !a.anonymize {
+		logging.Infof("Cached analysis is anonymized, will re-analyze")
 		return nil
 	}
 
-	// Check if cache is too old (older than 7 days)
-	if time.Since(profile.LastAnalyzed) > 7*24*time.Hour {
-		log.Printf("Cache is older than 7 days, will re-analyze")
+	// Check if cache is too old
+	if time.Since(profile.LastAnalyzed) > a.analysisMaxAge {
+		logging.Infof("Cache is older than %v, will re-analyze", a.analysisMaxAge)
 		return nil
 	}
 
@@ -1449,25 +1921,38 @@ func (a *Analyzer) GetGitHubRateLimitStatus() github.RateLimitInfo {
 	return a.client.GetRateLimitStatus()
 }
 
-// analyzeDockerConfig analyzes a repository for Docker configuration and expertise
-func (a *Analyzer) analyzeDockerConfig(ctx context.Context, fullName string) *DockerConfig {
-	parts := strings.Split(fullName, "/")
+// analyzeRepositoryContents fetches the root directory of a repository once and analyzes it
+// for Docker configuration, CI/testing setup, documentation and, with -with-security-scan, security
+// practices. Fields of repo are left nil or false when nothing was found.
+func (a *Analyzer) analyzeRepositoryContents(ctx context.Context, repo *RepositoryProfile) {
+	parts := strings.Split(repo.FullName, "/")
 	if len(parts) != 2 {
-		return nil
+		return
 	}
-	owner, repo := parts[0], parts[1]
+	owner, name := parts[0], parts[1]
 
 	// Fetch repository contents
-	contents, err := a.client.FetchRepositoryContents(ctx, owner, repo)
+	contents, err := a.client.FetchRepositoryContents(ctx, owner, name)
 	if err != nil {
-		log.Printf("Failed to fetch contents for %s: %v", fullName, err)
-		return nil
+		logging.Warnf("Failed to fetch contents for %s: %v", repo.FullName, err)
+		return
 	}
 
 	if len(contents) == 0 {
-		return nil
+		return
 	}
 
+	if a.withSecurityScan {
+		repo.SecurityPractices = a.analyzeSecurityPractices(ctx, owner, name, contents)
+	}
+
+	repo.DockerConfig = a.analyzeDockerConfig(contents)
+	repo.CIConfig = a.analyzeCIConfig(ctx, owner, name, contents)
+	repo.HasDocumentation = hasDocumentation(contents)
+}
+
+// analyzeDockerConfig analyzes repository root contents for Docker configuration and expertise
+func (a *Analyzer) analyzeDockerConfig(contents []github.RepositoryContentResponse) *DockerConfig {
 	config := &DockerConfig{
 		DockerFiles:  []DockerFile{},
 		ComposeFiles: []string{},
@@ -1785,7 +2270,7 @@ func (a *Analyzer) categorizeDockerSkills(repo RepositoryProfile, techMap map[st
 
 // logAnalysisSummary provides a summary of what data was successfully collected
 func (a *Analyzer) logAnalysisSummary(profile *UserProfile) {
-	log.Printf("=== Analysis Summary for %s ===", profile.Username)
+	logging.Infof("=== Analysis Summary for %s ===", profile.Username)
 
 	// Repository analysis
 	repoCount := len(profile.Repositories)
@@ -1796,16 +2281,16 @@ func (a *Analyzer) logAnalysisSummary(profile *UserProfile) {
 		}
 	}
 
-	log.Printf("📦 Repositories: %d total", repoCount)
+	logging.Infof("📦 Repositories: %d total", repoCount)
 	if dockerRepoCount > 0 {
-		log.Printf("🐳 Docker repositories detected: %d", dockerRepoCount)
+		logging.Infof("🐳 Docker repositories detected: %d", dockerRepoCount)
 	}
 
 	// Language analysis
 	if len(profile.Languages) > 0 {
-		log.Printf("💻 Programming languages: %d", len(profile.Languages))
+		logging.Infof("💻 Programming languages: %d", len(profile.Languages))
 		if len(profile.Languages) >= 3 {
-			log.Printf("    Primary languages: %s, %s, %s",
+			logging.Infof("    Primary languages: %s, %s, %s",
 				profile.Languages[0].Language,
 				profile.Languages[1].Language,
 				profile.Languages[2].Language)
@@ -1821,31 +2306,31 @@ func (a *Analyzer) logAnalysisSummary(profile *UserProfile) {
 		"Technical Areas": len(profile.Skills.TechnicalAreas),
 	}
 
-	log.Printf("🛠️  Skills detected:")
+	logging.Infof("🛠️  Skills detected:")
 	for category, count := range skillCounts {
 		if count > 0 {
-			log.Printf("    %s: %d", category, count)
+			logging.Infof("    %s: %d", category, count)
 		}
 	}
 
 	// Organization analysis
 	if len(profile.Organizations) > 0 {
-		log.Printf("🏢 Organizations: %d", len(profile.Organizations))
+		logging.Infof("🏢 Organizations: %d", len(profile.Organizations))
 	}
 
 	// Docker Hub analysis
 	if profile.DockerHubProfile != nil {
-		log.Printf("🐳 Docker Hub: %d images, %.1fM downloads, %s level",
+		logging.Infof("🐳 Docker Hub: %d images, %.1fM downloads, %s level",
 			profile.DockerHubProfile.TotalImages,
 			float64(profile.DockerHubProfile.TotalDownloads)/1000000,
 			profile.DockerHubProfile.ProficiencyLevel)
 	}
 
 	// Overall metrics
-	log.Printf("📊 Overall: %d commits, %d repos, %.1f impact score",
+	logging.Infof("📊 Overall: %d commits, %d repos, %.1f impact score",
 		profile.Contributions.TotalCommits,
 		repoCount,
 		profile.Insights.OverallImpactScore)
 
-	log.Printf("✅ Profile analysis ready for template generation")
+	logging.Infof("✅ Profile analysis ready for template generation")
 }
\ No newline at end of file
@@ -0,0 +1,302 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+)
+
+// newTestAnalyzer creates an analyzer whose GraphQL and REST requests go to server
+func newTestAnalyzer(t *testing.T, server *httptest.Server) *Analyzer {
+	t.Helper()
+	return &Analyzer{
+		client: github.NewClientWithRateLimit("test-token", 100, 10).WithEndpoint(server.URL).WithRESTEndpoint(server.URL),
+	}
+}
+
+// TestAnalyzeUserPartialOnCancel verifies a context cancelled mid-analysis yields the data gathered
+// so far, flagged as partial, along with ErrPartialAnalysis
+func TestAnalyzeUserPartialOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req github.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			// Optional REST lookups are not needed here
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, paginated := req.Variables["first"]; !paginated {
+			w.Write([]byte(`{"data":{"user":{"login":"octocat","name":"The Octocat","createdAt":"2015-01-01T00:00:00Z"}}}`))
+			return
+		}
+
+		// The timeout strikes while the second page of repositories is being fetched
+		if after, _ := req.Variables["after"].(string); after != "" {
+			cancel()
+			return
+		}
+		w.Write([]byte(`{"data":{"user":{"repositories":{"pageInfo":{"hasNextPage":true,"endCursor":"c1"},"nodes":[
+			{"name":"hello-world","nameWithOwner":"octocat/hello-world","stargazerCount":10,"primaryLanguage":{"name":"Go"},
+			 "languages":{"nodes":[{"name":"Go"}],"edges":[{"size":4096}]},"owner":{"login":"octocat"}}]}}}}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	analyzer.saveProgressDir = t.TempDir()
+	analyzer.cacheDir = t.TempDir()
+
+	prof, err := analyzer.AnalyzeUserWithCustomUsernames(ctx, "octocat", "octocat", "")
+	if !errors.Is(err, ErrPartialAnalysis) {
+		t.Fatalf("Expected ErrPartialAnalysis, got %v", err)
+	}
+	if prof == nil || !prof.Partial {
+		t.Fatalf("Expected a profile flagged as partial, got %+v", prof)
+	}
+	if prof.Name != "The Octocat" || len(prof.Repositories) != 1 {
+		t.Errorf("Expected the basic info and the first repository page, got name %q and %d repositories", prof.Name, len(prof.Repositories))
+	}
+	if len(prof.Skills.PrimaryLanguages) == 0 {
+		t.Error("Expected languages to be derived from the partial data")
+	}
+}
+
+// TestAnalyzeUserSavesProgressOnCancel verifies a context cancelled while repositories are processed,
+// as on Ctrl-C, saves the repositories fetched so far so that the next run resumes from them
+func TestAnalyzeUserSavesProgressOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req github.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			// The interrupt arrives while the first repository's contents are inspected
+			cancel()
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, paginated := req.Variables["first"]; !paginated {
+			w.Write([]byte(`{"data":{"user":{"login":"octocat","createdAt":"2015-01-01T00:00:00Z"}}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"user":{"repositories":{"pageInfo":{"hasNextPage":true,"endCursor":"c1"},"nodes":[
+			{"name":"hello-world","nameWithOwner":"octocat/hello-world","primaryLanguage":{"name":"Go"},
+			 "languages":{"nodes":[{"name":"Go"}],"edges":[{"size":4096}]},"owner":{"login":"octocat"}},
+			{"name":"spoon-knife","nameWithOwner":"octocat/spoon-knife","owner":{"login":"octocat"}}]}}}}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	analyzer.saveProgressDir = t.TempDir()
+	analyzer.cacheDir = t.TempDir()
+	analyzer.progressMaxAge = DefaultProgressMaxAge
+
+	if _, err := analyzer.AnalyzeUserWithCustomUsernames(ctx, "octocat", "octocat", ""); !errors.Is(err, ErrPartialAnalysis) {
+		t.Fatalf("Expected ErrPartialAnalysis, got %v", err)
+	}
+
+	saved, _ := analyzer.tryResumeProgress("octocat", "octocat", "")
+	if saved == nil {
+		t.Fatal("Expected progress to be saved when the context was cancelled")
+	}
+	if len(saved.Repositories) != 1 || saved.Repositories[0].Name != "hello-world" {
+		t.Errorf("Expected the saved progress to hold hello-world only, got %d repositories", len(saved.Repositories))
+	}
+}
+
+// TestBotsExcludedFromContributors verifies "[bot]" accounts and the configured bot logins are left out
+// of contributor counts
+func TestBotsExcludedFromContributors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"repository":{"mentionableUsers":{"totalCount":4,"nodes":[{"login":"monalisa"},{"login":"github-actions[bot]"},{"login":"dependabot"},{"login":"ci-robot"}]}}}}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	prof := &UserProfile{Repositories: []RepositoryProfile{{FullName: "octocat/hello-world", IsOwner: true}}}
+
+	analyzer.fetchContributors(context.Background(), "octocat", prof)
+	if expected := []string{"ci-robot", "monalisa"}; !reflect.DeepEqual(prof.Repositories[0].Contributors, expected) {
+		t.Errorf("Expected default bots to be excluded, leaving %v, got %v", expected, prof.Repositories[0].Contributors)
+	}
+
+	analyzer.SetBotLogins([]string{"CI-Robot"})
+	analyzer.fetchContributors(context.Background(), "octocat", prof)
+	if expected := []string{"dependabot", "monalisa"}; !reflect.DeepEqual(prof.Repositories[0].Contributors, expected) {
+		t.Errorf("Expected configured bots to be excluded, leaving %v, got %v", expected, prof.Repositories[0].Contributors)
+	}
+}
+
+// TestFetchContributors verifies owned repositories get their contributors, without the user,
+// and that forks and other users' repositories are not queried
+func TestFetchContributors(t *testing.T) {
+	var queried []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req github.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode GraphQL request: %v", err)
+		}
+		queried = append(queried, req.Variables["owner"].(string)+"/"+req.Variables["name"].(string))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"repository":{"mentionableUsers":{"totalCount":3,"nodes":[{"login":"octocat"},{"login":"monalisa"},{"login":"hubot"}]}}}}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	prof := &UserProfile{Repositories: []RepositoryProfile{
+		{FullName: "octocat/hello-world", IsOwner: true, Stars: 10},
+		{FullName: "octocat/fork", IsOwner: true, IsFork: true},
+		{FullName: "acme/widget", IsOwner: false, Stars: 100},
+	}}
+
+	analyzer.fetchContributors(context.Background(), "octocat", prof)
+
+	if !reflect.DeepEqual(queried, []string{"octocat/hello-world"}) {
+		t.Errorf("Expected only the owned, non-fork repository to be queried, got %v", queried)
+	}
+	if expected := []string{"hubot", "monalisa"}; !reflect.DeepEqual(prof.Repositories[0].Contributors, expected) {
+		t.Errorf("Expected contributors %v, got %v", expected, prof.Repositories[0].Contributors)
+	}
+	if prof.Repositories[1].Contributors != nil || prof.Repositories[2].Contributors != nil {
+		t.Error("Expected no contributors on repositories that were not queried")
+	}
+}
+
+// TestFetchReviewsMentorshipSigns verifies reviews of other people's pull requests, first-time
+// contributors in particular, yield mentorship signs while reviews of the user's own are ignored
+func TestFetchReviewsMentorshipSigns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"user":{"contributionsCollection":{"pullRequestReviewContributions":{"totalCount":42,"nodes":[
+			{"pullRequest":{"authorAssociation":"FIRST_TIME_CONTRIBUTOR","author":{"login":"newcomer"}},"repository":{"owner":{"login":"jenkinsci"}}},
+			{"pullRequest":{"authorAssociation":"CONTRIBUTOR","author":{"login":"monalisa"}},"repository":{"owner":{"login":"kubernetes"}}},
+			{"pullRequest":{"authorAssociation":"OWNER","author":{"login":"octocat"}},"repository":{"owner":{"login":"octocat"}}}
+		]}}}}}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	prof := &UserProfile{Username: "octocat"}
+
+	analyzer.fetchReviews(context.Background(), "octocat", prof)
+
+	expected := &ReviewActivity{TotalReviews: 42, ReviewsOfOthers: 2, FirstTimeContributors: 1, Organizations: []string{"jenkinsci", "kubernetes"}}
+	if !reflect.DeepEqual(prof.ReviewActivity, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, prof.ReviewActivity)
+	}
+
+	analyzer.generateInsights(prof)
+	if !slices.Contains(prof.Insights.MentorshipSigns, "Reviewed 1 pull request from first-time contributors") {
+		t.Errorf("Expected a first-time contributor mentorship sign, got %v", prof.Insights.MentorshipSigns)
+	}
+	if len(prof.Insights.MentorshipSigns) != 3 {
+		t.Errorf("Expected signs for first-timers, other contributors and organizations, got %v", prof.Insights.MentorshipSigns)
+	}
+}
+
+// orgRepositoriesFixture holds three public repositories of the "acme" organization
+const orgRepositoriesFixture = `{"data":{"organization":{"repositories":{
+	"pageInfo":{"hasNextPage":false,"endCursor":""},
+	"nodes":[
+		{"name":"api","nameWithOwner":"acme/api","stargazerCount":120,"forkCount":10,
+		 "pullRequests":{"totalCount":40},"issues":{"totalCount":5},
+		 "languages":{"nodes":[{"name":"Go"},{"name":"Python"}],"edges":[{"size":5000},{"size":1000}]},
+		 "defaultBranchRef":{"target":{"history":{"totalCount":300}}},"owner":{"login":"acme"}},
+		{"name":"cli","nameWithOwner":"acme/cli","stargazerCount":30,"forkCount":2,
+		 "pullRequests":{"totalCount":10},"issues":{"totalCount":1},
+		 "languages":{"nodes":[{"name":"Go"}],"edges":[{"size":2000}]},
+		 "defaultBranchRef":{"target":{"history":{"totalCount":100}}},"owner":{"login":"acme"}},
+		{"name":"scripts","nameWithOwner":"acme/scripts","stargazerCount":5,"forkCount":0,
+		 "languages":{"nodes":[{"name":"Python"}],"edges":[{"size":2000}]},
+		 "owner":{"login":"acme"}}
+	]}}}}`
+
+// newOrganizationServer serves the organization profile and repository fixtures, with empty repository contents
+func newOrganizationServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/repos/") {
+			w.Write([]byte(`[]`))
+			return
+		}
+
+		var req github.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode GraphQL request: %v", err)
+		}
+		if req.Variables["login"] != "acme" {
+			w.Write([]byte(`{"data":{"organization":null},"errors":[{"type":"NOT_FOUND","path":["organization"],"message":"Could not resolve to an Organization."}]}`))
+			return
+		}
+		if req.Query == github.OrganizationProfileQuery {
+			w.Write([]byte(`{"data":{"organization":{"login":"acme","name":"Acme Corp","description":"Tools for everyone",
+				"membersWithRole":{"totalCount":12},"repositories":{"totalCount":3}}}}`))
+			return
+		}
+		w.Write([]byte(orgRepositoriesFixture))
+	}))
+}
+
+// TestAnalyzeOrganization verifies language percentages and contribution totals are aggregated across the
+// organization's repositories
+func TestAnalyzeOrganization(t *testing.T) {
+	server := newOrganizationServer(t)
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+
+	prof, err := analyzer.AnalyzeOrganization(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("AnalyzeOrganization failed: %v", err)
+	}
+
+	if !prof.IsOrganization || prof.Name != "Acme Corp" || prof.Bio != "Tools for everyone" || prof.MemberCount != 12 {
+		t.Errorf("Unexpected organization details: %+v", prof)
+	}
+	if len(prof.Repositories) != 3 {
+		t.Fatalf("Expected 3 repositories, got %d", len(prof.Repositories))
+	}
+	if prof.Contributions.TotalCommits != 400 || prof.Contributions.TotalPullRequests != 50 || prof.Contributions.TotalIssues != 6 {
+		t.Errorf("Expected 400 commits, 50 pull requests and 6 issues, got %+v", prof.Contributions)
+	}
+
+	// Go has 7000 of the 10000 bytes, Python the remaining 3000
+	expected := map[string]float64{"Go": 70, "Python": 30}
+	if len(prof.Languages) != len(expected) {
+		t.Fatalf("Expected %d languages, got %+v", len(expected), prof.Languages)
+	}
+	for _, lang := range prof.Languages {
+		if math.Abs(lang.Percentage-expected[lang.Language]) > 0.01 {
+			t.Errorf("Expected %s at %.0f%%, got %.2f%%", lang.Language, expected[lang.Language], lang.Percentage)
+		}
+	}
+}
+
+// TestAnalyzeOrganizationNotFound verifies an unknown organization maps to ErrOrganizationNotFound
+func TestAnalyzeOrganizationNotFound(t *testing.T) {
+	server := newOrganizationServer(t)
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+
+	_, err := analyzer.AnalyzeOrganization(context.Background(), "nobody-here")
+	if !errors.Is(err, github.ErrOrganizationNotFound) {
+		t.Errorf("Expected ErrOrganizationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package profile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SetAnonymize strips personal information from analyzed profiles before they are cached and returned
+func (a *Analyzer) SetAnonymize(enabled bool) {
+	a.anonymize = enabled
+}
+
+// Anonymize removes personally identifiable information from profile so it can be shared publicly.
+// Email, Twitter username, avatar and location are blanked, and names (including community display names)
+// are replaced by a pseudonym derived from the username so repeated runs stay consistent.
+// Repository names and statistics are kept.
+func Anonymize(profile *UserProfile) {
+	pseudonym := anonymousName(profile.Username)
+
+	if profile.Name != "" {
+		profile.Name = pseudonym
+	}
+	profile.Email = ""
+	profile.TwitterUsername = ""
+	profile.AvatarURL = ""
+	profile.Location = ""
+
+	if profile.DiscourseProfile != nil && profile.DiscourseProfile.DisplayName != "" {
+		profile.DiscourseProfile.DisplayName = pseudonym
+	}
+	if profile.StackOverflowProfile != nil && profile.StackOverflowProfile.DisplayName != "" {
+		profile.StackOverflowProfile.DisplayName = pseudonym
+	}
+
+	profile.Anonymized = true
+}
+
+// anonymousName returns a stable pseudonym for username
+func anonymousName(username string) string {
+	sum := sha256.Sum256([]byte(username))
+	return "Developer " + hex.EncodeToString(sum[:4])
+}
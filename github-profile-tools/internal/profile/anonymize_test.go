@@ -0,0 +1,54 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAnonymize verifies personal fields are stripped while repositories are kept
+func TestAnonymize(t *testing.T) {
+	profile := &UserProfile{
+		Username:         "testuser",
+		Name:             "Test User",
+		Email:            "test.user@example.com",
+		TwitterUsername:  "testuser_tweets",
+		Location:         "Springfield",
+		Repositories:     []RepositoryProfile{{FullName: "testuser/tool", Stars: 42}},
+		DiscourseProfile: &DiscourseProfile{DisplayName: "Test User"},
+	}
+
+	Anonymize(profile)
+
+	if profile.Email != "" || profile.TwitterUsername != "" || profile.Location != "" {
+		t.Errorf("Expected contact details to be blanked, got %+v", profile)
+	}
+	if profile.Name == "Test User" || profile.Name != anonymousName("testuser") {
+		t.Errorf("Expected name to be replaced by a stable pseudonym, got %q", profile.Name)
+	}
+	if profile.DiscourseProfile.DisplayName != profile.Name {
+		t.Errorf("Expected Discourse display name to use the pseudonym, got %q", profile.DiscourseProfile.DisplayName)
+	}
+	if len(profile.Repositories) != 1 || profile.Repositories[0].Stars != 42 {
+		t.Errorf("Expected repositories to be kept, got %+v", profile.Repositories)
+	}
+	if !profile.Anonymized {
+		t.Error("Expected profile to be marked as anonymized")
+	}
+}
+
+// TestAnonymizedCacheNotReusedForRegularRun verifies an anonymized cache is re-analyzed without -anonymize
+func TestAnonymizedCacheNotReusedForRegularRun(t *testing.T) {
+	analyzer := &Analyzer{cacheDir: t.TempDir(), analysisMaxAge: DefaultAnalysisMaxAge, anonymize: true}
+	if err := analyzer.saveToCache("testuser", &UserProfile{Username: "testuser", LastAnalyzed: time.Now(), Anonymized: true}); err != nil {
+		t.Fatalf("saveToCache failed: %v", err)
+	}
+
+	if analyzer.tryLoadFromCache("testuser") == nil {
+		t.Error("Expected anonymized cache to be reused by an anonymized run")
+	}
+
+	analyzer.SetAnonymize(false)
+	if analyzer.tryLoadFromCache("testuser") != nil {
+		t.Error("Expected anonymized cache to be ignored by a regular run")
+	}
+}
@@ -0,0 +1,52 @@
+package profile
+
+import "strings"
+
+// botSuffix marks GitHub App accounts such as "github-actions[bot]", which are always treated as bots
+const botSuffix = "[bot]"
+
+// DefaultBotLogins are the automation accounts left out of contributor and review aggregation
+var DefaultBotLogins = []string{
+	"dependabot",
+	"dependabot-preview",
+	"github-actions",
+	"mergify",
+	"renovate",
+	"renovate-bot",
+	"codecov",
+	"imgbot",
+	"allcontributors",
+	"snyk-bot",
+}
+
+// SetBotLogins sets the accounts left out of contributor and review aggregation, in addition to any
+// login ending in "[bot]". Nil restores DefaultBotLogins.
+func (a *Analyzer) SetBotLogins(logins []string) {
+	if logins == nil {
+		a.botLogins = nil
+		return
+	}
+
+	a.botLogins = make(map[string]bool, len(logins))
+	for _, login := range logins {
+		a.botLogins[strings.ToLower(login)] = true
+	}
+}
+
+// isBot reports whether login is an automation account rather than a person
+func (a *Analyzer) isBot(login string) bool {
+	login = strings.ToLower(login)
+	if strings.HasSuffix(login, botSuffix) {
+		return true
+	}
+
+	if a.botLogins == nil {
+		for _, bot := range DefaultBotLogins {
+			if login == bot {
+				return true
+			}
+		}
+		return false
+	}
+	return a.botLogins[login]
+}
@@ -17,8 +17,32 @@ type ProfileCacheManager struct {
 	forceRefresh bool
 }
 
-// NewProfileCacheManager creates a new profile cache manager
+// profileCacheFormatVersion is the version of the cache entry format used for profiles
+const profileCacheFormatVersion = "1.0"
+
+// DockerHubCacheTTL is how long a Docker Hub analysis is reused, download counts changing slowly
+const DockerHubCacheTTL = 12 * time.Hour
+
+// dockerHubCacheEntry wraps a Docker Hub analysis so that users without images are cached too
+type dockerHubCacheEntry struct {
+	Profile *DockerHubProfile `json:"profile"`
+}
+
+// NewProfileCacheManager creates a new profile cache manager in the default namespace
 func NewProfileCacheManager(cacheDir string, forceRefresh bool) (*ProfileCacheManager, error) {
+	return NewProfileCacheManagerWithNamespace(cacheDir, "", forceRefresh)
+}
+
+// NewProfileCacheManagerWithNamespace creates a profile cache manager whose entries are kept apart
+// from other namespaces. Entries are versioned with the profile schema, so bumping
+// ProfileSchemaVersion invalidates them.
+func NewProfileCacheManagerWithNamespace(cacheDir, namespace string, forceRefresh bool) (*ProfileCacheManager, error) {
+	return NewProfileCacheManagerWithBackend(cacheDir, namespace, cache.BackendFile, forceRefresh)
+}
+
+// NewProfileCacheManagerWithBackend creates a profile cache manager on the given storage backend.
+// cache.BackendMemory keeps entries for the lifetime of the process only and ignores cacheDir.
+func NewProfileCacheManagerWithBackend(cacheDir, namespace, backend string, forceRefresh bool) (*ProfileCacheManager, error) {
 	config := &cache.CacheConfig{
 		BaseDir:           cacheDir,
 		DefaultTTL:        24 * time.Hour, // 24 hours default
@@ -26,7 +50,9 @@ func NewProfileCacheManager(cacheDir string, forceRefresh bool) (*ProfileCacheMa
 		MaxEntries:        0,               // Unlimited
 		CleanupInterval:   1 * time.Hour,
 		EnableCompression: true,
-		Version:           "1.0",
+		Version:           profileCacheFormatVersion + "+schema" + ProfileSchemaVersion,
+		Namespace:         namespace,
+		Backend:           backend,
 	}
 
 	manager, err := cache.NewManager(config)
@@ -89,6 +115,12 @@ func (pcm *ProfileCacheManager) GetUserProfileWithCustomUsernames(username, dock
 		return nil, false
 	}
 
+	if profile.SchemaVersion != ProfileSchemaVersion {
+		log.Printf("Cached profile for user %s has schema version %q (want %q), ignoring cached entry",
+			username, profile.SchemaVersion, ProfileSchemaVersion)
+		return nil, false
+	}
+
 	log.Printf("Cache HIT for user profile: %s (scope: %s, age: %s)", username, scope, time.Since(result.CreatedAt))
 	return &profile, true
 }
@@ -127,6 +159,7 @@ func (pcm *ProfileCacheManager) SetUserProfileWithCustomUsernames(username, dock
 		key = pcm.cacheManager.GetUserProfileKey(username)
 	}
 
+	profile.SchemaVersion = ProfileSchemaVersion
 	log.Printf("SetUserProfile: Calling Set with key: %s", key.String())
 	err := pcm.cacheManager.Set(key, profile, 0) // Use default TTL
 
@@ -369,6 +402,54 @@ func (pcm *ProfileCacheManager) SetUserSkills(username string, skills *SkillProf
 	return err
 }
 
+// GetDockerHubProfile attempts to retrieve a Docker Hub analysis from cache. A hit with a nil
+// profile means the user was found to have no public images.
+func (pcm *ProfileCacheManager) GetDockerHubProfile(dockerUsername string) (*DockerHubProfile, bool) {
+	if !pcm.isEnabled || pcm.forceRefresh {
+		return nil, false
+	}
+
+	key := pcm.cacheManager.GetDockerHubProfileKey(dockerUsername)
+	result, err := pcm.cacheManager.Get(key)
+
+	if err != nil || !result.Hit {
+		return nil, false
+	}
+
+	// Convert the generic interface{} to the specific type
+	jsonData, err := json.Marshal(result.Data)
+	if err != nil {
+		log.Printf("Cache corruption detected for Docker Hub profile %s (marshal failed), ignoring cached entry: %v", dockerUsername, err)
+		return nil, false
+	}
+
+	var entry dockerHubCacheEntry
+	if err := json.Unmarshal(jsonData, &entry); err != nil {
+		log.Printf("Cache corruption detected for Docker Hub profile %s (unmarshal failed), ignoring cached entry: %v", dockerUsername, err)
+		return nil, false
+	}
+
+	log.Printf("Cache HIT for Docker Hub profile: %s", dockerUsername)
+	return entry.Profile, true
+}
+
+// SetDockerHubProfile stores a Docker Hub analysis in cache for DockerHubCacheTTL; nil records
+// that the user has no public images
+func (pcm *ProfileCacheManager) SetDockerHubProfile(dockerUsername string, profile *DockerHubProfile) error {
+	if !pcm.isEnabled {
+		return nil
+	}
+
+	key := pcm.cacheManager.GetDockerHubProfileKey(dockerUsername)
+	err := pcm.cacheManager.Set(key, dockerHubCacheEntry{Profile: profile}, DockerHubCacheTTL)
+
+	if err == nil {
+		log.Printf("Cache SET for Docker Hub profile: %s", dockerUsername)
+	}
+
+	return err
+}
+
 // InvalidateUser removes all cached data for a user
 func (pcm *ProfileCacheManager) InvalidateUser(username string) error {
 	if !pcm.isEnabled {
@@ -445,6 +526,11 @@ func (pcm *ProfileCacheManager) Cleanup() error {
 	return pcm.cacheManager.Cleanup()
 }
 
+// Close stops the cache's background work, waiting for pending writes
+func (pcm *ProfileCacheManager) Close() error {
+	return pcm.cacheManager.Close()
+}
+
 // Clear removes all cache entries
 func (pcm *ProfileCacheManager) Clear() error {
 	if !pcm.isEnabled {
@@ -463,11 +549,24 @@ type CacheAwareAnalyzer struct {
 
 // WrapWithCache wraps an existing analyzer with caching capabilities
 func WrapWithCache(analyzer *Analyzer, cacheDir string, forceRefresh bool) (*CacheAwareAnalyzer, error) {
-	cacheManager, err := NewProfileCacheManager(cacheDir, forceRefresh)
+	return WrapWithCacheNamespace(analyzer, cacheDir, "", forceRefresh)
+}
+
+// WrapWithCacheNamespace wraps an existing analyzer with a cache kept in its own namespace
+func WrapWithCacheNamespace(analyzer *Analyzer, cacheDir, namespace string, forceRefresh bool) (*CacheAwareAnalyzer, error) {
+	return WrapWithCacheBackend(analyzer, cacheDir, namespace, cache.BackendFile, forceRefresh)
+}
+
+// WrapWithCacheBackend wraps an existing analyzer with a cache on the given storage backend
+func WrapWithCacheBackend(analyzer *Analyzer, cacheDir, namespace, backend string, forceRefresh bool) (*CacheAwareAnalyzer, error) {
+	cacheManager, err := NewProfileCacheManagerWithBackend(cacheDir, namespace, backend, forceRefresh)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cache manager: %w", err)
 	}
 
+	// The Docker Hub step reuses cached results on its own, even when the profile is analyzed afresh
+	analyzer.profileCache = cacheManager
+
 	return &CacheAwareAnalyzer{
 		Analyzer:     analyzer,
 		cacheManager: cacheManager,
@@ -504,7 +603,8 @@ func (caa *CacheAwareAnalyzer) AnalyzeUserWithCustomUsernames(ctx context.Contex
 	log.Printf("Performing fresh analysis for user: %s", username)
 	profile, err := caa.Analyzer.AnalyzeUserWithCustomUsernames(ctx, username, dockerUsername, discourseUsername)
 	if err != nil {
-		return nil, err
+		// A partial profile is returned along with ErrPartialAnalysis so it can still be rendered
+		return profile, err
 	}
 
 	// Cache the complete profile
@@ -2,11 +2,16 @@ package profile
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/cache"
+	"github.com/jenkins/github-profile-tools/internal/docker"
 )
 
 // setupTestProfileCache creates a temporary profile cache for testing
@@ -27,9 +32,12 @@ func setupTestProfileCache(t *testing.T) (*ProfileCacheManager, string) {
 	return pcm, tempDir
 }
 
-// cleanupTestProfileCache removes the temporary cache
-func cleanupTestProfileCache(t *testing.T, tempDir string) {
+// cleanupTestProfileCache closes the cache, waiting for its background writes, and removes it
+func cleanupTestProfileCache(t *testing.T, pcm *ProfileCacheManager, tempDir string) {
 	t.Helper()
+	if err := pcm.Close(); err != nil {
+		t.Errorf("Failed to close profile cache: %v", err)
+	}
 	if err := os.RemoveAll(tempDir); err != nil {
 		t.Errorf("Failed to cleanup temp dir: %v", err)
 	}
@@ -50,14 +58,8 @@ func createSampleUserProfile() *UserProfile {
 		CreatedAt:    time.Now().Add(-365 * 24 * time.Hour), // 1 year ago
 		UpdatedAt:    time.Now(),
 		PublicGists:  5,
-		Hireable:     true,
-		Blog:         "https://testuser.blog",
+		BlogURL:      "https://testuser.blog",
 		TwitterUsername: "testuser",
-		GravatarID:   "test123",
-		AvatarURL:    "https://avatar.test/testuser",
-		HTMLURL:      "https://github.com/testuser",
-		Type:         "User",
-		SiteAdmin:    false,
 	}
 }
 
@@ -68,30 +70,28 @@ func createSampleRepositories() []RepositoryProfile {
 			Name:        "test-repo-1",
 			FullName:    "testuser/test-repo-1",
 			Description: "A test repository",
-			Private:     false,
-			Fork:        false,
+			IsPrivate:   false,
+			IsFork:      false,
 			CreatedAt:   time.Now().Add(-30 * 24 * time.Hour),
 			UpdatedAt:   time.Now().Add(-1 * 24 * time.Hour),
 			PushedAt:    time.Now(),
 			Size:        1024,
 			Language:    "Go",
-			Archived:    false,
-			Disabled:    false,
+			IsArchived:  false,
 			Topics:      []string{"test", "go", "example"},
 		},
 		{
 			Name:        "test-repo-2",
 			FullName:    "testuser/test-repo-2",
 			Description: "Another test repository",
-			Private:     true,
-			Fork:        true,
+			IsPrivate:   true,
+			IsFork:      true,
 			CreatedAt:   time.Now().Add(-60 * 24 * time.Hour),
 			UpdatedAt:   time.Now().Add(-2 * 24 * time.Hour),
 			PushedAt:    time.Now().Add(-1 * time.Hour),
 			Size:        2048,
 			Language:    "Python",
-			Archived:    false,
-			Disabled:    false,
+			IsArchived:  false,
 			Topics:      []string{"test", "python", "fork"},
 		},
 	}
@@ -100,7 +100,7 @@ func createSampleRepositories() []RepositoryProfile {
 // TestProfileCacheBasicOperations tests basic profile cache operations
 func TestProfileCacheBasicOperations(t *testing.T) {
 	pcm, tempDir := setupTestProfileCache(t)
-	defer cleanupTestProfileCache(t, tempDir)
+	defer cleanupTestProfileCache(t, pcm, tempDir)
 
 	username := "testuser"
 	profile := createSampleUserProfile()
@@ -129,16 +129,23 @@ func TestProfileCacheBasicOperations(t *testing.T) {
 		t.Fatal("Expected non-nil profile on cache hit")
 	}
 
-	// Verify profile data integrity
-	if !reflect.DeepEqual(cachedProfile, profile) {
-		t.Errorf("Cached profile does not match original profile. Got %+v, want %+v", cachedProfile, profile)
+	// Verify profile data integrity. Times lose their monotonic clock reading in the cache,
+	// so they are compared with Equal and the rest of the profile without them.
+	if !cachedProfile.CreatedAt.Equal(profile.CreatedAt) || !cachedProfile.UpdatedAt.Equal(profile.UpdatedAt) {
+		t.Errorf("Cached profile times do not match: got %v/%v, want %v/%v",
+			cachedProfile.CreatedAt, cachedProfile.UpdatedAt, profile.CreatedAt, profile.UpdatedAt)
+	}
+	want := *profile
+	want.CreatedAt, want.UpdatedAt = cachedProfile.CreatedAt, cachedProfile.UpdatedAt
+	if !reflect.DeepEqual(cachedProfile, &want) {
+		t.Errorf("Cached profile does not match original profile. Got %+v, want %+v", cachedProfile, &want)
 	}
 }
 
 // TestRepositoryCaching tests repository caching functionality
 func TestRepositoryCaching(t *testing.T) {
 	pcm, tempDir := setupTestProfileCache(t)
-	defer cleanupTestProfileCache(t, tempDir)
+	defer cleanupTestProfileCache(t, pcm, tempDir)
 
 	username := "testuser"
 	repos := createSampleRepositories()
@@ -199,7 +206,6 @@ func TestCacheAwareAnalyzerIntegration(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
-	defer cleanupTestProfileCache(t, tempDir)
 
 	// Create a real analyzer (though we won't actually call GitHub APIs in this test)
 	realAnalyzer := NewAnalyzer("test-token")
@@ -209,6 +215,7 @@ func TestCacheAwareAnalyzerIntegration(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create cache-aware analyzer: %v", err)
 	}
+	defer cleanupTestProfileCache(t, cacheAnalyzer.GetCacheManager(), tempDir)
 
 	// Verify the cache manager is properly integrated
 	cacheManager := cacheAnalyzer.GetCacheManager()
@@ -244,7 +251,6 @@ func TestCacheAwareAnalyzerForceRefresh(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
-	defer cleanupTestProfileCache(t, tempDir)
 
 	// Test with force refresh enabled
 	realAnalyzer := NewAnalyzer("test-token")
@@ -252,6 +258,7 @@ func TestCacheAwareAnalyzerForceRefresh(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create cache-aware analyzer with force refresh: %v", err)
 	}
+	defer cleanupTestProfileCache(t, cacheAnalyzer.GetCacheManager(), tempDir)
 
 	// Verify cache manager has force refresh enabled by testing cache behavior
 	cacheManager := cacheAnalyzer.GetCacheManager()
@@ -275,7 +282,7 @@ func TestCacheAwareAnalyzerForceRefresh(t *testing.T) {
 // TestCacheInvalidation tests cache invalidation functionality
 func TestCacheInvalidation(t *testing.T) {
 	pcm, tempDir := setupTestProfileCache(t)
-	defer cleanupTestProfileCache(t, tempDir)
+	defer cleanupTestProfileCache(t, pcm, tempDir)
 
 	users := []string{"user1", "user2", "user3"}
 
@@ -333,7 +340,7 @@ func TestCacheInvalidation(t *testing.T) {
 // TestCacheCorruption tests handling of corrupted cache data
 func TestCacheCorruption(t *testing.T) {
 	pcm, tempDir := setupTestProfileCache(t)
-	defer cleanupTestProfileCache(t, tempDir)
+	defer cleanupTestProfileCache(t, pcm, tempDir)
 
 	username := "corruptuser"
 	profile := createSampleUserProfile()
@@ -345,25 +352,96 @@ func TestCacheCorruption(t *testing.T) {
 		t.Fatalf("Failed to set profile: %v", err)
 	}
 
-	// Verify it's cached
-	_, hit := pcm.GetUserProfile(username)
-	if !hit {
-		t.Error("Expected cache hit for valid data")
+	// Overwrite the cache file with invalid data to simulate corruption. This happens before
+	// any read, as a hit rewrites the entry in the background.
+	cachePaths, err := filepath.Glob(filepath.Join(tempDir, "profiles", "profile_"+username+"*"))
+	if err != nil || len(cachePaths) != 1 {
+		t.Fatalf("Expected one cache file for %s, got %v (%v)", username, cachePaths, err)
 	}
-
-	key := pcm.cacheManager.GetUserProfileKey(username)
-
-	// Manually overwrite cache file with invalid JSON to simulate corruption
-	cachePath := filepath.Join(tempDir, "user_profile_"+username+".json")
-	err = os.WriteFile(cachePath, []byte("invalid json {{{"), 0644)
+	err = os.WriteFile(cachePaths[0], []byte("invalid json {{{"), 0644)
 	if err != nil {
 		t.Fatalf("Failed to write corrupt data: %v", err)
 	}
 
 	// Verify graceful handling of corrupted data (should return cache miss)
-	_, hit = pcm.GetUserProfile(username)
+	_, hit := pcm.GetUserProfile(username)
 	if hit {
 		t.Error("Expected cache miss for corrupted data")
 	}
+
+	// Setting the profile again replaces the corrupted entry
+	if err := pcm.SetUserProfile(username, profile); err != nil {
+		t.Fatalf("Failed to set profile: %v", err)
+	}
+	if _, hit := pcm.GetUserProfile(username); !hit {
+		t.Error("Expected cache hit after replacing corrupted data")
+	}
 }
 
+
+// TestMemoryBackendProfileCache verifies the profile cache works unchanged on the in-memory backend
+// and writes nothing to the cache directory
+func TestMemoryBackendProfileCache(t *testing.T) {
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	pcm, err := NewProfileCacheManagerWithBackend(cacheDir, "", cache.BackendMemory, false)
+	if err != nil {
+		t.Fatalf("Failed to create memory profile cache: %v", err)
+	}
+
+	profile := createSampleUserProfile()
+	if err := pcm.SetUserProfile("testuser", profile); err != nil {
+		t.Fatalf("Failed to set user profile: %v", err)
+	}
+	cachedProfile, hit := pcm.GetUserProfile("testuser")
+	if !hit || cachedProfile.Username != profile.Username || cachedProfile.Followers != profile.Followers {
+		t.Errorf("Expected the cached profile back, got hit=%v %+v", hit, cachedProfile)
+	}
+
+	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
+		t.Errorf("Expected no cache directory to be created, got %v", err)
+	}
+}
+
+// TestDockerHubProfileCached verifies a second analysis within the TTL skips the Docker Hub fetch
+func TestDockerHubProfileCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repositories/octocat/":
+			w.Write([]byte(`{"count":1,"next":"","results":[{"name":"app","namespace":"octocat","pull_count":1000}]}`))
+		case "/repositories/octocat/app":
+			w.Write([]byte(`{"name":"app","namespace":"octocat","pull_count":1000,"star_count":5}`))
+		default:
+			w.Write([]byte(`{"results":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	analyzer := &Analyzer{dockerClient: docker.NewClient().WithBaseURL(server.URL)}
+	if _, err := WrapWithCacheBackend(analyzer, t.TempDir(), "", cache.BackendMemory, false); err != nil {
+		t.Fatalf("Failed to wrap analyzer: %v", err)
+	}
+
+	first := &UserProfile{}
+	if err := analyzer.analyzeDockerHub(context.Background(), "octocat", first); err != nil {
+		t.Fatalf("First analysis failed: %v", err)
+	}
+	if first.DockerHubProfile == nil || first.DockerHubProfile.TotalDownloads != 1000 {
+		t.Fatalf("Expected 1000 downloads, got %+v", first.DockerHubProfile)
+	}
+	fetched := requests
+
+	second := &UserProfile{}
+	if err := analyzer.analyzeDockerHub(context.Background(), "octocat", second); err != nil {
+		t.Fatalf("Second analysis failed: %v", err)
+	}
+	if requests != fetched {
+		t.Errorf("Expected no Docker Hub requests on the second analysis, got %d", requests-fetched)
+	}
+	if !reflect.DeepEqual(second.DockerHubProfile.TopRepositories, first.DockerHubProfile.TopRepositories) ||
+		second.DockerHubProfile.TotalDownloads != first.DockerHubProfile.TotalDownloads {
+		t.Errorf("Expected cached profile %+v, got %+v", first.DockerHubProfile, second.DockerHubProfile)
+	}
+}
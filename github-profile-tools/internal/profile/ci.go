@@ -0,0 +1,153 @@
+package profile
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+// Technical areas derived from the CI and test setup of repositories
+const (
+	ciArea      = "CI/CD"
+	testingArea = "Testing"
+
+	// maturityCompetencyPerRepo is the competency each repository with a CI or test setup adds, up to 1
+	maturityCompetencyPerRepo = 0.2
+)
+
+// ciSystemFiles maps root entries (lowercased) to the CI system they configure.
+// GitHub Actions lives in .github/workflows and is detected separately.
+var ciSystemFiles = map[string]string{
+	"jenkinsfile":             "Jenkins",
+	".circleci":               "CircleCI",
+	".travis.yml":             "Travis CI",
+	".gitlab-ci.yml":          "GitLab CI",
+	"azure-pipelines.yml":     "Azure Pipelines",
+	"bitbucket-pipelines.yml": "Bitbucket Pipelines",
+}
+
+// testFrameworkFiles maps root entries (lowercased) to the test tooling they indicate
+var testFrameworkFiles = map[string]string{
+	"pytest.ini":       "pytest",
+	"conftest.py":      "pytest",
+	"tox.ini":          "tox",
+	"jest.config.js":   "Jest",
+	"jest.config.ts":   "Jest",
+	"karma.conf.js":    "Karma",
+	"phpunit.xml":      "PHPUnit",
+	"phpunit.xml.dist": "PHPUnit",
+	".rspec":           "RSpec",
+}
+
+// testDirectories are root directories that conventionally hold a test suite
+var testDirectories = map[string]bool{
+	"test":      true,
+	"tests":     true,
+	"spec":      true,
+	"__tests__": true,
+}
+
+// coverageFiles are root entries (lowercased) that configure coverage reporting
+var coverageFiles = map[string]bool{
+	"codecov.yml":              true,
+	".codecov.yml":             true,
+	".coveragerc":              true,
+	".coveralls.yml":           true,
+	".nycrc":                   true,
+	".nycrc.json":              true,
+	"sonar-project.properties": true,
+}
+
+// analyzeCIConfig detects CI systems, test tooling and coverage configuration from repository
+// root contents. The .github directory is only listed when present, to look for workflows.
+func (a *Analyzer) analyzeCIConfig(ctx context.Context, owner, repo string, contents []github.RepositoryContentResponse) *CIConfig {
+	config := &CIConfig{}
+	var hasGitHubDir bool
+
+	for _, item := range contents {
+		name := strings.ToLower(item.Name)
+
+		if system, ok := ciSystemFiles[name]; ok {
+			config.CISystems = appendUnique(config.CISystems, system)
+		}
+		if framework, ok := testFrameworkFiles[name]; ok {
+			config.TestFrameworks = appendUnique(config.TestFrameworks, framework)
+		}
+		if coverageFiles[name] {
+			config.HasCoverage = true
+		}
+
+		switch {
+		case name == ".github" && item.Type == "dir":
+			hasGitHubDir = true
+		case item.Type == "file" && strings.HasSuffix(name, "_test.go"):
+			config.TestFrameworks = appendUnique(config.TestFrameworks, "Go test")
+		case item.Type == "dir" && testDirectories[name]:
+			config.TestFrameworks = appendUnique(config.TestFrameworks, "Test suite")
+		}
+	}
+
+	if hasGitHubDir {
+		githubContents, err := a.client.FetchDirectoryContents(ctx, owner, repo, ".github")
+		if err != nil {
+			logging.Warnf("Failed to fetch .github contents for %s/%s: %v", owner, repo, err)
+		}
+		for _, item := range githubContents {
+			if item.Type == "dir" && strings.EqualFold(item.Name, "workflows") {
+				config.CISystems = appendUnique(config.CISystems, "GitHub Actions")
+			}
+		}
+	}
+
+	if len(config.CISystems) == 0 && len(config.TestFrameworks) == 0 && !config.HasCoverage {
+		return nil
+	}
+	return config
+}
+
+// addCIMaturityAreas adds "CI/CD" and "Testing" technical areas whose competency grows with the
+// number of repositories that have a CI system or test tooling configured
+func (a *Analyzer) addCIMaturityAreas(repos []RepositoryProfile, skills *SkillProfile) {
+	ci := TechnicalArea{Area: ciArea}
+	tests := TechnicalArea{Area: testingArea}
+
+	for _, repo := range repos {
+		if repo.CIConfig == nil {
+			continue
+		}
+		if len(repo.CIConfig.CISystems) > 0 {
+			ci.ProjectCount++
+			for _, system := range repo.CIConfig.CISystems {
+				ci.Technologies = appendUnique(ci.Technologies, system)
+			}
+		}
+		if len(repo.CIConfig.TestFrameworks) > 0 || repo.CIConfig.HasCoverage {
+			tests.ProjectCount++
+			for _, framework := range repo.CIConfig.TestFrameworks {
+				tests.Technologies = appendUnique(tests.Technologies, framework)
+			}
+		}
+	}
+
+	for _, area := range []TechnicalArea{ci, tests} {
+		if area.ProjectCount == 0 {
+			continue
+		}
+		area.Competency = min(1, float64(area.ProjectCount)*maturityCompetencyPerRepo)
+		sort.Strings(area.Technologies)
+		skills.TechnicalAreas = append(skills.TechnicalAreas, area)
+	}
+}
+
+// appendUnique appends value to values unless it is already present
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,79 @@
+package profile
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	maintainerCommitShare = 0.25 // share of the user's commits a repository needs for a maintainer role
+	maintainerMinCommits  = 10   // commits below which a repository never makes a maintainer role
+	highImpactStars       = 1000 // stars of a high impact collaboration
+	mediumImpactStars     = 100  // stars of a medium impact collaboration
+)
+
+// Collaboration types of CollaborationProfile
+const (
+	collaborationMaintainer  = "maintainer"
+	collaborationReviewer    = "reviewer"
+	collaborationContributor = "contributor"
+)
+
+// calculateCollaborations describes the user's work on repositories owned by others, forks excluded,
+// highest impact first. A repository taking a large share of the user's commits makes a maintainer,
+// reviews in its owner's repositories a reviewer, and anything else a contributor.
+func calculateCollaborations(profile *UserProfile) []CollaborationProfile {
+	totalCommits := 0
+	for _, repo := range profile.Repositories {
+		totalCommits += repo.ContributionStats.Commits
+	}
+
+	reviewedOwners := make(map[string]bool)
+	if profile.ReviewActivity != nil {
+		for _, owner := range profile.ReviewActivity.Organizations {
+			reviewedOwners[strings.ToLower(owner)] = true
+		}
+	}
+
+	var repos []RepositoryProfile
+	for _, repo := range profile.Repositories {
+		if !repo.IsOwner && !repo.IsFork {
+			repos = append(repos, repo)
+		}
+	}
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].Stars > repos[j].Stars
+	})
+
+	collaborations := []CollaborationProfile{}
+	for _, repo := range repos {
+		stats := repo.ContributionStats
+		owner, _, _ := strings.Cut(repo.FullName, "/")
+
+		collaborationType := collaborationContributor
+		switch {
+		case stats.Commits >= maintainerMinCommits && float64(stats.Commits) >= maintainerCommitShare*float64(totalCommits):
+			collaborationType = collaborationMaintainer
+		case stats.CodeReviews > 0 || reviewedOwners[strings.ToLower(owner)]:
+			collaborationType = collaborationReviewer
+		}
+
+		impactLevel := "low"
+		if repo.Stars >= highImpactStars {
+			impactLevel = "high"
+		} else if repo.Stars >= mediumImpactStars {
+			impactLevel = "medium"
+		}
+
+		collaborations = append(collaborations, CollaborationProfile{
+			Repository:        repo.FullName,
+			Collaborators:     []string{},
+			CollaborationType: collaborationType,
+			ImpactLevel:       impactLevel,
+			StartDate:         stats.FirstCommit,
+			EndDate:           stats.LastCommit,
+		})
+	}
+
+	return collaborations
+}
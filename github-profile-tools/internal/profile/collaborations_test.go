@@ -0,0 +1,36 @@
+package profile
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCalculateCollaborations verifies contributed repositories become collaborations typed by commit
+// share and reviews, while owned repositories and forks are left out
+func TestCalculateCollaborations(t *testing.T) {
+	prof := &UserProfile{
+		Repositories: []RepositoryProfile{
+			{FullName: "octocat/hello-world", IsOwner: true, ContributionStats: ContributionStats{Commits: 40}},
+			{FullName: "jenkinsci/git-plugin", Stars: 1500, ContributionStats: ContributionStats{Commits: 50}},
+			{FullName: "kubernetes/website", Stars: 300, ContributionStats: ContributionStats{Commits: 2}},
+			{FullName: "acme/widget", Stars: 5, ContributionStats: ContributionStats{Commits: 8}},
+			{FullName: "octocat/linux", IsFork: true, Stars: 9000},
+		},
+		ReviewActivity: &ReviewActivity{Organizations: []string{"kubernetes"}},
+	}
+
+	collaborations := calculateCollaborations(prof)
+
+	var got [][3]string
+	for _, collaboration := range collaborations {
+		got = append(got, [3]string{collaboration.Repository, collaboration.CollaborationType, collaboration.ImpactLevel})
+	}
+	expected := [][3]string{
+		{"jenkinsci/git-plugin", "maintainer", "high"},
+		{"kubernetes/website", "reviewer", "medium"},
+		{"acme/widget", "contributor", "low"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
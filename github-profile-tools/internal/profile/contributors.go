@@ -0,0 +1,82 @@
+package profile
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+const (
+	contributorsTopRepositories = 10  // owned repositories queried for contributors
+	contributorsPerRepository   = 100 // mentionable users fetched per repository
+)
+
+// SetContributorsEnabled enables fetching the contributors of the user's top owned repositories,
+// at the cost of one query per repository
+func (a *Analyzer) SetContributorsEnabled(enabled bool) {
+	a.withContributors = enabled
+}
+
+// fetchContributors records who else is active on the user's most starred owned repositories.
+// Failures are logged per repository and leave its contributors empty.
+func (a *Analyzer) fetchContributors(ctx context.Context, username string, profile *UserProfile) {
+	targets := selectContributorRepositories(profile.Repositories, contributorsTopRepositories)
+	logging.Infof("Fetching contributors for %d owned repositories of user: %s", len(targets), username)
+
+	for _, i := range targets {
+		repo := &profile.Repositories[i]
+		parts := strings.SplitN(repo.FullName, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		req := &github.GraphQLRequest{
+			Query: github.RepositoryContributorsQuery,
+			Variables: map[string]interface{}{
+				"owner": parts[0],
+				"name":  parts[1],
+				"first": contributorsPerRepository,
+			},
+		}
+
+		var resp github.RepositoryContributorsResponse
+		if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
+			logging.Warnf("Failed to fetch contributors for %s: %v", repo.FullName, err)
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		repo.Contributors = nil
+		for _, user := range resp.Repository.MentionableUsers.Nodes {
+			if user.Login != "" && !strings.EqualFold(user.Login, username) && !a.isBot(user.Login) {
+				repo.Contributors = append(repo.Contributors, user.Login)
+			}
+		}
+		sort.Strings(repo.Contributors)
+	}
+}
+
+// selectContributorRepositories returns the indexes of up to limit owned, non-fork repositories,
+// most starred first
+func selectContributorRepositories(repos []RepositoryProfile, limit int) []int {
+	indexes := make([]int, 0, len(repos))
+	for i, repo := range repos {
+		if repo.IsOwner && !repo.IsFork {
+			indexes = append(indexes, i)
+		}
+	}
+
+	sort.SliceStable(indexes, func(x, y int) bool {
+		return repos[indexes[x]].Stars > repos[indexes[y]].Stars
+	})
+
+	if len(indexes) > limit {
+		indexes = indexes[:limit]
+	}
+	return indexes
+}
@@ -0,0 +1,255 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// historyDirName is the cache subdirectory holding one snapshot per completed analysis
+	historyDirName = "history"
+	// historyTimeFormat stamps snapshot filenames so they sort in analysis order
+	historyTimeFormat = "20060102T150405Z"
+	// maxHistorySnapshots is how many snapshots are kept per user; older ones are pruned
+	maxHistorySnapshots = 10
+)
+
+// ErrNoPriorSnapshot is returned by Diff when no analysis older than the cached one was kept
+var ErrNoPriorSnapshot = errors.New("no earlier analysis to compare with")
+
+// ProfileDiff reports how a profile changed between two analyses
+type ProfileDiff struct {
+	Username            string             `json:"username"`
+	From                time.Time          `json:"from"`
+	To                  time.Time          `json:"to"`
+	NewRepositories     []string           `json:"new_repositories"`
+	RemovedRepositories []string           `json:"removed_repositories"`
+	StarChanges         []StarChange       `json:"star_changes"`
+	StarsBefore         int                `json:"stars_before"`
+	StarsAfter          int                `json:"stars_after"`
+	NewLanguages        []string           `json:"new_languages"`
+	Contributions       ContributionGrowth `json:"contributions"`
+	CareerLevelBefore   string             `json:"career_level_before"`
+	CareerLevelAfter    string             `json:"career_level_after"`
+}
+
+// StarChange is the star count of a repository present in both analyses
+type StarChange struct {
+	Repository string `json:"repository"`
+	Before     int    `json:"before"`
+	After      int    `json:"after"`
+}
+
+// ContributionGrowth holds the change of each contribution total; negative values are possible when
+// the contribution window moved
+type ContributionGrowth struct {
+	Commits      int `json:"commits"`
+	PullRequests int `json:"pull_requests"`
+	Issues       int `json:"issues"`
+	CodeReviews  int `json:"code_reviews"`
+}
+
+// CareerLevelChanged reports whether the inferred career level differs between the analyses
+func (d *ProfileDiff) CareerLevelChanged() bool {
+	return d.CareerLevelBefore != d.CareerLevelAfter
+}
+
+// DiffProfiles compares an earlier analysis with a later one of the same user
+func DiffProfiles(before, after *UserProfile) *ProfileDiff {
+	diff := &ProfileDiff{
+		Username:            after.Username,
+		From:                before.LastAnalyzed,
+		To:                  after.LastAnalyzed,
+		NewRepositories:     []string{},
+		RemovedRepositories: []string{},
+		StarChanges:         []StarChange{},
+		NewLanguages:        []string{},
+		CareerLevelBefore:   before.Insights.CareerLevel,
+		CareerLevelAfter:    after.Insights.CareerLevel,
+		Contributions: ContributionGrowth{
+			Commits:      after.Contributions.TotalCommits - before.Contributions.TotalCommits,
+			PullRequests: after.Contributions.TotalPullRequests - before.Contributions.TotalPullRequests,
+			Issues:       after.Contributions.TotalIssues - before.Contributions.TotalIssues,
+			CodeReviews:  after.Contributions.TotalCodeReviews - before.Contributions.TotalCodeReviews,
+		},
+	}
+
+	beforeStars := make(map[string]int, len(before.Repositories))
+	for _, repo := range before.Repositories {
+		beforeStars[repo.FullName] = repo.Stars
+		diff.StarsBefore += repo.Stars
+	}
+
+	afterRepos := make(map[string]bool, len(after.Repositories))
+	for _, repo := range after.Repositories {
+		afterRepos[repo.FullName] = true
+		diff.StarsAfter += repo.Stars
+
+		stars, existed := beforeStars[repo.FullName]
+		switch {
+		case !existed:
+			diff.NewRepositories = append(diff.NewRepositories, repo.FullName)
+		case stars != repo.Stars:
+			diff.StarChanges = append(diff.StarChanges, StarChange{Repository: repo.FullName, Before: stars, After: repo.Stars})
+		}
+	}
+	for _, repo := range before.Repositories {
+		if !afterRepos[repo.FullName] {
+			diff.RemovedRepositories = append(diff.RemovedRepositories, repo.FullName)
+		}
+	}
+
+	beforeLanguages := make(map[string]bool, len(before.Languages))
+	for _, lang := range before.Languages {
+		beforeLanguages[lang.Language] = true
+	}
+	for _, lang := range after.Languages {
+		if !beforeLanguages[lang.Language] {
+			diff.NewLanguages = append(diff.NewLanguages, lang.Language)
+		}
+	}
+
+	sort.Strings(diff.NewRepositories)
+	sort.Strings(diff.RemovedRepositories)
+	sort.Strings(diff.NewLanguages)
+	// Largest gains first
+	sort.Slice(diff.StarChanges, func(i, j int) bool {
+		gainI := diff.StarChanges[i].After - diff.StarChanges[i].Before
+		gainJ := diff.StarChanges[j].After - diff.StarChanges[j].Before
+		if gainI != gainJ {
+			return gainI > gainJ
+		}
+		return diff.StarChanges[i].Repository < diff.StarChanges[j].Repository
+	})
+
+	return diff
+}
+
+// Diff compares the cached analysis of username with the latest snapshot taken before it
+func (a *Analyzer) Diff(username string) (*ProfileDiff, error) {
+	data, err := os.ReadFile(filepath.Join(a.cacheDir, fmt.Sprintf("%s_analysis.json", username)))
+	if err != nil {
+		return nil, fmt.Errorf("no cached analysis for %s: %w", username, err)
+	}
+
+	var current UserProfile
+	if err := json.Unmarshal(data, &current); err != nil {
+		return nil, fmt.Errorf("failed to parse cached analysis: %w", err)
+	}
+
+	previous, err := a.loadPriorSnapshot(username, current.LastAnalyzed)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffProfiles(previous, &current), nil
+}
+
+// getHistoryFilename returns the path of the history snapshot for an analysis completed at analyzedAt
+func (a *Analyzer) getHistoryFilename(username string, analyzedAt time.Time) string {
+	return filepath.Join(a.cacheDir, historyDirName, fmt.Sprintf("%s_%s.json", username, analyzedAt.UTC().Format(historyTimeFormat)))
+}
+
+// historySnapshot is a history file and the analysis time stamped in its name
+type historySnapshot struct {
+	path       string
+	analyzedAt time.Time
+}
+
+// listHistorySnapshots returns the history snapshots of username, oldest first, read from their
+// filenames alone
+func (a *Analyzer) listHistorySnapshots(username string) ([]historySnapshot, error) {
+	paths, err := filepath.Glob(filepath.Join(a.cacheDir, historyDirName, username+"_*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history snapshots: %w", err)
+	}
+
+	var snapshots []historySnapshot
+	for _, path := range paths {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), username+"_"), ".json")
+		analyzedAt, err := time.Parse(historyTimeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, historySnapshot{path: path, analyzedAt: analyzedAt})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].analyzedAt.Before(snapshots[j].analyzedAt)
+	})
+	return snapshots, nil
+}
+
+// pruneHistory removes all but the latest maxHistorySnapshots snapshots of username
+func (a *Analyzer) pruneHistory(username string) error {
+	snapshots, err := a.listHistorySnapshots(username)
+	if err != nil {
+		return err
+	}
+
+	for len(snapshots) > maxHistorySnapshots {
+		if err := os.Remove(snapshots[0].path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to prune history snapshot: %w", err)
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// saveHistorySnapshot keeps a copy of a completed analysis so later analyses can be compared with it
+func (a *Analyzer) saveHistorySnapshot(username string, profile *UserProfile) error {
+	if err := os.MkdirAll(filepath.Join(a.cacheDir, historyDirName), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	snapshot := ProfileSnapshot{
+		Username:  username,
+		Timestamp: profile.LastAnalyzed,
+		Version:   snapshotVersion,
+		Profile:   profile,
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal history snapshot: %w", err)
+	}
+
+	if err := WriteFileAtomic(a.getHistoryFilename(username, profile.LastAnalyzed), data, 0644); err != nil {
+		return err
+	}
+	return a.pruneHistory(username)
+}
+
+// loadPriorSnapshot returns the most recent history snapshot of username taken before the given time.
+// Snapshots are picked by the time in their filename, so only the chosen one is decoded.
+func (a *Analyzer) loadPriorSnapshot(username string, before time.Time) (*UserProfile, error) {
+	snapshots, err := a.listHistorySnapshots(username)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filenames keep whole seconds, so the snapshot of the analysis at before itself is skipped too
+	cutoff := before.UTC().Truncate(time.Second)
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].analyzedAt.Before(cutoff) {
+			continue
+		}
+		data, err := os.ReadFile(snapshots[i].path)
+		if err != nil {
+			continue
+		}
+		var snapshot ProfileSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil || snapshot.Profile == nil || snapshot.Username != username {
+			continue
+		}
+		return snapshot.Profile, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s", ErrNoPriorSnapshot, username)
+}
@@ -0,0 +1,151 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// diffFixtures returns two analyses of the same user a year apart with known differences
+func diffFixtures() (*UserProfile, *UserProfile) {
+	lastYear := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := &UserProfile{
+		Username:     "testuser",
+		LastAnalyzed: lastYear,
+		Repositories: []RepositoryProfile{
+			{FullName: "testuser/tool", Stars: 10},
+			{FullName: "testuser/lib", Stars: 5},
+			{FullName: "testuser/old", Stars: 1},
+		},
+		Languages:     []LanguageStats{{Language: "Go"}},
+		Contributions: ContributionSummary{TotalCommits: 100, TotalPullRequests: 20, TotalIssues: 5, TotalCodeReviews: 8},
+		Insights:      UserInsights{CareerLevel: "mid"},
+	}
+	after := &UserProfile{
+		Username:     "testuser",
+		LastAnalyzed: lastYear.AddDate(1, 0, 0),
+		Repositories: []RepositoryProfile{
+			{FullName: "testuser/tool", Stars: 25},
+			{FullName: "testuser/lib", Stars: 5},
+			{FullName: "testuser/operator", Stars: 3},
+		},
+		Languages:     []LanguageStats{{Language: "Go"}, {Language: "Rust"}},
+		Contributions: ContributionSummary{TotalCommits: 250, TotalPullRequests: 32, TotalIssues: 5, TotalCodeReviews: 20},
+		Insights:      UserInsights{CareerLevel: "senior"},
+	}
+	return before, after
+}
+
+// TestDiffProfiles verifies new repositories, star changes, new languages, contribution growth and the
+// career level change are reported
+func TestDiffProfiles(t *testing.T) {
+	before, after := diffFixtures()
+	diff := DiffProfiles(before, after)
+
+	if !reflect.DeepEqual(diff.NewRepositories, []string{"testuser/operator"}) {
+		t.Errorf("Expected testuser/operator as new repository, got %v", diff.NewRepositories)
+	}
+	if !reflect.DeepEqual(diff.RemovedRepositories, []string{"testuser/old"}) {
+		t.Errorf("Expected testuser/old as removed repository, got %v", diff.RemovedRepositories)
+	}
+	if !reflect.DeepEqual(diff.StarChanges, []StarChange{{Repository: "testuser/tool", Before: 10, After: 25}}) {
+		t.Errorf("Expected only testuser/tool to change stars, got %+v", diff.StarChanges)
+	}
+	if diff.StarsBefore != 16 || diff.StarsAfter != 33 {
+		t.Errorf("Expected stars to go from 16 to 33, got %d to %d", diff.StarsBefore, diff.StarsAfter)
+	}
+	if !reflect.DeepEqual(diff.NewLanguages, []string{"Rust"}) {
+		t.Errorf("Expected Rust as new language, got %v", diff.NewLanguages)
+	}
+	expected := ContributionGrowth{Commits: 150, PullRequests: 12, Issues: 0, CodeReviews: 12}
+	if diff.Contributions != expected {
+		t.Errorf("Expected contribution growth %+v, got %+v", expected, diff.Contributions)
+	}
+	if !diff.CareerLevelChanged() || diff.CareerLevelBefore != "mid" || diff.CareerLevelAfter != "senior" {
+		t.Errorf("Expected career level to change from mid to senior, got %q to %q", diff.CareerLevelBefore, diff.CareerLevelAfter)
+	}
+}
+
+// TestDiffLoadsPriorSnapshot verifies the cached analysis is compared with the latest earlier history
+// snapshot, ignoring the snapshot of the cached analysis itself
+func TestDiffLoadsPriorSnapshot(t *testing.T) {
+	cacheDir := t.TempDir()
+	analyzer := &Analyzer{cacheDir: cacheDir}
+	before, after := diffFixtures()
+
+	if _, err := analyzer.Diff("testuser"); err == nil {
+		t.Error("Expected an error without a cached analysis")
+	}
+
+	older := *before
+	older.LastAnalyzed = before.LastAnalyzed.AddDate(-1, 0, 0)
+	older.Insights.CareerLevel = "junior"
+	for _, snapshot := range []*UserProfile{&older, before, after} {
+		if err := analyzer.saveHistorySnapshot("testuser", snapshot); err != nil {
+			t.Fatalf("saveHistorySnapshot failed: %v", err)
+		}
+	}
+
+	data, err := json.Marshal(after)
+	if err != nil {
+		t.Fatalf("Failed to marshal profile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, "testuser_analysis.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write cached analysis: %v", err)
+	}
+
+	diff, err := analyzer.Diff("testuser")
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if !diff.From.Equal(before.LastAnalyzed) || diff.CareerLevelBefore != "mid" {
+		t.Errorf("Expected the comparison against the analysis of %s, got %s (%s)", before.LastAnalyzed, diff.From, diff.CareerLevelBefore)
+	}
+
+	if _, err := (&Analyzer{cacheDir: cacheDir}).loadPriorSnapshot("testuser", older.LastAnalyzed); !errors.Is(err, ErrNoPriorSnapshot) {
+		t.Errorf("Expected ErrNoPriorSnapshot before the first analysis, got %v", err)
+	}
+}
+
+// TestSaveHistorySnapshotPrunes verifies only the latest maxHistorySnapshots snapshots of a user are
+// kept, leaving other users' snapshots alone
+func TestSaveHistorySnapshotPrunes(t *testing.T) {
+	analyzer := &Analyzer{cacheDir: t.TempDir()}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := analyzer.saveHistorySnapshot("other", &UserProfile{Username: "other", LastAnalyzed: start}); err != nil {
+		t.Fatalf("saveHistorySnapshot failed: %v", err)
+	}
+	for i := 0; i < maxHistorySnapshots+3; i++ {
+		prof := &UserProfile{Username: "testuser", LastAnalyzed: start.AddDate(0, 0, i)}
+		if err := analyzer.saveHistorySnapshot("testuser", prof); err != nil {
+			t.Fatalf("saveHistorySnapshot failed: %v", err)
+		}
+	}
+
+	snapshots, err := analyzer.listHistorySnapshots("testuser")
+	if err != nil {
+		t.Fatalf("listHistorySnapshots failed: %v", err)
+	}
+	if len(snapshots) != maxHistorySnapshots {
+		t.Fatalf("Expected %d snapshots, got %d", maxHistorySnapshots, len(snapshots))
+	}
+	if oldest := start.AddDate(0, 0, 3); !snapshots[0].analyzedAt.Equal(oldest) {
+		t.Errorf("Expected the oldest kept snapshot from %s, got %s", oldest, snapshots[0].analyzedAt)
+	}
+	if others, _ := analyzer.listHistorySnapshots("other"); len(others) != 1 {
+		t.Errorf("Expected the other user's snapshot to be kept, got %d", len(others))
+	}
+
+	prior, err := analyzer.loadPriorSnapshot("testuser", start.AddDate(0, 0, maxHistorySnapshots+2))
+	if err != nil {
+		t.Fatalf("loadPriorSnapshot failed: %v", err)
+	}
+	if want := start.AddDate(0, 0, maxHistorySnapshots+1); !prior.LastAnalyzed.Equal(want) {
+		t.Errorf("Expected the snapshot from %s, got %s", want, prior.LastAnalyzed)
+	}
+}
@@ -0,0 +1,60 @@
+package profile
+
+import (
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+)
+
+// substantialReadmeSize is the README size, in bytes, from which it counts as documentation on its own
+const substantialReadmeSize = 5000
+
+// documentationStrengthThreshold is the number of documented repositories that makes documentation a strength
+const documentationStrengthThreshold = 3
+
+// documentationDirs are root directories holding a documentation site or guides
+var documentationDirs = map[string]bool{
+	"docs":          true,
+	"doc":           true,
+	"documentation": true,
+}
+
+// documentationTopics are repository topics marking a documentation project
+var documentationTopics = map[string]bool{
+	"documentation": true,
+	"docs":          true,
+}
+
+// hasDocumentation reports whether repository root contents include a documentation directory
+// or a README large enough to document the project by itself
+func hasDocumentation(contents []github.RepositoryContentResponse) bool {
+	for _, item := range contents {
+		name := strings.ToLower(item.Name)
+		switch {
+		case item.Type == "dir" && documentationDirs[name]:
+			return true
+		case item.Type == "file" && strings.HasPrefix(name, "readme") && item.Size >= substantialReadmeSize:
+			return true
+		}
+	}
+	return false
+}
+
+// countDocumentationRepositories counts the repositories with substantial documentation or a
+// documentation topic
+func countDocumentationRepositories(repos []RepositoryProfile) int {
+	count := 0
+	for _, repo := range repos {
+		if repo.HasDocumentation {
+			count++
+			continue
+		}
+		for _, topic := range repo.Topics {
+			if documentationTopics[strings.ToLower(topic)] {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
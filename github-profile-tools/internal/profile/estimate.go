@@ -0,0 +1,116 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+)
+
+const (
+	basicInfoQueries    = 1 // user profile query
+	organizationQueries = 1 // organizations are fetched in a single query
+)
+
+// CostEstimate is the expected number of GitHub API calls for a full analysis
+type CostEstimate struct {
+	Repositories        int    `json:"repositories"`
+	RepositorySource    string `json:"repository_source"` // "cache" or "profile"
+	RepositoryPages     int    `json:"repository_pages"`
+	ContributionQueries int    `json:"contribution_queries"`
+	LineStatsQueries    int    `json:"line_stats_queries"`
+	ContributorQueries  int    `json:"contributor_queries"`
+	ReviewQueries       int    `json:"review_queries"`
+	DockerContentCalls  int    `json:"docker_content_calls"`
+	SecurityScanCalls   int    `json:"security_scan_calls"`
+}
+
+// GraphQLQueries returns the estimated number of GraphQL queries
+func (e CostEstimate) GraphQLQueries() int {
+	return basicInfoQueries + e.RepositoryPages + organizationQueries + e.ContributionQueries + e.LineStatsQueries + e.ContributorQueries + e.ReviewQueries
+}
+
+// RESTCalls returns the estimated number of REST calls
+func (e CostEstimate) RESTCalls() int {
+	return e.DockerContentCalls + e.SecurityScanCalls
+}
+
+// EstimateAnalysisCost estimates the API calls needed to analyze a user owning repoCount repositories.
+// Line statistics assume one commit history page per repository, so long histories cost more.
+func EstimateAnalysisCost(repoCount, pageSize, contributionWindows int, withLineStats bool) CostEstimate {
+	estimate := CostEstimate{
+		Repositories:        repoCount,
+		ContributionQueries: contributionWindows,
+		DockerContentCalls:  repoCount, // one contents listing per repository
+	}
+
+	// The first page is always requested, even for users without repositories
+	estimate.RepositoryPages = 1
+	if repoCount > 0 && pageSize > 0 {
+		estimate.RepositoryPages = (repoCount + pageSize - 1) / pageSize
+	}
+
+	if withLineStats {
+		repos := repoCount
+		if repos > lineStatsTopRepositories {
+			repos = lineStatsTopRepositories
+		}
+		estimate.LineStatsQueries = 1 + repos // user ID lookup plus commit history per repository
+	}
+
+	return estimate
+}
+
+// EstimateCost estimates the API calls needed to analyze username without running the analysis.
+// The repository count comes from a cached analysis when one exists, otherwise from a single profile query.
+func (a *Analyzer) EstimateCost(ctx context.Context, username string) (CostEstimate, error) {
+	repoCount, source := 0, ""
+
+	filename := filepath.Join(a.cacheDir, fmt.Sprintf("%s_analysis.json", username))
+	if data, err := os.ReadFile(filename); err == nil {
+		var cached UserProfile
+		if err := json.Unmarshal(data, &cached); err == nil {
+			repoCount, source = len(cached.Repositories), "cache"
+		}
+	}
+
+	if source == "" {
+		req := &github.GraphQLRequest{
+			Query: github.UserProfileQuery,
+			Variables: map[string]interface{}{
+				"username": username,
+			},
+		}
+
+		var resp github.UserProfileResponse
+		if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
+			return CostEstimate{}, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+		repoCount, source = resp.User.Repositories.TotalCount, "profile"
+	}
+
+	if a.maxRepos > 0 {
+		repoCount = min(repoCount, a.maxRepos)
+	}
+
+	since, until := a.contributionPeriod()
+	windows := len(github.SplitContributionWindows(since, until))
+
+	estimate := EstimateAnalysisCost(repoCount, a.repositoryPageSize(), windows, a.withLineStats)
+	if a.withContributors {
+		// An upper bound: only owned repositories are queried
+		estimate.ContributorQueries = min(repoCount, contributorsTopRepositories)
+	}
+	if a.withReviews {
+		estimate.ReviewQueries = 1
+	}
+	if a.withSecurityScan {
+		// An upper bound: .github is only listed in repositories that have one
+		estimate.SecurityScanCalls = repoCount
+	}
+	estimate.RepositorySource = source
+	return estimate, nil
+}
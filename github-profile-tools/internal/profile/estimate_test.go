@@ -0,0 +1,44 @@
+package profile
+
+import "testing"
+
+// TestEstimateAnalysisCost verifies the estimate for a user with a known repository count
+func TestEstimateAnalysisCost(t *testing.T) {
+	estimate := EstimateAnalysisCost(120, 50, 1, false)
+
+	if estimate.RepositoryPages != 3 {
+		t.Errorf("Expected 3 repository pages, got %d", estimate.RepositoryPages)
+	}
+	if estimate.ContributionQueries != 1 {
+		t.Errorf("Expected 1 contribution query, got %d", estimate.ContributionQueries)
+	}
+	if estimate.DockerContentCalls != 120 {
+		t.Errorf("Expected 120 Docker content calls, got %d", estimate.DockerContentCalls)
+	}
+	if estimate.LineStatsQueries != 0 {
+		t.Errorf("Expected no line stats queries, got %d", estimate.LineStatsQueries)
+	}
+	// basic info + 3 pages + organizations + 1 contribution query
+	if got := estimate.GraphQLQueries(); got != 6 {
+		t.Errorf("Expected 6 GraphQL queries, got %d", got)
+	}
+	if got := estimate.RESTCalls(); got != 120 {
+		t.Errorf("Expected 120 REST calls, got %d", got)
+	}
+}
+
+// TestEstimateAnalysisCostEdges verifies page rounding, empty accounts and line stats
+func TestEstimateAnalysisCostEdges(t *testing.T) {
+	if pages := EstimateAnalysisCost(50, 50, 1, false).RepositoryPages; pages != 1 {
+		t.Errorf("Expected 1 page for exactly 50 repositories, got %d", pages)
+	}
+	if pages := EstimateAnalysisCost(51, 50, 1, false).RepositoryPages; pages != 2 {
+		t.Errorf("Expected 2 pages for 51 repositories, got %d", pages)
+	}
+	if pages := EstimateAnalysisCost(0, 50, 1, false).RepositoryPages; pages != 1 {
+		t.Errorf("Expected the first page to be requested for 0 repositories, got %d", pages)
+	}
+	if queries := EstimateAnalysisCost(120, 50, 3, true).LineStatsQueries; queries != 1+lineStatsTopRepositories {
+		t.Errorf("Expected %d line stats queries, got %d", 1+lineStatsTopRepositories, queries)
+	}
+}
@@ -0,0 +1,103 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWriteFileAtomicReplacesTruncatedFile verifies a truncated file from an earlier crash is replaced cleanly
+func TestWriteFileAtomicReplacesTruncatedFile(t *testing.T) {
+	analyzer := &Analyzer{cacheDir: t.TempDir(), analysisMaxAge: DefaultAnalysisMaxAge}
+
+	// Simulate a crash that left a half-written analysis behind
+	path := filepath.Join(analyzer.cacheDir, "testuser_analysis.json")
+	if err := os.WriteFile(path, []byte(`{"username": "testuser", "repositories": [{"na`), 0644); err != nil {
+		t.Fatalf("Failed to write truncated file: %v", err)
+	}
+	if analyzer.tryLoadFromCache("testuser") != nil {
+		t.Fatal("Expected truncated analysis to be unreadable")
+	}
+
+	if err := analyzer.saveToCache("testuser", &UserProfile{Username: "testuser", LastAnalyzed: time.Now()}); err != nil {
+		t.Fatalf("saveToCache failed: %v", err)
+	}
+
+	cached := analyzer.tryLoadFromCache("testuser")
+	if cached == nil || cached.Username != "testuser" {
+		t.Fatalf("Expected analysis to load after atomic write, got %+v", cached)
+	}
+
+	assertNoTempFiles(t, analyzer.cacheDir)
+}
+
+// assertNoTempFiles fails the test when a temporary file of WriteFileAtomic is left in dir
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(matches) > 0 {
+		t.Errorf("Expected temporary files to be gone after rename, found %v", matches)
+	}
+}
+
+// TestWriteFileAtomicKeepsOriginalOnFailure verifies a failed write leaves the existing target
+// untouched and removes its temporary file
+func TestWriteFileAtomicKeepsOriginalOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "profile.json")
+
+	// A non-empty directory squatting on the target makes the rename fail
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatalf("Failed to create blocking directory: %v", err)
+	}
+	original := filepath.Join(path, "original.json")
+	if err := os.WriteFile(original, []byte(`{"ok": true}`), 0644); err != nil {
+		t.Fatalf("Failed to write original file: %v", err)
+	}
+
+	if err := WriteFileAtomic(path, []byte(`{"ok": false}`), 0644); err == nil {
+		t.Fatal("Expected write to fail")
+	}
+
+	data, err := os.ReadFile(original)
+	if err != nil || string(data) != `{"ok": true}` {
+		t.Errorf("Expected original content to survive, got %q (%v)", data, err)
+	}
+	assertNoTempFiles(t, dir)
+}
+
+// TestWriteFileAtomicConcurrentWriters verifies concurrent writers of one path never leave a mix
+// of their data behind
+func TestWriteFileAtomicConcurrentWriters(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "profile.json")
+
+	contents := make(map[string]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		data := strings.Repeat(strconv.Itoa(i%10), 64*1024)
+		contents[data] = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := WriteFileAtomic(path, []byte(data), 0644); err != nil {
+				t.Errorf("WriteFileAtomic failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	if err != nil || !contents[string(data)] {
+		t.Errorf("Expected the complete data of one writer, got %d bytes (%v)", len(data), err)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Errorf("Failed to stat the written file: %v", err)
+	} else if info.Mode().Perm() != 0644 {
+		t.Errorf("Expected mode 0644, got %v", info.Mode().Perm())
+	}
+	assertNoTempFiles(t, dir)
+}
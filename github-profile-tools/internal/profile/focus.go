@@ -0,0 +1,50 @@
+package profile
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxFocusAreas is the number of topics reported as focus areas
+const maxFocusAreas = 5
+
+// minFocusAreaRepositories is the number of repositories sharing a topic for it to count as a focus
+const minFocusAreaRepositories = 2
+
+// calculateFocusAreas tallies repository topics and returns the most frequent ones, revealing the
+// domains (e.g. "jenkins", "kubernetes") a user works in regardless of language
+func calculateFocusAreas(repos []RepositoryProfile) []FocusArea {
+	counts := make(map[string]int)
+	for _, repo := range repos {
+		// A topic listed twice on one repository still counts once
+		seen := make(map[string]bool)
+		for _, topic := range repo.Topics {
+			topic = strings.ToLower(topic)
+			if topic == "" || seen[topic] {
+				continue
+			}
+			seen[topic] = true
+			counts[topic]++
+		}
+	}
+
+	areas := []FocusArea{}
+	for topic, count := range counts {
+		if count >= minFocusAreaRepositories {
+			areas = append(areas, FocusArea{Topic: topic, RepositoryCount: count})
+		}
+	}
+
+	// Most frequent first, ties broken by name so the order does not depend on map order
+	sort.Slice(areas, func(i, j int) bool {
+		if areas[i].RepositoryCount != areas[j].RepositoryCount {
+			return areas[i].RepositoryCount > areas[j].RepositoryCount
+		}
+		return areas[i].Topic < areas[j].Topic
+	})
+
+	if len(areas) > maxFocusAreas {
+		areas = areas[:maxFocusAreas]
+	}
+	return areas
+}
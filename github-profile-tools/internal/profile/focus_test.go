@@ -0,0 +1,29 @@
+package profile
+
+import "testing"
+
+// TestCalculateFocusAreas verifies the topic shared by the most repositories is reported first and
+// topics found on a single repository are left out
+func TestCalculateFocusAreas(t *testing.T) {
+	repos := []RepositoryProfile{
+		{Name: "plugin-a", Topics: []string{"jenkins", "jenkins-plugin", "java"}},
+		{Name: "plugin-b", Topics: []string{"Jenkins", "jenkins-plugin"}},
+		{Name: "operator", Topics: []string{"jenkins", "kubernetes", "kubernetes"}},
+		{Name: "charts", Topics: []string{"kubernetes", "helm"}},
+	}
+
+	areas := calculateFocusAreas(repos)
+	expected := []FocusArea{
+		{Topic: "jenkins", RepositoryCount: 3},
+		{Topic: "jenkins-plugin", RepositoryCount: 2},
+		{Topic: "kubernetes", RepositoryCount: 2},
+	}
+	if len(areas) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, areas)
+	}
+	for i := range expected {
+		if areas[i] != expected[i] {
+			t.Errorf("Expected %v at position %d, got %v", expected[i], i, areas[i])
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// ImpactConfig configures how calculateImpactScore combines a profile's activity into a 0-1 score.
+// Each factor adds Weight × value / Divisor, or with LogScale Weight × log(1+value) / log(1+Divisor),
+// and the sum is capped at 1.
+type ImpactConfig struct {
+	Contributions     ImpactFactor `json:"contributions"` // commits, pull requests and issues
+	Stars             ImpactFactor `json:"stars"`         // stars received by all repositories
+	Languages         ImpactFactor `json:"languages"`
+	Organizations     ImpactFactor `json:"organizations"`
+	ConsistencyWeight float64      `json:"consistencyWeight"` // weight of the 0-1 contribution consistency
+	LogScale          bool         `json:"logScale"`          // separates high performers instead of saturating
+}
+
+// ImpactFactor normalizes one measure of activity: Divisor is the value worth the full Weight
+type ImpactFactor struct {
+	Divisor float64 `json:"divisor"`
+	Weight  float64 `json:"weight"`
+}
+
+// DefaultImpactConfig is the built-in linear formula, also used when no -impact-config is given
+var DefaultImpactConfig = ImpactConfig{
+	Contributions:     ImpactFactor{Divisor: 1000, Weight: 1},
+	Stars:             ImpactFactor{Divisor: 100, Weight: 1},
+	Languages:         ImpactFactor{Divisor: 10, Weight: 1},
+	Organizations:     ImpactFactor{Divisor: 5, Weight: 1},
+	ConsistencyWeight: 1,
+}
+
+// LoadImpactConfig reads an impact score formula from a JSON file shaped like ImpactConfig.
+// Fields missing from the file keep their DefaultImpactConfig values.
+func LoadImpactConfig(path string) (*ImpactConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read impact config: %w", err)
+	}
+
+	config := DefaultImpactConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid impact config %s: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid impact config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// validate checks that every divisor is positive and no weight is negative
+func (c ImpactConfig) validate() error {
+	factors := map[string]ImpactFactor{
+		"contributions": c.Contributions,
+		"stars":         c.Stars,
+		"languages":     c.Languages,
+		"organizations": c.Organizations,
+	}
+	for name, factor := range factors {
+		if factor.Divisor <= 0 {
+			return fmt.Errorf("%s divisor must be positive", name)
+		}
+		if factor.Weight < 0 {
+			return fmt.Errorf("%s weight must not be negative", name)
+		}
+	}
+	if c.ConsistencyWeight < 0 {
+		return fmt.Errorf("consistencyWeight must not be negative")
+	}
+	return nil
+}
+
+// score returns the weighted, normalized contribution of value to the impact score
+func (f ImpactFactor) score(value float64, logScale bool) float64 {
+	if logScale {
+		return f.Weight * math.Log1p(value) / math.Log1p(f.Divisor)
+	}
+	return f.Weight * value / f.Divisor
+}
+
+// SetImpactConfig replaces the impact score formula; nil restores DefaultImpactConfig
+func (a *Analyzer) SetImpactConfig(config *ImpactConfig) {
+	a.impactConfig = config
+}
@@ -0,0 +1,83 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// highOutputProfile returns a profile with the given contribution and star totals
+func highOutputProfile(contributions, stars int) *UserProfile {
+	return &UserProfile{
+		Contributions: ContributionSummary{TotalCommits: contributions, ConsistencyScore: 0.9},
+		Repositories:  []RepositoryProfile{{Name: "project", Stars: stars}},
+		Languages:     []LanguageStats{{Language: "Go"}, {Language: "Java"}, {Language: "Python"}},
+		Organizations: []OrganizationProfile{{Login: "acme"}},
+	}
+}
+
+// TestImpactScoreLogScale verifies very different high-output profiles saturate under the defaults
+// but get distinguishable scores under a log-scale config
+func TestImpactScoreLogScale(t *testing.T) {
+	prolific := highOutputProfile(5000, 500)
+	exceptional := highOutputProfile(60000, 40000)
+
+	analyzer := &Analyzer{}
+	if a, b := analyzer.calculateImpactScore(prolific), analyzer.calculateImpactScore(exceptional); a != 1 || b != 1 {
+		t.Fatalf("Expected both default scores to saturate at 1, got %.2f and %.2f", a, b)
+	}
+
+	path := filepath.Join(t.TempDir(), "impact.json")
+	config := `{
+  "contributions": {"divisor": 100000, "weight": 0.4},
+  "stars": {"divisor": 100000, "weight": 0.4},
+  "languages": {"divisor": 20, "weight": 0.1},
+  "organizations": {"divisor": 20, "weight": 0.1},
+  "consistencyWeight": 0,
+  "logScale": true
+}`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write impact config: %v", err)
+	}
+
+	impact, err := LoadImpactConfig(path)
+	if err != nil {
+		t.Fatalf("LoadImpactConfig failed: %v", err)
+	}
+	analyzer.SetImpactConfig(impact)
+
+	a, b := analyzer.calculateImpactScore(prolific), analyzer.calculateImpactScore(exceptional)
+	if b >= 1 || b-a < 0.1 {
+		t.Errorf("Expected distinguishable scores below 1, got %.2f and %.2f", a, b)
+	}
+}
+
+// TestLoadImpactConfigInvalid verifies partial configs keep defaults and invalid ones are rejected
+func TestLoadImpactConfigInvalid(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write impact config: %v", err)
+		}
+		return path
+	}
+
+	config, err := LoadImpactConfig(write("partial.json", `{"logScale": true}`))
+	if err != nil {
+		t.Fatalf("LoadImpactConfig failed: %v", err)
+	}
+	if !config.LogScale || config.Stars != DefaultImpactConfig.Stars {
+		t.Errorf("Expected defaults with a log scale, got %+v", config)
+	}
+
+	for name, content := range map[string]string{
+		"zero divisor":    `{"stars": {"divisor": 0, "weight": 1}}`,
+		"negative weight": `{"languages": {"divisor": 10, "weight": -1}}`,
+		"not json":        `impact`,
+	} {
+		if _, err := LoadImpactConfig(write("invalid.json", content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
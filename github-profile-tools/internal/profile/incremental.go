@@ -0,0 +1,110 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	snapshotVersion          = "1"
+	repositoryCheckpointType = "repository"
+)
+
+// getSnapshotFilename returns the path of the snapshot used for incremental updates
+func (a *Analyzer) getSnapshotFilename(username string) string {
+	return filepath.Join(a.cacheDir, fmt.Sprintf("%s_snapshot.json", username))
+}
+
+// saveSnapshot records each repository's pushedAt so the next incremental run can skip unchanged ones
+func (a *Analyzer) saveSnapshot(username string, profile *UserProfile) error {
+	if err := os.MkdirAll(a.cacheDir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	snapshot := ProfileSnapshot{
+		Username:  username,
+		Timestamp: time.Now(),
+		Version:   snapshotVersion,
+	}
+	for _, repo := range profile.Repositories {
+		snapshot.Checkpoints = append(snapshot.Checkpoints, Checkpoint{
+			DataType:    repositoryCheckpointType,
+			Key:         repo.FullName,
+			LastUpdated: repo.PushedAt,
+			RecordCount: 1,
+		})
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	return WriteFileAtomic(a.getSnapshotFilename(username), data, 0644)
+}
+
+// loadRepositoryBaseline returns the repositories of the previous analysis that have a snapshot
+// checkpoint, keyed by full name. Repositories missing from either are always re-analyzed.
+func (a *Analyzer) loadRepositoryBaseline(username string) map[string]RepositoryProfile {
+	data, err := os.ReadFile(a.getSnapshotFilename(username))
+	if err != nil {
+		log.Printf("No snapshot found for incremental update, analyzing all repositories")
+		return nil
+	}
+
+	var snapshot ProfileSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil || snapshot.Version != snapshotVersion {
+		log.Printf("Warning: Ignoring unusable snapshot for incremental update: %v", err)
+		return nil
+	}
+
+	// The previous analysis holds the per-repository results (e.g. DockerConfig) to reuse
+	data, err = os.ReadFile(filepath.Join(a.cacheDir, fmt.Sprintf("%s_analysis.json", username)))
+	if err != nil {
+		log.Printf("No previous analysis found for incremental update, analyzing all repositories")
+		return nil
+	}
+
+	var previous UserProfile
+	if err := json.Unmarshal(data, &previous); err != nil {
+		log.Printf("Warning: Ignoring unreadable previous analysis for incremental update: %v", err)
+		return nil
+	}
+	if previous.SchemaVersion != ProfileSchemaVersion {
+		log.Printf("Previous analysis has schema version %q, analyzing all repositories", previous.SchemaVersion)
+		return nil
+	}
+
+	previousRepos := make(map[string]RepositoryProfile, len(previous.Repositories))
+	for _, repo := range previous.Repositories {
+		previousRepos[repo.FullName] = repo
+	}
+
+	baseline := make(map[string]RepositoryProfile)
+	for _, checkpoint := range snapshot.Checkpoints {
+		if checkpoint.DataType != repositoryCheckpointType {
+			continue
+		}
+		if repo, ok := previousRepos[checkpoint.Key]; ok {
+			repo.PushedAt = checkpoint.LastUpdated
+			baseline[checkpoint.Key] = repo
+		}
+	}
+
+	log.Printf("Loaded incremental baseline with %d repositories from %s",
+		len(baseline), snapshot.Timestamp.Format("2006-01-02 15:04:05"))
+	return baseline
+}
+
+// unchangedRepository returns the previous analysis of repo if nothing was pushed since
+func (a *Analyzer) unchangedRepository(repo RepositoryProfile) (RepositoryProfile, bool) {
+	previous, ok := a.repoBaseline[repo.FullName]
+	if !ok || repo.PushedAt.IsZero() || repo.PushedAt.After(previous.PushedAt) {
+		return RepositoryProfile{}, false
+	}
+	return previous, true
+}
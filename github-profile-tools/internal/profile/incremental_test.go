@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+)
+
+// TestIncrementalReusesUnchangedRepository verifies an unchanged repository's Docker analysis is not recomputed
+func TestIncrementalReusesUnchangedRepository(t *testing.T) {
+	pushedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	dockerConfig := &DockerConfig{HasDockerfile: true, ComplexityScore: 4.5}
+
+	// First pass: record the analysis and its snapshot
+	first := &Analyzer{cacheDir: t.TempDir()}
+	profile := &UserProfile{
+		Username:     "testuser",
+		LastAnalyzed: time.Now(),
+		Repositories: []RepositoryProfile{
+			{Name: "stable", FullName: "testuser/stable", PushedAt: pushedAt, DockerConfig: dockerConfig},
+			{Name: "active", FullName: "testuser/active", PushedAt: pushedAt},
+		},
+	}
+	if err := first.saveToCache("testuser", profile); err != nil {
+		t.Fatalf("saveToCache failed: %v", err)
+	}
+	if err := first.saveSnapshot("testuser", profile); err != nil {
+		t.Fatalf("saveSnapshot failed: %v", err)
+	}
+
+	// Second pass: the analyzer has no GitHub client, so any Docker re-analysis would panic
+	second := &Analyzer{cacheDir: first.cacheDir, incremental: true}
+	second.repoBaseline = second.loadRepositoryBaseline("testuser")
+	if len(second.repoBaseline) != 2 {
+		t.Fatalf("Expected 2 baseline repositories, got %d", len(second.repoBaseline))
+	}
+
+	node := github.RepositoryNode{Name: "stable", NameWithOwner: "testuser/stable", PushedAt: pushedAt}
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("Unchanged repository was re-analyzed: %v", r)
+			}
+		}()
+		repo := second.convertRepositoryNode(context.Background(), node, "testuser")
+		if repo.DockerConfig == nil || repo.DockerConfig.ComplexityScore != 4.5 {
+			t.Errorf("Expected previous Docker analysis to be reused, got %+v", repo.DockerConfig)
+		}
+	}()
+}
+
+// TestIncrementalDetectsPushedRepository verifies repositories pushed since the snapshot are re-analyzed
+func TestIncrementalDetectsPushedRepository(t *testing.T) {
+	pushedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	analyzer := &Analyzer{
+		repoBaseline: map[string]RepositoryProfile{
+			"testuser/active": {FullName: "testuser/active", PushedAt: pushedAt},
+		},
+	}
+
+	if _, ok := analyzer.unchangedRepository(RepositoryProfile{FullName: "testuser/active", PushedAt: pushedAt}); !ok {
+		t.Error("Expected repository with same pushedAt to be unchanged")
+	}
+	if _, ok := analyzer.unchangedRepository(RepositoryProfile{FullName: "testuser/active", PushedAt: pushedAt.Add(time.Minute)}); ok {
+		t.Error("Expected repository pushed after the snapshot to be re-analyzed")
+	}
+	if _, ok := analyzer.unchangedRepository(RepositoryProfile{FullName: "testuser/new", PushedAt: pushedAt}); ok {
+		t.Error("Expected repository missing from the snapshot to be analyzed")
+	}
+}
+
+// TestIncrementalWithoutSnapshot verifies a missing snapshot falls back to a full analysis
+func TestIncrementalWithoutSnapshot(t *testing.T) {
+	analyzer := &Analyzer{cacheDir: t.TempDir(), incremental: true}
+
+	if baseline := analyzer.loadRepositoryBaseline("nobody"); baseline != nil {
+		t.Errorf("Expected no baseline without a snapshot, got %d repositories", len(baseline))
+	}
+}
@@ -0,0 +1,43 @@
+package profile
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+// SetLanguageFallback enables fetching languages over REST for repositories whose GraphQL
+// `languages` field came back empty, at the cost of one extra call per such repository
+func (a *Analyzer) SetLanguageFallback(enabled bool) {
+	a.languageFallback = enabled
+}
+
+// fetchLanguagesFallback merges the byte counts of GET /repos/{owner}/{repo}/languages into
+// repo.Languages, and derives the primary language from them when GraphQL reported none
+func (a *Analyzer) fetchLanguagesFallback(ctx context.Context, repo *RepositoryProfile) {
+	parts := strings.SplitN(repo.FullName, "/", 2)
+	if len(parts) != 2 {
+		return
+	}
+
+	languages, err := a.client.FetchRepositoryLanguages(ctx, parts[0], parts[1])
+	if err != nil {
+		logging.Warnf("Failed to fetch languages for %s: %v", repo.FullName, err)
+		return
+	}
+
+	for language, bytes := range languages {
+		repo.Languages[language] += bytes
+	}
+
+	if repo.Language == "" {
+		largest := 0
+		for language, bytes := range repo.Languages {
+			// Ties are broken by name so the result does not depend on map order
+			if bytes > largest || (bytes == largest && language < repo.Language) {
+				repo.Language, largest = language, bytes
+			}
+		}
+	}
+}
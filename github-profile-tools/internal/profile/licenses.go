@@ -0,0 +1,115 @@
+package profile
+
+import (
+	"sort"
+	"strings"
+)
+
+// License categories counted by LicenseSummary
+const (
+	licensePermissive = "permissive"
+	licenseCopyleft   = "copyleft"
+	licenseOther      = "other"
+)
+
+// copyleftLicenseMarkers identify copyleft licenses by their GitHub name, e.g. "GNU General Public License v3.0"
+var copyleftLicenseMarkers = []string{
+	"general public license", // GPL, LGPL and AGPL
+	"mozilla public license",
+	"eclipse public license",
+	"european union public licence",
+	"common development and distribution",
+	"open software license",
+}
+
+// permissiveLicenseMarkers identify permissive licenses by their GitHub name, e.g. "Apache License 2.0"
+var permissiveLicenseMarkers = []string{
+	"mit",
+	"apache",
+	"bsd",
+	"isc",
+	"unlicense",
+	"zlib",
+	"boost software license",
+	"creative commons zero",
+	"do what the f",
+	"academic free license",
+}
+
+// licenseCategory classifies a GitHub license name as permissive, copyleft or other. Single-word
+// markers such as "mit" must match a whole word so they are not found inside other names.
+func licenseCategory(name string) string {
+	lower := strings.ToLower(name)
+	for _, marker := range copyleftLicenseMarkers {
+		if strings.Contains(lower, marker) {
+			return licenseCopyleft
+		}
+	}
+	words := strings.FieldsFunc(lower, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '"' || r == '(' || r == ')'
+	})
+	for _, marker := range permissiveLicenseMarkers {
+		if strings.Contains(marker, " ") {
+			if strings.Contains(lower, marker) {
+				return licensePermissive
+			}
+			continue
+		}
+		for _, word := range words {
+			if word == marker {
+				return licensePermissive
+			}
+		}
+	}
+	return licenseOther
+}
+
+// summarizeLicenses tallies the licenses of the user's own repositories, forks excluded, or returns
+// nil when the user owns none
+func summarizeLicenses(repos []RepositoryProfile) *LicenseSummary {
+	summary := &LicenseSummary{}
+	counts := make(map[string]int)
+	owned := 0
+
+	for _, repo := range repos {
+		if !repo.IsOwner || repo.IsFork {
+			continue
+		}
+		owned++
+
+		if repo.License == "" {
+			summary.Unlicensed++
+			continue
+		}
+		counts[repo.License]++
+		switch licenseCategory(repo.License) {
+		case licensePermissive:
+			summary.Permissive++
+		case licenseCopyleft:
+			summary.Copyleft++
+		default:
+			summary.Other++
+		}
+	}
+
+	if owned == 0 {
+		return nil
+	}
+
+	// Most used first, ties broken by name so the result does not depend on map order
+	licenses := make([]string, 0, len(counts))
+	for license := range counts {
+		licenses = append(licenses, license)
+	}
+	sort.Slice(licenses, func(i, j int) bool {
+		if counts[licenses[i]] != counts[licenses[j]] {
+			return counts[licenses[i]] > counts[licenses[j]]
+		}
+		return licenses[i] < licenses[j]
+	})
+	if len(licenses) > 0 {
+		summary.MostUsed = licenses[0]
+	}
+
+	return summary
+}
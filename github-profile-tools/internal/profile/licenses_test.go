@@ -0,0 +1,45 @@
+package profile
+
+import "testing"
+
+// TestSummarizeLicenses verifies owned repositories under MIT, GPL and no license are categorized,
+// and forks and contributed repositories are left out
+func TestSummarizeLicenses(t *testing.T) {
+	repos := []RepositoryProfile{
+		{Name: "tool", IsOwner: true, License: "MIT License"},
+		{Name: "lib", IsOwner: true, License: "MIT License"},
+		{Name: "daemon", IsOwner: true, License: "GNU General Public License v3.0"},
+		{Name: "scratch", IsOwner: true},
+		{Name: "custom", IsOwner: true, License: "Other"},
+		{Name: "fork", IsOwner: true, IsFork: true, License: "GNU Affero General Public License v3.0"},
+		{Name: "upstream", License: "Apache License 2.0"},
+	}
+
+	summary := summarizeLicenses(repos)
+	expected := LicenseSummary{Permissive: 2, Copyleft: 1, Other: 1, Unlicensed: 1, MostUsed: "MIT License"}
+	if summary == nil || *summary != expected {
+		t.Errorf("Expected %+v, got %+v", expected, summary)
+	}
+
+	if summary := summarizeLicenses(repos[6:]); summary != nil {
+		t.Errorf("Expected no summary without owned repositories, got %+v", summary)
+	}
+}
+
+// TestLicenseCategory verifies GitHub license names map to their category
+func TestLicenseCategory(t *testing.T) {
+	for name, expected := range map[string]string{
+		"MIT License":        licensePermissive,
+		"Apache License 2.0": licensePermissive,
+		"BSD 3-Clause \"New\" or \"Revised\" License": licensePermissive,
+		"The Unlicense":                          licensePermissive,
+		"GNU Lesser General Public License v2.1": licenseCopyleft,
+		"Mozilla Public License 2.0":             licenseCopyleft,
+		"Other":                                  licenseOther,
+		"Permit License":                         licenseOther,
+	} {
+		if category := licenseCategory(name); category != expected {
+			t.Errorf("Expected %q to be %s, got %s", name, expected, category)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+)
+
+// loadCommitHistoryFixture loads a recorded commit history response
+func loadCommitHistoryFixture(t *testing.T) *github.CommitHistoryResponse {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "commit_history.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	var resp github.CommitHistoryResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("Failed to decode fixture: %v", err)
+	}
+	if resp.Repository.DefaultBranchRef == nil {
+		t.Fatal("Fixture has no default branch")
+	}
+
+	return &resp
+}
+
+// TestLineStatsPerRepositoryAndTotal verifies additions/deletions are summed per repository and overall
+func TestLineStatsPerRepositoryAndTotal(t *testing.T) {
+	history := loadCommitHistoryFixture(t).Repository.DefaultBranchRef.Target.History
+
+	additions, deletions := sumCommitLineStats(history.Nodes)
+	if additions != 600 || deletions != 270 {
+		t.Fatalf("Expected +600/-270 for fixture history, got +%d/-%d", additions, deletions)
+	}
+
+	profile := &UserProfile{
+		Repositories: []RepositoryProfile{
+			{FullName: "testuser/first"},
+			{FullName: "testuser/second", ContributionStats: ContributionStats{Additions: 40, Deletions: 10}},
+		},
+	}
+	profile.Repositories[0].ContributionStats.Additions = additions
+	profile.Repositories[0].ContributionStats.Deletions = deletions
+
+	aggregateLineStats(profile)
+
+	if profile.Contributions.TotalAdditions != 640 {
+		t.Errorf("Expected 640 total additions, got %d", profile.Contributions.TotalAdditions)
+	}
+	if profile.Contributions.TotalDeletions != 280 {
+		t.Errorf("Expected 280 total deletions, got %d", profile.Contributions.TotalDeletions)
+	}
+
+	// Aggregating again must not double count
+	aggregateLineStats(profile)
+	if profile.Contributions.TotalAdditions != 640 {
+		t.Errorf("Expected aggregation to be idempotent, got %d additions", profile.Contributions.TotalAdditions)
+	}
+}
+
+// TestSelectLineStatsRepositories verifies repositories are ranked by commits then stars, skipping forks
+func TestSelectLineStatsRepositories(t *testing.T) {
+	repos := []RepositoryProfile{
+		{Name: "popular", Stars: 500},
+		{Name: "fork", IsFork: true, ContributionStats: ContributionStats{Commits: 900}},
+		{Name: "busy", ContributionStats: ContributionStats{Commits: 80}},
+		{Name: "quiet", Stars: 3},
+	}
+
+	got := selectLineStatsRepositories(repos, 2)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 repositories, got %d", len(got))
+	}
+	if repos[got[0]].Name != "busy" || repos[got[1]].Name != "popular" {
+		t.Errorf("Expected [busy popular], got [%s %s]", repos[got[0]].Name, repos[got[1]].Name)
+	}
+}
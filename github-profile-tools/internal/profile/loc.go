@@ -0,0 +1,61 @@
+package profile
+
+// Lines of code are not exposed by the GitHub API, which only reports bytes per language.
+// The figures below are rough averages of bytes per source line (including indentation and
+// comments) used to turn byte counts into an approximate line count. They are estimates only.
+
+// defaultBytesPerLineFallback is used for languages without a specific factor
+const defaultBytesPerLineFallback = 30
+
+// DefaultBytesPerLine maps GitHub language names to average bytes per line of source
+var DefaultBytesPerLine = map[string]int{
+	"Go":         30,
+	"Python":     25,
+	"Java":       35,
+	"Kotlin":     32,
+	"Groovy":     30,
+	"JavaScript": 30,
+	"TypeScript": 32,
+	"C":          28,
+	"C++":        30,
+	"C#":         35,
+	"Rust":       32,
+	"Ruby":       24,
+	"PHP":        32,
+	"Shell":      28,
+	"Dockerfile": 40,
+	"HTML":       45,
+	"CSS":        22,
+	"Makefile":   26,
+	"YAML":       22,
+}
+
+// SetBytesPerLine overrides the bytes-per-line factors used to estimate lines of code.
+// Languages not present in overrides keep their default factor.
+func (a *Analyzer) SetBytesPerLine(overrides map[string]int) {
+	factors := make(map[string]int, len(DefaultBytesPerLine)+len(overrides))
+	for language, factor := range DefaultBytesPerLine {
+		factors[language] = factor
+	}
+	for language, factor := range overrides {
+		if factor > 0 {
+			factors[language] = factor
+		}
+	}
+	a.bytesPerLine = factors
+}
+
+// estimateLinesOfCode converts a byte count into an approximate number of lines for a language
+func (a *Analyzer) estimateLinesOfCode(language string, bytes int) int {
+	factors := a.bytesPerLine
+	if factors == nil {
+		factors = DefaultBytesPerLine
+	}
+
+	factor, ok := factors[language]
+	if !ok || factor <= 0 {
+		factor = defaultBytesPerLineFallback
+	}
+
+	return bytes / factor
+}
@@ -0,0 +1,54 @@
+package profile
+
+import "testing"
+
+// TestEstimateLinesOfCodeGo verifies a known Go byte count yields the expected estimated lines
+func TestEstimateLinesOfCodeGo(t *testing.T) {
+	analyzer := &Analyzer{}
+
+	if got := analyzer.estimateLinesOfCode("Go", 300000); got != 10000 {
+		t.Errorf("Expected 10000 estimated Go lines for 300000 bytes, got %d", got)
+	}
+}
+
+// TestEstimateLinesOfCodeOverrides verifies custom factors replace defaults and unknown languages use the fallback
+func TestEstimateLinesOfCodeOverrides(t *testing.T) {
+	analyzer := &Analyzer{}
+	analyzer.SetBytesPerLine(map[string]int{"Go": 50})
+
+	if got := analyzer.estimateLinesOfCode("Go", 300000); got != 6000 {
+		t.Errorf("Expected 6000 lines with overridden Go factor, got %d", got)
+	}
+	if got := analyzer.estimateLinesOfCode("Python", 2500); got != 100 {
+		t.Errorf("Expected default Python factor to be kept, got %d lines", got)
+	}
+	if got := analyzer.estimateLinesOfCode("Zig", 3000); got != 3000/defaultBytesPerLineFallback {
+		t.Errorf("Expected fallback factor for unknown language, got %d lines", got)
+	}
+}
+
+// TestAnalyzeLanguagesPopulatesLinesOfCode verifies language stats carry an estimated line count
+func TestAnalyzeLanguagesPopulatesLinesOfCode(t *testing.T) {
+	analyzer := &Analyzer{}
+	profile := &UserProfile{
+		Repositories: []RepositoryProfile{
+			{Name: "a", Languages: map[string]int{"Go": 60000}},
+			{Name: "b", Languages: map[string]int{"Go": 30000, "Python": 5000}},
+		},
+	}
+
+	analyzer.analyzeLanguages(profile)
+
+	for _, lang := range profile.Languages {
+		switch lang.Language {
+		case "Go":
+			if lang.LinesOfCode != 3000 {
+				t.Errorf("Expected 3000 Go lines, got %d", lang.LinesOfCode)
+			}
+		case "Python":
+			if lang.LinesOfCode != 200 {
+				t.Errorf("Expected 200 Python lines, got %d", lang.LinesOfCode)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package profile
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+	"github.com/jenkins/github-profile-tools/internal/metrics"
+)
+
+// scrapeMetrics fetches the metrics endpoint at addr and returns the value of each sample
+func scrapeMetrics(t *testing.T, addr string) map[string]float64 {
+	t.Helper()
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("Scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	samples := make(map[string]float64)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || strings.HasPrefix(name, "#") {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			samples[name] = parsed
+		}
+	}
+	return samples
+}
+
+// TestAnalysisMetrics verifies the scraped counters increment after a cached analysis and an
+// interrupted analysis against a mock GitHub API
+func TestAnalysisMetrics(t *testing.T) {
+	server, err := metrics.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start metrics server: %v", err)
+	}
+	defer server.Shutdown(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req github.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "4990")
+		if _, paginated := req.Variables["first"]; !paginated {
+			w.Write([]byte(`{"data":{"user":{"login":"hubot","createdAt":"2015-01-01T00:00:00Z"}}}`))
+			return
+		}
+		// Interrupt the analysis on the first repository page
+		cancel()
+	}))
+	defer api.Close()
+
+	analyzer := newTestAnalyzer(t, api)
+	analyzer.saveProgressDir = t.TempDir()
+	analyzer.cacheDir = t.TempDir()
+	analyzer.analysisMaxAge = time.Hour
+	if err := analyzer.saveToCache("octocat", &UserProfile{Username: "octocat", LastAnalyzed: time.Now()}); err != nil {
+		t.Fatalf("Failed to save cached analysis: %v", err)
+	}
+
+	before := scrapeMetrics(t, server.Addr())
+
+	if _, err := analyzer.AnalyzeUserWithCustomUsernames(context.Background(), "octocat", "octocat", ""); err != nil {
+		t.Fatalf("Cached analysis failed: %v", err)
+	}
+	if _, err := analyzer.AnalyzeUserWithCustomUsernames(ctx, "hubot", "hubot", ""); !errors.Is(err, ErrPartialAnalysis) {
+		t.Fatalf("Expected ErrPartialAnalysis, got %v", err)
+	}
+
+	after := scrapeMetrics(t, server.Addr())
+	for name, increment := range map[string]float64{
+		"github_profile_users_analyzed_total":                 1,
+		"github_profile_analysis_errors_total":                1,
+		`github_profile_cache_hits_total{layer="analysis"}`:   1,
+		`github_profile_cache_misses_total{layer="analysis"}`: 1,
+	} {
+		if got := after[name] - before[name]; got != increment {
+			t.Errorf("Expected %s to increase by %v, got %v", name, increment, got)
+		}
+	}
+	if requests := after["github_profile_github_requests_total"] - before["github_profile_github_requests_total"]; requests < 2 {
+		t.Errorf("Expected at least 2 GitHub requests, got %v", requests)
+	}
+	if remaining := after["github_profile_rate_limit_remaining"]; remaining != 4990 {
+		t.Errorf("Expected the remaining rate limit from the last response, got %v", remaining)
+	}
+}
@@ -0,0 +1,54 @@
+package profile
+
+import "strings"
+
+const (
+	monorepoByteShare    = 0.6  // share of all bytes above which a many-language repository counts as a monorepo
+	monorepoMinLanguages = 5    // languages a repository needs before its byte share marks it as a monorepo
+	monorepoWeight       = 0.25 // weight of monorepo bytes in language percentages with -monorepo-aware
+)
+
+// SetMonorepoAware down-weights repositories detected as monorepos when computing language
+// percentages, so a single large repository does not dominate them
+func (a *Analyzer) SetMonorepoAware(enabled bool) {
+	a.monorepoAware = enabled
+}
+
+// detectMonorepos flags repositories that look like monorepos: those holding more than
+// monorepoByteShare of all bytes across monorepoMinLanguages or more languages, and those
+// whose name or topics mention "monorepo"
+func detectMonorepos(repos []RepositoryProfile) {
+	totalBytes := 0
+	for _, repo := range repos {
+		for _, bytes := range repo.Languages {
+			totalBytes += bytes
+		}
+	}
+
+	for i := range repos {
+		repo := &repos[i]
+		repo.IsMonorepo = strings.Contains(strings.ToLower(repo.Name), "monorepo")
+		for _, topic := range repo.Topics {
+			if strings.Contains(strings.ToLower(topic), "monorepo") {
+				repo.IsMonorepo = true
+			}
+		}
+
+		if repo.IsMonorepo || totalBytes == 0 || len(repo.Languages) < monorepoMinLanguages {
+			continue
+		}
+		repoBytes := 0
+		for _, bytes := range repo.Languages {
+			repoBytes += bytes
+		}
+		repo.IsMonorepo = float64(repoBytes)/float64(totalBytes) > monorepoByteShare
+	}
+}
+
+// languageWeight is the weight of a repository's bytes in language percentages
+func (a *Analyzer) languageWeight(repo RepositoryProfile) float64 {
+	if a.monorepoAware && repo.IsMonorepo {
+		return monorepoWeight
+	}
+	return 1
+}
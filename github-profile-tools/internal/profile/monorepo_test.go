@@ -0,0 +1,79 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+// monorepoFixture returns a monorepo holding most bytes across six languages next to two small Go repositories
+func monorepoFixture() *UserProfile {
+	created := time.Now().AddDate(-2, 0, 0)
+	return &UserProfile{
+		Username: "octocat",
+		Repositories: []RepositoryProfile{
+			{Name: "platform", CreatedAt: created, UpdatedAt: created, Languages: map[string]int{
+				"TypeScript": 600000, "Java": 200000, "Python": 100000, "Shell": 50000, "Dockerfile": 25000, "Go": 25000,
+			}},
+			{Name: "cli", CreatedAt: created, UpdatedAt: created, Languages: map[string]int{"Go": 100000}},
+			{Name: "api", CreatedAt: created, UpdatedAt: created, Languages: map[string]int{"Go": 100000}},
+		},
+	}
+}
+
+// languagePercentage returns the percentage of language in prof, or zero
+func languagePercentage(prof *UserProfile, language string) float64 {
+	for _, lang := range prof.Languages {
+		if lang.Language == language {
+			return lang.Percentage
+		}
+	}
+	return 0
+}
+
+// TestMonorepoDetection verifies the byte share and naming heuristics
+func TestMonorepoDetection(t *testing.T) {
+	prof := monorepoFixture()
+	prof.Repositories = append(prof.Repositories, RepositoryProfile{Name: "tools", Topics: []string{"Monorepo"}})
+
+	detectMonorepos(prof.Repositories)
+
+	for i, expected := range []bool{true, false, false, true} {
+		if prof.Repositories[i].IsMonorepo != expected {
+			t.Errorf("Expected %s IsMonorepo=%v", prof.Repositories[i].Name, expected)
+		}
+	}
+}
+
+// TestMonorepoAwareLanguagePercentages verifies -monorepo-aware shrinks the share of languages found only
+// in a monorepo, and that monorepos are tagged either way
+func TestMonorepoAwareLanguagePercentages(t *testing.T) {
+	plain := monorepoFixture()
+	(&Analyzer{}).analyzeLanguages(plain)
+
+	aware := monorepoFixture()
+	analyzer := &Analyzer{}
+	analyzer.SetMonorepoAware(true)
+	analyzer.analyzeLanguages(aware)
+
+	if !plain.Repositories[0].IsMonorepo || !aware.Repositories[0].IsMonorepo {
+		t.Error("Expected the platform repository to be tagged as a monorepo")
+	}
+
+	// 600k of 1.2M bytes unweighted; 150k of 0.45M weighted
+	if got := languagePercentage(plain, "TypeScript"); got < 49.9 || got > 50.1 {
+		t.Errorf("Expected TypeScript at 50%% without -monorepo-aware, got %.1f%%", got)
+	}
+	if got := languagePercentage(aware, "TypeScript"); got > 34 {
+		t.Errorf("Expected TypeScript down-weighted below 34%%, got %.1f%%", got)
+	}
+	if plainGo, awareGo := languagePercentage(plain, "Go"), languagePercentage(aware, "Go"); awareGo <= plainGo {
+		t.Errorf("Expected Go to gain share with -monorepo-aware, got %.1f%% -> %.1f%%", plainGo, awareGo)
+	}
+
+	// Byte counts are not weighted
+	for _, lang := range aware.Languages {
+		if lang.Language == "TypeScript" && lang.Bytes != 600000 {
+			t.Errorf("Expected raw TypeScript bytes to be kept, got %d", lang.Bytes)
+		}
+	}
+}
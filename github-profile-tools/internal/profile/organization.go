@@ -0,0 +1,128 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+// AnalyzeOrganization analyzes the public repositories of a GitHub organization. The result reuses
+// UserProfile: Username holds the organization login, Bio its description, and Contributions the
+// commits, pull requests and open issues summed over its repositories.
+func (a *Analyzer) AnalyzeOrganization(ctx context.Context, org string) (*UserProfile, error) {
+	logging.Infof("Starting analysis for organization: %s", org)
+
+	profile := &UserProfile{
+		SchemaVersion:  ProfileSchemaVersion,
+		Username:       org,
+		LastAnalyzed:   time.Now(),
+		IsOrganization: true,
+	}
+
+	if err := a.fetchOrganizationInfo(ctx, org, profile); err != nil {
+		return nil, err
+	}
+	if err := a.fetchOrganizationRepositories(ctx, org, profile); err != nil {
+		return nil, fmt.Errorf("failed to fetch organization repositories: %w", err)
+	}
+
+	for _, repo := range profile.Repositories {
+		profile.Contributions.TotalCommits += repo.ContributionStats.Commits
+		profile.Contributions.TotalPullRequests += repo.ContributionStats.PullRequests
+		profile.Contributions.TotalIssues += repo.OpenIssues
+	}
+
+	a.analyzeLanguages(profile)
+
+	logging.Infof("Organization analysis complete for %s: %d repositories", org, len(profile.Repositories))
+	return profile, nil
+}
+
+// fetchOrganizationInfo fetches the organization's description and membership
+func (a *Analyzer) fetchOrganizationInfo(ctx context.Context, org string, profile *UserProfile) error {
+	req := &github.GraphQLRequest{
+		Query: github.OrganizationProfileQuery,
+		Variables: map[string]interface{}{
+			"login": org,
+		},
+	}
+
+	var resp github.OrganizationProfileResponse
+	if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
+		if github.IsNotFoundError(err) {
+			return fmt.Errorf("%w: %s", github.ErrOrganizationNotFound, org)
+		}
+		return fmt.Errorf("GraphQL query failed: %w", err)
+	}
+	if resp.Organization == nil {
+		return fmt.Errorf("%w: %s", github.ErrOrganizationNotFound, org)
+	}
+
+	info := resp.Organization
+	profile.Name = info.Name
+	profile.Bio = info.Description
+	profile.Location = info.Location
+	profile.Email = info.Email
+	profile.BlogURL = info.WebsiteUrl
+	profile.AvatarURL = info.AvatarUrl
+	profile.CreatedAt = info.CreatedAt
+	profile.UpdatedAt = info.UpdatedAt
+	profile.PublicRepos = info.Repositories.TotalCount
+	profile.MemberCount = info.MembersWithRole.TotalCount
+	return nil
+}
+
+// fetchOrganizationRepositories fetches the public repositories of the organization, most starred first,
+// up to the -max-repos cap
+func (a *Analyzer) fetchOrganizationRepositories(ctx context.Context, org string, profile *UserProfile) error {
+	var cursor string
+	pageSize := a.repositoryPageSize()
+
+	for pageNum := 1; ; pageNum++ {
+		if a.repositoryCapReached(len(profile.Repositories)) {
+			logging.Warnf("Repository cap reached: analyzing the first %d repositories of %s, skipping the rest (-max-repos)", a.maxRepos, org)
+			return nil
+		}
+		if a.maxRepos > 0 {
+			pageSize = min(pageSize, a.maxRepos-len(profile.Repositories))
+		}
+
+		req := &github.GraphQLRequest{
+			Query: github.OrganizationRepositoriesQuery,
+			Variables: map[string]interface{}{
+				"login": org,
+				"first": pageSize,
+				"after": cursor,
+			},
+		}
+
+		var resp github.OrganizationRepositoriesResponse
+		if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
+			return err
+		}
+
+		for _, node := range resp.Organization.Repositories.Nodes {
+			if a.repositoryCapReached(len(profile.Repositories)) {
+				break
+			}
+			repo := a.convertRepositoryNode(ctx, node.RepositoryNode, org)
+			if node.DefaultBranchRef != nil && node.DefaultBranchRef.Target.History != nil {
+				repo.ContributionStats.Commits = node.DefaultBranchRef.Target.History.TotalCount
+			}
+			if node.PullRequests != nil {
+				repo.ContributionStats.PullRequests = node.PullRequests.TotalCount
+			}
+			profile.Repositories = append(profile.Repositories, repo)
+		}
+		logging.Infof("Processed organization repository page %d (%d total fetched)", pageNum, len(profile.Repositories))
+
+		pageInfo := resp.Organization.Repositories.PageInfo
+		if !pageInfo.HasNextPage {
+			return nil
+		}
+		cursor = pageInfo.EndCursor
+	}
+}
@@ -0,0 +1,135 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const progressFileSuffix = "_progress.json"
+
+// ProgressInfo summarizes a saved progress file without loading the partial profile
+type ProgressInfo struct {
+	Path              string    `json:"path"`
+	Username          string    `json:"username"`
+	DockerUsername    string    `json:"docker_username,omitempty"`
+	DiscourseUsername string    `json:"discourse_username,omitempty"`
+	LastStep          int       `json:"last_step"`
+	SavedAt           time.Time `json:"saved_at"`
+}
+
+// Age returns how long ago the progress was saved
+func (p ProgressInfo) Age() time.Duration {
+	return time.Since(p.SavedAt)
+}
+
+// ListProgress returns the saved progress files in the progress directory, sorted by username
+func (a *Analyzer) ListProgress() ([]ProgressInfo, error) {
+	entries, err := os.ReadDir(a.saveProgressDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read progress directory: %w", err)
+	}
+
+	var progress []ProgressInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), progressFileSuffix) {
+			continue
+		}
+
+		path := filepath.Join(a.saveProgressDir, entry.Name())
+		info, err := readProgressInfo(path)
+		if err != nil {
+			log.Printf("Warning: Skipping unreadable progress file %s: %v", path, err)
+			continue
+		}
+		progress = append(progress, info)
+	}
+
+	sort.Slice(progress, func(i, j int) bool {
+		if progress[i].Username != progress[j].Username {
+			return progress[i].Username < progress[j].Username
+		}
+		return progress[i].Path < progress[j].Path
+	})
+
+	return progress, nil
+}
+
+// ClearProgress removes the progress files of a user (including files scoped by custom
+// Docker/Discourse usernames), or every progress file when username is "all".
+// It returns the number of files removed.
+func (a *Analyzer) ClearProgress(username string) (int, error) {
+	entries, err := os.ReadDir(a.saveProgressDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read progress directory: %w", err)
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, progressFileSuffix) {
+			continue
+		}
+
+		path := filepath.Join(a.saveProgressDir, name)
+		if username != "all" && !progressFileBelongsTo(path, name, username) {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove progress file %s: %w", path, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// progressFileBelongsTo reports whether a progress file was saved for username,
+// falling back to the filename when the file cannot be parsed
+func progressFileBelongsTo(path, name, username string) bool {
+	if info, err := readProgressInfo(path); err == nil {
+		return info.Username == username
+	}
+	return name == username+progressFileSuffix || strings.HasPrefix(name, username+"_docker_")
+}
+
+// readProgressInfo reads the metadata of a progress file
+func readProgressInfo(path string) (ProgressInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ProgressInfo{}, err
+	}
+
+	// Decode only the metadata, not the (potentially large) partial profile
+	var header struct {
+		Username          string    `json:"username"`
+		DockerUsername    string    `json:"dockerUsername"`
+		DiscourseUsername string    `json:"discourseUsername"`
+		LastStep          int       `json:"lastStep"`
+		SavedAt           time.Time `json:"savedAt"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return ProgressInfo{}, fmt.Errorf("failed to parse progress file: %w", err)
+	}
+
+	return ProgressInfo{
+		Path:              path,
+		Username:          header.Username,
+		DockerUsername:    header.DockerUsername,
+		DiscourseUsername: header.DiscourseUsername,
+		LastStep:          header.LastStep,
+		SavedAt:           header.SavedAt,
+	}, nil
+}
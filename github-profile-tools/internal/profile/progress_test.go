@@ -0,0 +1,174 @@
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newProgressTestAnalyzer creates an analyzer writing progress files to a temporary directory
+func newProgressTestAnalyzer(t *testing.T) *Analyzer {
+	t.Helper()
+
+	analyzer := &Analyzer{saveProgressDir: t.TempDir()}
+
+	fixtures := []struct {
+		username, docker, discourse string
+		step                        int
+	}{
+		{"alice", "", "", 3},
+		{"alice", "alice-docker", "", 5},
+		{"bob", "", "", 7},
+	}
+	for _, f := range fixtures {
+		if err := analyzer.saveProgress(f.username, f.docker, f.discourse, &UserProfile{Username: f.username}, f.step); err != nil {
+			t.Fatalf("Failed to write progress fixture: %v", err)
+		}
+	}
+
+	// Unrelated files must be ignored
+	if err := os.WriteFile(filepath.Join(analyzer.saveProgressDir, "notes.txt"), []byte("keep"), 0644); err != nil {
+		t.Fatalf("Failed to write unrelated file: %v", err)
+	}
+
+	return analyzer
+}
+
+// TestListProgress verifies saved progress files are listed with username, step and age
+func TestListProgress(t *testing.T) {
+	analyzer := newProgressTestAnalyzer(t)
+
+	progress, err := analyzer.ListProgress()
+	if err != nil {
+		t.Fatalf("ListProgress failed: %v", err)
+	}
+
+	if len(progress) != 3 {
+		t.Fatalf("Expected 3 progress files, got %d", len(progress))
+	}
+	if progress[0].Username != "alice" || progress[2].Username != "bob" {
+		t.Errorf("Expected progress sorted by username, got %s, %s, %s",
+			progress[0].Username, progress[1].Username, progress[2].Username)
+	}
+	if progress[2].LastStep != 7 {
+		t.Errorf("Expected bob's last step to be 7, got %d", progress[2].LastStep)
+	}
+	for _, p := range progress {
+		if p.Age() < 0 || p.Age() > time.Minute {
+			t.Errorf("Unexpected age %s for %s", p.Age(), p.Path)
+		}
+	}
+}
+
+// TestListProgressMissingDirectory verifies a missing progress directory is not an error
+func TestListProgressMissingDirectory(t *testing.T) {
+	analyzer := &Analyzer{saveProgressDir: filepath.Join(t.TempDir(), "missing")}
+
+	progress, err := analyzer.ListProgress()
+	if err != nil {
+		t.Fatalf("ListProgress failed: %v", err)
+	}
+	if len(progress) != 0 {
+		t.Errorf("Expected no progress files, got %d", len(progress))
+	}
+}
+
+// TestClearProgressForUser verifies only the given user's progress files, scoped or not, are removed
+func TestClearProgressForUser(t *testing.T) {
+	analyzer := newProgressTestAnalyzer(t)
+
+	removed, err := analyzer.ClearProgress("alice")
+	if err != nil {
+		t.Fatalf("ClearProgress failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 files removed for alice, got %d", removed)
+	}
+
+	progress, _ := analyzer.ListProgress()
+	if len(progress) != 1 || progress[0].Username != "bob" {
+		t.Errorf("Expected only bob's progress to remain, got %+v", progress)
+	}
+
+	if _, err := os.Stat(filepath.Join(analyzer.saveProgressDir, "notes.txt")); err != nil {
+		t.Errorf("Expected unrelated file to be kept: %v", err)
+	}
+}
+
+// TestClearProgressAll verifies "all" removes every progress file
+func TestClearProgressAll(t *testing.T) {
+	analyzer := newProgressTestAnalyzer(t)
+
+	removed, err := analyzer.ClearProgress("all")
+	if err != nil {
+		t.Fatalf("ClearProgress failed: %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("Expected 3 files removed, got %d", removed)
+	}
+
+	progress, _ := analyzer.ListProgress()
+	if len(progress) != 0 {
+		t.Errorf("Expected no progress files left, got %d", len(progress))
+	}
+}
+
+// writeProgressSavedAt writes a progress file for username that was saved age ago
+func writeProgressSavedAt(t *testing.T, analyzer *Analyzer, username string, age time.Duration) {
+	t.Helper()
+
+	data, err := json.Marshal(ProgressData{
+		Username:      username,
+		LastStep:      4,
+		SavedAt:       time.Now().Add(-age),
+		SchemaVersion: ProfileSchemaVersion,
+		UserProfile:   &UserProfile{Username: username},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal progress: %v", err)
+	}
+	if err := os.WriteFile(analyzer.getProgressFilename(username, "", ""), data, 0644); err != nil {
+		t.Fatalf("Failed to write progress: %v", err)
+	}
+}
+
+// TestProgressMaxAge verifies progress just under the configured age is resumed and just over is discarded
+func TestProgressMaxAge(t *testing.T) {
+	analyzer := &Analyzer{saveProgressDir: t.TempDir()}
+	analyzer.SetProgressMaxAge(48 * time.Hour)
+
+	writeProgressSavedAt(t, analyzer, "fresh", 47*time.Hour)
+	if profile, step := analyzer.tryResumeProgress("fresh", "", ""); profile == nil || step != 5 {
+		t.Errorf("Expected progress saved 47h ago to resume at step 5, got step %d", step)
+	}
+
+	writeProgressSavedAt(t, analyzer, "stale", 49*time.Hour)
+	if profile, step := analyzer.tryResumeProgress("stale", "", ""); profile != nil || step != 1 {
+		t.Errorf("Expected progress saved 49h ago to be discarded, got step %d", step)
+	}
+	if _, err := os.Stat(analyzer.getProgressFilename("stale", "", "")); !os.IsNotExist(err) {
+		t.Errorf("Expected stale progress file to be removed")
+	}
+}
+
+// TestAnalysisMaxAge verifies completed analyses are reused only within the configured age
+func TestAnalysisMaxAge(t *testing.T) {
+	analyzer := &Analyzer{cacheDir: t.TempDir()}
+	analyzer.SetAnalysisMaxAge(time.Hour)
+
+	if err := analyzer.saveToCache("recent", &UserProfile{Username: "recent", LastAnalyzed: time.Now().Add(-59 * time.Minute)}); err != nil {
+		t.Fatalf("Failed to save analysis: %v", err)
+	}
+	if err := analyzer.saveToCache("old", &UserProfile{Username: "old", LastAnalyzed: time.Now().Add(-61 * time.Minute)}); err != nil {
+		t.Fatalf("Failed to save analysis: %v", err)
+	}
+
+	if analyzer.tryLoadFromCache("recent") == nil {
+		t.Error("Expected analysis within max age to be reused")
+	}
+	if analyzer.tryLoadFromCache("old") != nil {
+		t.Error("Expected analysis past max age to be ignored")
+	}
+}
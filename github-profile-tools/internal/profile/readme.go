@@ -0,0 +1,122 @@
+package profile
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+// DefaultEnrichLimit is the number of notable repositories whose README is fetched by EnrichDescriptions
+const DefaultEnrichLimit = 10
+
+// maxReadmeSummaryLength caps README excerpts so they read like a repository description
+const maxReadmeSummaryLength = 300
+
+// markdownLinkPattern matches inline markdown links, keeping their text
+var markdownLinkPattern = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+
+// DisplayDescription returns the repository description, or the README excerpt when it is empty
+func (r RepositoryProfile) DisplayDescription() string {
+	if r.Description != "" {
+		return r.Description
+	}
+	return r.ReadmeSummary
+}
+
+// EnrichDescriptions fills ReadmeSummary for up to limit notable repositories without a description,
+// using the first paragraph of their README. Repositories are ranked by stars, then size, and
+// README downloads go through the rate-limited GitHub client. Failures only leave a repository bare.
+func (a *Analyzer) EnrichDescriptions(ctx context.Context, profile *UserProfile, limit int) {
+	var candidates []int
+	for i, repo := range profile.Repositories {
+		// Same notability rule as the templates' Notable Projects section
+		if repo.Description == "" && (repo.Stars > 0 || repo.IsOwner || repo.Size > 1000) {
+			candidates = append(candidates, i)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := profile.Repositories[candidates[i]], profile.Repositories[candidates[j]]
+		if ri.Stars != rj.Stars {
+			return ri.Stars > rj.Stars
+		}
+		return ri.Size > rj.Size
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	for _, i := range candidates {
+		repo := &profile.Repositories[i]
+		owner, name, ok := strings.Cut(repo.FullName, "/")
+		if !ok {
+			continue
+		}
+
+		readme, err := a.client.FetchReadme(ctx, owner, name)
+		if err != nil {
+			logging.Warnf("Failed to fetch README for %s: %v", repo.FullName, err)
+			continue
+		}
+		repo.ReadmeSummary = readmeSummary(readme)
+		if repo.ReadmeSummary != "" {
+			logging.Debugf("Using README excerpt as description of %s", repo.FullName)
+		}
+	}
+}
+
+// readmeSummary extracts the first prose paragraph of a markdown README, skipping headings,
+// badges, images, HTML and code blocks, and flattening links to their text
+func readmeSummary(readme string) string {
+	var paragraph []string
+	inCodeBlock := false
+
+	for _, line := range strings.Split(readme, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		if trimmed == "" {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+
+		// Setext heading underlines end the heading text collected so far
+		if strings.Trim(trimmed, "=-") == "" {
+			paragraph = nil
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "<") ||
+			strings.HasPrefix(trimmed, "![") || strings.HasPrefix(trimmed, "[![") ||
+			strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, ">") {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+
+		paragraph = append(paragraph, trimmed)
+	}
+
+	summary := markdownLinkPattern.ReplaceAllString(strings.Join(paragraph, " "), "$1")
+	if len(summary) > maxReadmeSummaryLength {
+		cut := strings.LastIndex(summary[:maxReadmeSummaryLength], " ")
+		if cut <= 0 {
+			cut = maxReadmeSummaryLength
+		}
+		summary = summary[:cut] + "..."
+	}
+	return summary
+}
@@ -0,0 +1,45 @@
+package profile
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultRecencyHalfLife is the number of years after its last use at which a technology's weight halves
+const DefaultRecencyHalfLife = 3.0
+
+// SetRecencyHalfLife sets how quickly skills and language proficiency decay when unused, in years.
+// Zero disables recency weighting.
+func (a *Analyzer) SetRecencyHalfLife(years float64) {
+	a.recencyHalfLife = years
+}
+
+// recencyFactor returns a weight in (0, 1] that halves for every half-life elapsed since lastUsed
+func (a *Analyzer) recencyFactor(lastUsed time.Time) float64 {
+	return halfLifeFactor(lastUsed, a.recencyHalfLife)
+}
+
+// SetStarDecay sets the number of years since a repository's last push at which its stars count half
+// in the impact score, so abandoned popular projects weigh less than active ones. Zero disables decay.
+func (a *Analyzer) SetStarDecay(years float64) {
+	a.starDecayHalfLife = years
+}
+
+// DecayedStars returns the repository's stars, halved for every halfLife years elapsed since its last
+// push. A zero halfLife returns the stars unchanged.
+func (r RepositoryProfile) DecayedStars(halfLife float64) float64 {
+	return float64(r.Stars) * halfLifeFactor(r.PushedAt, halfLife)
+}
+
+// halfLifeFactor returns a weight in (0, 1] that halves for every halfLife years elapsed since t
+func halfLifeFactor(t time.Time, halfLife float64) float64 {
+	if halfLife <= 0 || t.IsZero() {
+		return 1
+	}
+
+	years := time.Since(t).Hours() / (24 * 365.25)
+	if years <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, years/halfLife)
+}
@@ -0,0 +1,86 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRecencyRanksCurrentSkillHigher verifies a recently used technology outranks an equally prevalent stale one
+func TestRecencyRanksCurrentSkillHigher(t *testing.T) {
+	analyzer := &Analyzer{recencyHalfLife: DefaultRecencyHalfLife}
+	techMap := make(map[string]*TechnologySkill)
+	var skills []TechnologySkill
+
+	// Both technologies appear in two repositories; only their last push differs
+	lastUsed := map[string]time.Time{
+		"current": time.Now().AddDate(0, -1, 0),
+		"stale":   time.Now().AddDate(-5, 0, 0),
+	}
+	for name, updatedAt := range lastUsed {
+		for _, repoName := range []string{"one", "two"} {
+			repo := RepositoryProfile{
+				FullName:  name + "/" + repoName,
+				CreatedAt: updatedAt.AddDate(-1, 0, 0),
+				UpdatedAt: updatedAt,
+			}
+			analyzer.addTechnologySkill(name, "framework", repo, techMap, &skills)
+		}
+	}
+
+	if techMap["current"].Confidence <= techMap["stale"].Confidence {
+		t.Errorf("Expected current skill (%.2f) to outrank stale skill (%.2f)",
+			techMap["current"].Confidence, techMap["stale"].Confidence)
+	}
+}
+
+// TestRecencyLanguageProficiency verifies language proficiency decays when a language is no longer used
+func TestRecencyLanguageProficiency(t *testing.T) {
+	analyzer := &Analyzer{recencyHalfLife: DefaultRecencyHalfLife}
+	created := time.Now().AddDate(-8, 0, 0)
+	profile := &UserProfile{
+		Username: "testuser",
+		Repositories: []RepositoryProfile{
+			{FullName: "testuser/new", CreatedAt: created, UpdatedAt: time.Now(), Languages: map[string]int{"Go": 1000}},
+			{FullName: "testuser/old", CreatedAt: created, UpdatedAt: time.Now().AddDate(-6, 0, 0), Languages: map[string]int{"Perl": 1000}},
+		},
+	}
+
+	analyzer.analyzeLanguages(profile)
+
+	scores := make(map[string]float64)
+	for _, lang := range profile.Languages {
+		scores[lang.Language] = lang.ProficiencyScore
+	}
+	if scores["Go"] <= scores["Perl"] {
+		t.Errorf("Expected Go (%.2f) to outrank Perl (%.2f)", scores["Go"], scores["Perl"])
+	}
+}
+
+// TestRecencyFactorDisabled verifies a zero half-life leaves scores unchanged
+func TestRecencyFactorDisabled(t *testing.T) {
+	analyzer := &Analyzer{}
+	if factor := analyzer.recencyFactor(time.Now().AddDate(-10, 0, 0)); factor != 1 {
+		t.Errorf("Expected factor 1 when disabled, got %v", factor)
+	}
+
+	analyzer.SetRecencyHalfLife(3)
+	if factor := analyzer.recencyFactor(time.Now().AddDate(-3, 0, 0)); factor < 0.49 || factor > 0.51 {
+		t.Errorf("Expected factor 0.5 after one half-life, got %v", factor)
+	}
+}
+
+// TestStarDecayLowersStaleImpact verifies -star-decay lowers the impact of stars on abandoned repositories
+func TestStarDecayLowersStaleImpact(t *testing.T) {
+	stale := &UserProfile{Repositories: []RepositoryProfile{{Name: "legacy", Stars: 500, PushedAt: time.Now().AddDate(-8, 0, 0)}}}
+
+	analyzer := &Analyzer{}
+	undecayed := analyzer.calculateImpactScore(stale)
+
+	analyzer.SetStarDecay(2)
+	if decayed := analyzer.calculateImpactScore(stale); decayed >= undecayed {
+		t.Errorf("Expected decayed impact below %.3f, got %.3f", undecayed, decayed)
+	}
+	if stars := stale.Repositories[0].DecayedStars(0); stars != 500 {
+		t.Errorf("Expected 500 stars without decay, got %v", stars)
+	}
+}
@@ -0,0 +1,505 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+)
+
+// TestRepoPageSizePassedToQuery verifies the configured page size is sent as the GraphQL `first` argument
+func TestRepoPageSizePassedToQuery(t *testing.T) {
+	var first interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req github.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode GraphQL request: %v", err)
+		}
+		first = req.Variables["first"]
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"user":{"repositories":{"pageInfo":{"hasNextPage":false,"endCursor":""},"nodes":[]}}}}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	analyzer.saveProgressDir = t.TempDir()
+	analyzer.cacheDir = t.TempDir()
+	analyzer.SetRepoPageSize(17)
+
+	if err := analyzer.fetchUserRepositories(context.Background(), "testuser", "testuser", "", &UserProfile{}); err != nil {
+		t.Fatalf("fetchUserRepositories failed: %v", err)
+	}
+
+	// Variables are decoded as JSON numbers
+	if first != float64(17) {
+		t.Errorf("Expected first = 17, got %v", first)
+	}
+}
+
+// TestRepoPageSizeFallback verifies out-of-range page sizes fall back to the default
+func TestRepoPageSizeFallback(t *testing.T) {
+	for _, size := range []int{0, -1, MaxRepoPageSize + 1} {
+		analyzer := &Analyzer{}
+		analyzer.SetRepoPageSize(size)
+		if got := analyzer.repositoryPageSize(); got != DefaultRepoPageSize {
+			t.Errorf("Page size %d: expected default %d, got %d", size, DefaultRepoPageSize, got)
+		}
+	}
+
+	analyzer := &Analyzer{}
+	analyzer.SetRepoPageSize(MaxRepoPageSize)
+	if got := analyzer.repositoryPageSize(); got != MaxRepoPageSize {
+		t.Errorf("Expected page size %d, got %d", MaxRepoPageSize, got)
+	}
+}
+
+// TestFetchUserBasicInfoNotFound verifies GitHub's NOT_FOUND error for an unknown login maps to ErrUserNotFound
+func TestFetchUserBasicInfoNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"user":null},"errors":[{"type":"NOT_FOUND","path":["user"],"message":"Could not resolve to a User with the login of 'nobody-here'."}]}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+
+	err := analyzer.fetchUserBasicInfo(context.Background(), "nobody-here", &UserProfile{})
+	if !errors.Is(err, github.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+// TestFetchUserBasicInfoRESTFallback verifies the REST user endpoint supplies the basics when the
+// GraphQL query fails, e.g. for a token lacking a scope
+func TestFetchUserBasicInfoRESTFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/users/octocat" {
+			w.Write([]byte(`{"login":"octocat","name":"The Octocat","bio":"Mascot","company":"@github","location":"San Francisco","followers":42,"public_repos":8}`))
+			return
+		}
+		w.Write([]byte(`{"data":null,"errors":[{"type":"INSUFFICIENT_SCOPES","message":"Your token has not been granted the required scopes."}]}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+
+	profile := &UserProfile{Username: "octocat"}
+	if err := analyzer.fetchUserBasicInfo(context.Background(), "octocat", profile); err != nil {
+		t.Fatalf("Expected the REST fallback to succeed, got %v", err)
+	}
+	if profile.Name != "The Octocat" || profile.Bio != "Mascot" || profile.Company != "@github" ||
+		profile.Location != "San Francisco" || profile.Followers != 42 || profile.PublicRepos != 8 {
+		t.Errorf("Expected the REST basics, got %+v", profile)
+	}
+}
+
+// TestMaxReposStopsPagination verifies pagination halts once the cap is reached, requesting no more
+// repositories than it leaves room for, and that skills are computed over the capped set
+func TestMaxReposStopsPagination(t *testing.T) {
+	var firsts []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/repos/") {
+			w.Write([]byte(`[]`))
+			return
+		}
+
+		var req github.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode GraphQL request: %v", err)
+		}
+		firsts = append(firsts, req.Variables["first"])
+
+		// Every page claims more repositories follow
+		page := len(firsts)
+		w.Write([]byte(fmt.Sprintf(`{"data":{"user":{"repositories":{"pageInfo":{"hasNextPage":true,"endCursor":"page%d"},"nodes":[
+			{"name":"repo%da","nameWithOwner":"testuser/repo%da","owner":{"login":"testuser"},
+			 "languages":{"nodes":[{"name":"Go"}],"edges":[{"size":5000}]}},
+			{"name":"repo%db","nameWithOwner":"testuser/repo%db","owner":{"login":"testuser"},
+			 "languages":{"nodes":[{"name":"Go"}],"edges":[{"size":5000}]}}
+		]}}}}`, page, page, page, page, page)))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	analyzer.saveProgressDir = t.TempDir()
+	analyzer.cacheDir = t.TempDir()
+	analyzer.SetRepoPageSize(2)
+	analyzer.SetMaxRepos(3)
+
+	prof := &UserProfile{Username: "testuser"}
+	if err := analyzer.fetchUserRepositories(context.Background(), "testuser", "testuser", "", prof); err != nil {
+		t.Fatalf("fetchUserRepositories failed: %v", err)
+	}
+
+	if len(prof.Repositories) != 3 {
+		t.Errorf("Expected 3 repositories, got %d", len(prof.Repositories))
+	}
+	// Variables are decoded as JSON numbers
+	if !reflect.DeepEqual(firsts, []interface{}{float64(2), float64(1)}) {
+		t.Errorf("Expected pages of 2 then 1 repositories, got %v", firsts)
+	}
+
+	analyzer.analyzeLanguages(prof)
+	if len(prof.Languages) != 1 || prof.Languages[0].RepositoryCount != 3 {
+		t.Errorf("Expected Go across the 3 capped repositories, got %+v", prof.Languages)
+	}
+	if !reflect.DeepEqual(prof.Skills.PrimaryLanguages, []string{"Go"}) {
+		t.Errorf("Expected Go as primary language, got %v", prof.Skills.PrimaryLanguages)
+	}
+}
+
+// TestLanguageFallback verifies a repository without GraphQL languages is populated from the REST
+// languages endpoint, and only when the fallback is enabled
+func TestLanguageFallback(t *testing.T) {
+	languageCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octocat/hello-world/languages" {
+			http.NotFound(w, r)
+			return
+		}
+		languageCalls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Go": 4096, "Shell": 512}`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	node := github.RepositoryNode{Name: "hello-world", NameWithOwner: "octocat/hello-world"}
+
+	repo := analyzer.convertRepositoryNode(context.Background(), node, "octocat")
+	if len(repo.Languages) != 0 || languageCalls != 0 {
+		t.Errorf("Expected no REST languages lookup without the fallback, got %v after %d calls", repo.Languages, languageCalls)
+	}
+
+	analyzer.SetLanguageFallback(true)
+	repo = analyzer.convertRepositoryNode(context.Background(), node, "octocat")
+	if repo.Languages["Go"] != 4096 || repo.Languages["Shell"] != 512 {
+		t.Errorf("Expected languages from the REST endpoint, got %v", repo.Languages)
+	}
+	if repo.Language != "Go" {
+		t.Errorf("Expected the largest language to become the primary one, got %q", repo.Language)
+	}
+	if languageCalls != 1 {
+		t.Errorf("Expected 1 REST languages call, got %d", languageCalls)
+	}
+}
+
+// TestAnalyzeCIConfig verifies a workflows directory and a Jenkinsfile are detected along with test tooling
+func TestAnalyzeCIConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/testuser/tool/contents":
+			w.Write([]byte(`[
+				{"name": ".github", "type": "dir"},
+				{"name": "Jenkinsfile", "type": "file"},
+				{"name": "main_test.go", "type": "file"},
+				{"name": "codecov.yml", "type": "file"},
+				{"name": "Dockerfile", "type": "file", "size": 500}
+			]`))
+		case "/repos/testuser/tool/contents/.github":
+			w.Write([]byte(`[{"name": "workflows", "type": "dir"}, {"name": "dependabot.yml", "type": "file"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	repo := RepositoryProfile{FullName: "testuser/tool"}
+	analyzer.analyzeRepositoryContents(context.Background(), &repo)
+	dockerConfig, ciConfig := repo.DockerConfig, repo.CIConfig
+
+	if dockerConfig == nil || !dockerConfig.HasDockerfile {
+		t.Errorf("Expected the Dockerfile to be detected from the same listing, got %+v", dockerConfig)
+	}
+	if ciConfig == nil {
+		t.Fatal("Expected a CI configuration")
+	}
+	if !reflect.DeepEqual(ciConfig.CISystems, []string{"Jenkins", "GitHub Actions"}) {
+		t.Errorf("Expected Jenkins and GitHub Actions, got %v", ciConfig.CISystems)
+	}
+	if !reflect.DeepEqual(ciConfig.TestFrameworks, []string{"Go test"}) || !ciConfig.HasCoverage {
+		t.Errorf("Expected Go tests with coverage, got %+v", ciConfig)
+	}
+}
+
+// TestAnalyzeCIConfigNone verifies repositories without CI or tests get no configuration
+func TestAnalyzeCIConfigNone(t *testing.T) {
+	analyzer := &Analyzer{}
+	contents := []github.RepositoryContentResponse{{Name: "README.md", Type: "file"}, {Name: "src", Type: "dir"}}
+	if config := analyzer.analyzeCIConfig(context.Background(), "testuser", "tool", contents); config != nil {
+		t.Errorf("Expected no CI configuration, got %+v", config)
+	}
+}
+
+// TestCIMaturityAreas verifies CI/CD and Testing areas scale with the number of repositories and
+// are no longer reported as growth areas
+func TestCIMaturityAreas(t *testing.T) {
+	profile := &UserProfile{Repositories: []RepositoryProfile{
+		{FullName: "testuser/a", CIConfig: &CIConfig{CISystems: []string{"GitHub Actions"}, TestFrameworks: []string{"Go test"}}},
+		{FullName: "testuser/b", CIConfig: &CIConfig{CISystems: []string{"Jenkins", "GitHub Actions"}}},
+		{FullName: "testuser/c"},
+	}}
+
+	analyzer := &Analyzer{}
+	analyzer.analyzeSkills(profile)
+
+	areas := make(map[string]TechnicalArea)
+	for _, area := range profile.Skills.TechnicalAreas {
+		areas[area.Area] = area
+	}
+
+	ci, ok := areas[ciArea]
+	if !ok || ci.ProjectCount != 2 || ci.Competency != 2*maturityCompetencyPerRepo {
+		t.Errorf("Unexpected CI/CD area: %+v", ci)
+	}
+	if !reflect.DeepEqual(ci.Technologies, []string{"GitHub Actions", "Jenkins"}) {
+		t.Errorf("Expected both CI systems, got %v", ci.Technologies)
+	}
+	if tests, ok := areas[testingArea]; !ok || tests.ProjectCount != 1 {
+		t.Errorf("Unexpected Testing area: %+v", tests)
+	}
+
+	_, growthAreas := analyzer.identifyStrengthsAndGrowthAreas(profile)
+	for _, area := range growthAreas {
+		if area == "Testing" || area == "Ci/Cd" {
+			t.Errorf("Expected %s not to be a growth area", area)
+		}
+	}
+}
+
+// TestDocumentationDetection verifies a docs/ directory or a large README marks a repository as
+// documented while a short README does not
+func TestDocumentationDetection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/testuser/site/contents":
+			w.Write([]byte(`[{"name": "docs", "type": "dir"}, {"name": "README.md", "type": "file", "size": 200}]`))
+		case "/repos/testuser/guide/contents":
+			w.Write([]byte(`[{"name": "README.md", "type": "file", "size": 12000}]`))
+		case "/repos/testuser/tool/contents":
+			w.Write([]byte(`[{"name": "README.md", "type": "file", "size": 200}, {"name": "main.go", "type": "file"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+
+	repos := []RepositoryProfile{{FullName: "testuser/site"}, {FullName: "testuser/guide"}, {FullName: "testuser/tool"}}
+	for i := range repos {
+		analyzer.analyzeRepositoryContents(context.Background(), &repos[i])
+	}
+
+	if !repos[0].HasDocumentation || !repos[1].HasDocumentation || repos[2].HasDocumentation {
+		t.Errorf("Expected site and guide to be documented but not tool, got %v, %v, %v",
+			repos[0].HasDocumentation, repos[1].HasDocumentation, repos[2].HasDocumentation)
+	}
+
+	// A documentation topic counts as well
+	repos = append(repos, RepositoryProfile{FullName: "testuser/handbook", Topics: []string{"Documentation"}})
+	if count := countDocumentationRepositories(repos); count != 3 {
+		t.Errorf("Expected 3 documented repositories, got %d", count)
+	}
+}
+
+// TestDocumentationStrength verifies documented repositories are counted in insights and make
+// documentation a strength rather than a growth area
+func TestDocumentationStrength(t *testing.T) {
+	profile := &UserProfile{
+		Username: "testuser",
+		Repositories: []RepositoryProfile{
+			{Name: "a", HasDocumentation: true},
+			{Name: "b", HasDocumentation: true},
+			{Name: "c", HasDocumentation: true},
+			{Name: "d"},
+		},
+	}
+
+	analyzer := &Analyzer{}
+	analyzer.generateInsights(profile)
+
+	if got := profile.Insights.CommunityImpact.DocumentationContrib; got != 3 {
+		t.Errorf("Expected 3 documentation contributions, got %d", got)
+	}
+	if !slices.Contains(profile.Insights.StrengthAreas, "Documentation") {
+		t.Errorf("Expected Documentation among strengths, got %v", profile.Insights.StrengthAreas)
+	}
+	if slices.Contains(profile.Insights.GrowthAreas, "Documentation") {
+		t.Errorf("Expected Documentation not to be a growth area, got %v", profile.Insights.GrowthAreas)
+	}
+}
+
+// TestAnalyzeSecurityPractices verifies a root SECURITY.md and .github Dependabot and CODEOWNERS
+// files are detected when the security scan is enabled
+func TestAnalyzeSecurityPractices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/testuser/tool/contents":
+			w.Write([]byte(`[
+				{"name": ".github", "type": "dir"},
+				{"name": "SECURITY.md", "type": "file"},
+				{"name": "README.md", "type": "file"}
+			]`))
+		case "/repos/testuser/tool/contents/.github":
+			w.Write([]byte(`[{"name": "dependabot.yml", "type": "file"}, {"name": "CODEOWNERS", "type": "file"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+
+	repo := RepositoryProfile{FullName: "testuser/tool"}
+	if analyzer.analyzeRepositoryContents(context.Background(), &repo); repo.SecurityPractices != nil {
+		t.Errorf("Expected no security scan without -with-security-scan, got %v", repo.SecurityPractices)
+	}
+
+	analyzer.SetSecurityScan(true)
+	analyzer.analyzeRepositoryContents(context.Background(), &repo)
+	practices := repo.SecurityPractices
+
+	expected := []string{securityPolicyPractice, dependabotPractice, codeOwnersPractice}
+	if !reflect.DeepEqual(practices, expected) {
+		t.Errorf("Expected %v, got %v", expected, practices)
+	}
+}
+
+// TestSecurityMindedness verifies insights count the repositories following each security practice
+func TestSecurityMindedness(t *testing.T) {
+	profile := &UserProfile{
+		Username: "testuser",
+		Repositories: []RepositoryProfile{
+			{Name: "a", SecurityPractices: []string{securityPolicyPractice, dependabotPractice}},
+			{Name: "b", SecurityPractices: []string{securityPolicyPractice}},
+			{Name: "c"},
+		},
+	}
+
+	analyzer := &Analyzer{}
+	analyzer.generateInsights(profile)
+
+	signals := profile.Insights.ArchitecturalThinking
+	if !signals.SecurityMindedness {
+		t.Error("Expected security mindedness")
+	}
+	expected := map[string]int{securityPolicyPractice: 2, dependabotPractice: 1}
+	if !reflect.DeepEqual(signals.SecurityPractices, expected) {
+		t.Errorf("Expected %v, got %v", expected, signals.SecurityPractices)
+	}
+}
+
+const sampleReadme = "# Tool\n\n" +
+	"[![Build](https://ci.example.com/badge.svg)](https://ci.example.com)\n\n" +
+	"A command-line [tool](https://example.com) that\nsummarizes GitHub activity.\n\n" +
+	"## Installation\n\nRun `go install`.\n"
+
+// TestReadmeSummary verifies the first prose paragraph is extracted from common README layouts
+func TestReadmeSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		readme string
+		want   string
+	}{
+		{"headings and badges", sampleReadme, "A command-line tool that summarizes GitHub activity."},
+		{"setext heading", "Tool\n====\n\nDoes things.\n", "Does things."},
+		{"html header", "<p align=\"center\"><img src=\"logo.png\"></p>\n\nDoes things.\n", "Does things."},
+		{"code before prose", "```\nmake\n```\n\nDoes things.\n", "Does things."},
+		{"no prose", "# Tool\n\n![logo](logo.png)\n", ""},
+	}
+
+	for _, tt := range tests {
+		if got := readmeSummary(tt.readme); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	long := readmeSummary(strings.Repeat("word ", 100))
+	if len(long) > maxReadmeSummaryLength+3 || !strings.HasSuffix(long, "...") {
+		t.Errorf("Expected a truncated summary, got %q", long)
+	}
+}
+
+// TestEnrichDescriptions verifies README excerpts fill empty descriptions of notable repositories only,
+// and are left out of the saved profile
+func TestEnrichDescriptions(t *testing.T) {
+	var server *httptest.Server
+	var fetched []string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/contents"):
+			fetched = append(fetched, r.URL.Path)
+			fmt.Fprintf(w, `[{"name":"README.md","type":"file","download_url":"%s/raw/README.md"}]`, server.URL)
+		case r.URL.Path == "/raw/README.md":
+			w.Write([]byte(sampleReadme))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	prof := &UserProfile{Repositories: []RepositoryProfile{
+		{Name: "tool", FullName: "testuser/tool", Stars: 5},
+		{Name: "described", FullName: "testuser/described", Stars: 50, Description: "Already described"},
+		{Name: "fork", FullName: "other/fork"},
+	}}
+
+	analyzer.EnrichDescriptions(context.Background(), prof, DefaultEnrichLimit)
+
+	if len(fetched) != 1 || fetched[0] != "/repos/testuser/tool/contents" {
+		t.Errorf("Expected only the undescribed notable repository to be fetched, got %v", fetched)
+	}
+	if got := prof.Repositories[0].DisplayDescription(); got != "A command-line tool that summarizes GitHub activity." {
+		t.Errorf("Unexpected enriched description: %q", got)
+	}
+	if prof.Repositories[0].Description != "" {
+		t.Error("Expected the canonical description to stay empty")
+	}
+	if got := prof.Repositories[1].DisplayDescription(); got != "Already described" {
+		t.Errorf("Expected the existing description to be kept, got %q", got)
+	}
+
+	data, err := json.Marshal(prof)
+	if err != nil {
+		t.Fatalf("Failed to marshal profile: %v", err)
+	}
+	if strings.Contains(string(data), "summarizes GitHub activity") {
+		t.Error("Expected the README excerpt not to be saved")
+	}
+}
+
+// TestEnrichDescriptionsLimit verifies only the top repositories are fetched
+func TestEnrichDescriptionsLimit(t *testing.T) {
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = append(fetched, r.URL.Path)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	analyzer := newTestAnalyzer(t, server)
+	prof := &UserProfile{Repositories: []RepositoryProfile{
+		{Name: "small", FullName: "testuser/small", Stars: 1},
+		{Name: "big", FullName: "testuser/big", Stars: 100},
+	}}
+
+	analyzer.EnrichDescriptions(context.Background(), prof, 1)
+
+	if len(fetched) != 1 || fetched[0] != "/repos/testuser/big/contents" {
+		t.Errorf("Expected only the most starred repository to be fetched, got %v", fetched)
+	}
+}
@@ -0,0 +1,105 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+// reviewsSampleSize is the number of most recent reviews inspected for mentorship signals
+const reviewsSampleSize = 100
+
+// firstTimeAssociations are the author associations GitHub gives to a contributor's first pull requests
+var firstTimeAssociations = map[string]bool{
+	"FIRST_TIME_CONTRIBUTOR": true,
+	"FIRST_TIMER":            true,
+}
+
+// SetReviewsEnabled enables fetching the user's recent pull request reviews to derive mentorship
+// signals, at the cost of one query
+func (a *Analyzer) SetReviewsEnabled(enabled bool) {
+	a.withReviews = enabled
+}
+
+// fetchReviews summarizes whose pull requests the user reviewed in the past year. A failure is
+// logged and leaves profile.ReviewActivity nil.
+func (a *Analyzer) fetchReviews(ctx context.Context, username string, profile *UserProfile) {
+	logging.Infof("Fetching pull request reviews of user: %s", username)
+
+	req := &github.GraphQLRequest{
+		Query: github.UserPullRequestReviewsQuery,
+		Variables: map[string]interface{}{
+			"username": username,
+			"first":    reviewsSampleSize,
+		},
+	}
+
+	var resp github.UserPullRequestReviewsResponse
+	if err := a.client.ExecuteGraphQL(ctx, req, &resp); err != nil {
+		logging.Warnf("Failed to fetch pull request reviews: %v", err)
+		return
+	}
+
+	contributions := resp.User.ContributionsCollection.PullRequestReviewContributions
+	profile.ReviewActivity = a.summarizeReviews(username, contributions.TotalCount, contributions.Nodes)
+}
+
+// summarizeReviews counts the reviews of other users' pull requests, those of first-time
+// contributors, and the organizations they were made in. Pull requests opened by bots are skipped.
+func (a *Analyzer) summarizeReviews(username string, total int, reviews []github.PullRequestReviewNode) *ReviewActivity {
+	activity := &ReviewActivity{TotalReviews: total, Organizations: []string{}}
+	organizations := make(map[string]bool)
+
+	for _, review := range reviews {
+		author := review.PullRequest.Author
+		if author == nil || strings.EqualFold(author.Login, username) || a.isBot(author.Login) {
+			continue
+		}
+
+		activity.ReviewsOfOthers++
+		if firstTimeAssociations[review.PullRequest.AuthorAssociation] {
+			activity.FirstTimeContributors++
+		}
+		if owner := review.Repository.Owner.Login; owner != "" && !strings.EqualFold(owner, username) {
+			organizations[owner] = true
+		}
+	}
+
+	for organization := range organizations {
+		activity.Organizations = append(activity.Organizations, organization)
+	}
+	sort.Strings(activity.Organizations)
+
+	return activity
+}
+
+// reviewMentorshipSigns describes the mentoring shown by reviews of other people's pull requests
+func reviewMentorshipSigns(activity *ReviewActivity) []string {
+	if activity == nil {
+		return nil
+	}
+
+	var signs []string
+	if activity.FirstTimeContributors > 0 {
+		signs = append(signs, fmt.Sprintf("Reviewed %s from first-time contributors", pullRequestCount(activity.FirstTimeContributors)))
+	}
+	if activity.ReviewsOfOthers > 0 {
+		signs = append(signs, fmt.Sprintf("Reviewed %s from other contributors", pullRequestCount(activity.ReviewsOfOthers)))
+	}
+	if len(activity.Organizations) > 1 {
+		signs = append(signs, fmt.Sprintf("Reviews pull requests across %d organizations", len(activity.Organizations)))
+	}
+	return signs
+}
+
+// pullRequestCount formats n as "1 pull request" or "n pull requests"
+func pullRequestCount(n int) string {
+	if n == 1 {
+		return "1 pull request"
+	}
+	return fmt.Sprintf("%d pull requests", n)
+}
@@ -0,0 +1,141 @@
+package profile
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// defaultRoleLevel is the roles key used for career levels without their own entry
+const defaultRoleLevel = "default"
+
+//go:embed roles.json
+var defaultRolesJSON []byte
+
+// DefaultRoleRules are the built-in role recommendations, also used when no -roles-config is given
+var DefaultRoleRules = mustParseRoleRules(defaultRolesJSON)
+
+// RoleRules configures the roles recommended for a profile. Every matching track contributes the
+// roles of the profile's career level, in track order; the fallback roles apply when no track matches.
+type RoleRules struct {
+	Tracks   []RoleTrack         `json:"tracks"`
+	Fallback map[string][]string `json:"fallback"` // roles by career level
+}
+
+// RoleTrack is a family of roles, such as backend or DevOps, with the conditions a profile must meet.
+// A track without conditions always matches.
+type RoleTrack struct {
+	Name            string              `json:"name"`
+	Languages       []string            `json:"languages,omitempty"`      // any of them must be a primary language
+	RequiresTracks  []string            `json:"requiresTracks,omitempty"` // names of tracks whose conditions must also hold
+	MinDevOpsSkills int                 `json:"minDevOpsSkills,omitempty"`
+	Roles           map[string][]string `json:"roles"` // roles by career level, "default" for the others
+}
+
+// LoadRoleRules reads role recommendation rules from a JSON file shaped like the embedded defaults.
+// The file replaces the defaults entirely.
+func LoadRoleRules(path string) (*RoleRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roles config: %w", err)
+	}
+
+	rules, err := parseRoleRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid roles config %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// parseRoleRules decodes and validates role recommendation rules
+func parseRoleRules(data []byte) (*RoleRules, error) {
+	var rules RoleRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+
+	if len(rules.Tracks) == 0 && len(rules.Fallback) == 0 {
+		return nil, fmt.Errorf("no tracks or fallback roles defined")
+	}
+
+	names := make(map[string]bool, len(rules.Tracks))
+	for _, track := range rules.Tracks {
+		if track.Name == "" {
+			return nil, fmt.Errorf("track without a name")
+		}
+		if len(track.Roles) == 0 {
+			return nil, fmt.Errorf("track %s has no roles", track.Name)
+		}
+		names[track.Name] = true
+	}
+	for _, track := range rules.Tracks {
+		for _, required := range track.RequiresTracks {
+			if !names[required] {
+				return nil, fmt.Errorf("track %s requires unknown track %s", track.Name, required)
+			}
+		}
+	}
+
+	return &rules, nil
+}
+
+// mustParseRoleRules parses rules that are embedded at build time and therefore must be valid
+func mustParseRoleRules(data []byte) *RoleRules {
+	rules, err := parseRoleRules(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded roles: %v", err))
+	}
+	return rules
+}
+
+// SetRoleRules replaces the rules used to recommend roles; nil restores DefaultRoleRules
+func (a *Analyzer) SetRoleRules(rules *RoleRules) {
+	a.roleRules = rules
+}
+
+// rolesForLevel returns the roles of careerLevel, or the default roles when the level has no entry
+func rolesForLevel(roles map[string][]string, careerLevel string) []string {
+	if levelRoles, ok := roles[careerLevel]; ok {
+		return levelRoles
+	}
+	return roles[defaultRoleLevel]
+}
+
+// recommendRoles suggests suitable roles based on the profile
+func (a *Analyzer) recommendRoles(profile *UserProfile) []string {
+	rules := a.roleRules
+	if rules == nil {
+		rules = DefaultRoleRules
+	}
+
+	careerLevel := profile.Insights.CareerLevel
+	if careerLevel == "" {
+		careerLevel = "mid" // Default fallback if career level is empty
+	}
+
+	// Own conditions first, so that tracks can require tracks listed after them
+	meetsConditions := make(map[string]bool, len(rules.Tracks))
+	for _, track := range rules.Tracks {
+		meetsConditions[track.Name] = (len(track.Languages) == 0 || a.hasAnyLanguage(profile.Skills.PrimaryLanguages, track.Languages)) &&
+			len(profile.Skills.DevOpsSkills) >= track.MinDevOpsSkills
+	}
+
+	var roles []string
+	for _, track := range rules.Tracks {
+		matched := meetsConditions[track.Name]
+		for _, required := range track.RequiresTracks {
+			matched = matched && meetsConditions[required]
+		}
+		if matched {
+			roles = append(roles, rolesForLevel(track.Roles, careerLevel)...)
+		}
+	}
+
+	// Ensure we always have at least some role recommendations
+	if len(roles) == 0 {
+		roles = append(roles, rolesForLevel(rules.Fallback, careerLevel)...)
+	}
+
+	return roles
+}
@@ -0,0 +1,90 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// seniorGoProfile returns a senior profile whose primary language is Go
+func seniorGoProfile() *UserProfile {
+	return &UserProfile{
+		Skills:   SkillProfile{PrimaryLanguages: []string{"Go"}},
+		Insights: UserInsights{CareerLevel: "senior"},
+	}
+}
+
+// TestRecommendRolesDefaults verifies the embedded rules keep the built-in recommendations
+func TestRecommendRolesDefaults(t *testing.T) {
+	analyzer := &Analyzer{}
+
+	roles := analyzer.recommendRoles(seniorGoProfile())
+	expected := []string{"Senior Backend Engineer", "Backend Team Lead", "Staff Engineer"}
+	if !reflect.DeepEqual(roles, expected) {
+		t.Errorf("Expected %v, got %v", expected, roles)
+	}
+
+	fullStack := seniorGoProfile()
+	fullStack.Skills.PrimaryLanguages = append(fullStack.Skills.PrimaryLanguages, "TypeScript")
+	roles = analyzer.recommendRoles(fullStack)
+	if len(roles) != 7 || roles[len(roles)-1] != "Technical Lead" {
+		t.Errorf("Expected backend, frontend and full-stack roles, got %v", roles)
+	}
+
+	roles = analyzer.recommendRoles(&UserProfile{Insights: UserInsights{CareerLevel: "junior"}})
+	expected = []string{"Junior Software Engineer", "Software Developer"}
+	if !reflect.DeepEqual(roles, expected) {
+		t.Errorf("Expected fallback roles %v, got %v", expected, roles)
+	}
+}
+
+// TestLoadRoleRulesCustomTitles verifies a roles config changes the recommendation for a Go-primary senior profile
+func TestLoadRoleRulesCustomTitles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roles.json")
+	config := `{
+  "tracks": [
+    {"name": "backend", "languages": ["Go"], "roles": {"senior": ["SDE II"], "default": ["SDE I"]}}
+  ],
+  "fallback": {"default": ["Engineer"]}
+}`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write roles config: %v", err)
+	}
+
+	rules, err := LoadRoleRules(path)
+	if err != nil {
+		t.Fatalf("LoadRoleRules failed: %v", err)
+	}
+
+	analyzer := &Analyzer{}
+	analyzer.SetRoleRules(rules)
+
+	if roles := analyzer.recommendRoles(seniorGoProfile()); !reflect.DeepEqual(roles, []string{"SDE II"}) {
+		t.Errorf("Expected [SDE II], got %v", roles)
+	}
+
+	principal := seniorGoProfile()
+	principal.Insights.CareerLevel = "principal"
+	if roles := analyzer.recommendRoles(principal); !reflect.DeepEqual(roles, []string{"SDE I"}) {
+		t.Errorf("Expected the default level roles [SDE I], got %v", roles)
+	}
+}
+
+// TestLoadRoleRulesInvalid verifies malformed roles configs are rejected
+func TestLoadRoleRulesInvalid(t *testing.T) {
+	for name, config := range map[string]string{
+		"empty":         `{}`,
+		"no roles":      `{"tracks": [{"name": "backend"}]}`,
+		"unknown track": `{"tracks": [{"name": "fullstack", "requiresTracks": ["backend"], "roles": {"default": ["Dev"]}}]}`,
+		"not json":      `tracks`,
+	} {
+		path := filepath.Join(t.TempDir(), "roles.json")
+		if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+			t.Fatalf("Failed to write roles config: %v", err)
+		}
+		if _, err := LoadRoleRules(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestCacheRejectsOldSchemaVersion verifies a cached analysis from an older layout is re-analyzed
+func TestCacheRejectsOldSchemaVersion(t *testing.T) {
+	analyzer := &Analyzer{cacheDir: t.TempDir(), analysisMaxAge: DefaultAnalysisMaxAge}
+
+	data, err := json.Marshal(UserProfile{SchemaVersion: "1", Username: "testuser", LastAnalyzed: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to marshal profile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(analyzer.cacheDir, "testuser_analysis.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write cache file: %v", err)
+	}
+
+	if analyzer.tryLoadFromCache("testuser") != nil {
+		t.Error("Expected cached profile with old schema version to be rejected")
+	}
+
+	// Saving stamps the current version, so the next load succeeds
+	if err := analyzer.saveToCache("testuser", &UserProfile{Username: "testuser", LastAnalyzed: time.Now()}); err != nil {
+		t.Fatalf("saveToCache failed: %v", err)
+	}
+	if analyzer.tryLoadFromCache("testuser") == nil {
+		t.Error("Expected cached profile with current schema version to be loaded")
+	}
+}
+
+// TestProgressRejectsOldSchemaVersion verifies progress from an older layout is discarded and analysis restarts
+func TestProgressRejectsOldSchemaVersion(t *testing.T) {
+	analyzer := &Analyzer{saveProgressDir: t.TempDir(), progressMaxAge: DefaultProgressMaxAge}
+	filename := analyzer.getProgressFilename("testuser", "", "")
+
+	data, err := json.Marshal(ProgressData{
+		Username:      "testuser",
+		LastStep:      3,
+		SavedAt:       time.Now(),
+		SchemaVersion: "1",
+		UserProfile:   &UserProfile{Username: "testuser"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal progress: %v", err)
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		t.Fatalf("Failed to write progress file: %v", err)
+	}
+
+	profile, step := analyzer.tryResumeProgress("testuser", "", "")
+	if profile != nil || step != 1 {
+		t.Errorf("Expected fresh start for old schema version, got step %d", step)
+	}
+	if _, err := os.Stat(filename); !os.IsNotExist(err) {
+		t.Error("Expected outdated progress file to be removed")
+	}
+
+	if err := analyzer.saveProgress("testuser", "", "", &UserProfile{Username: "testuser"}, 3); err != nil {
+		t.Fatalf("saveProgress failed: %v", err)
+	}
+	if _, step := analyzer.tryResumeProgress("testuser", "", ""); step != 4 {
+		t.Errorf("Expected resume at step 4 with current schema version, got %d", step)
+	}
+}
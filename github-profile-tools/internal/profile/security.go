@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jenkins/github-profile-tools/internal/github"
+	"github.com/jenkins/github-profile-tools/internal/logging"
+)
+
+// Security practices detected from repository files
+const (
+	securityPolicyPractice = "Security policy"
+	dependabotPractice     = "Dependabot updates"
+	codeOwnersPractice     = "Code owners"
+	signedCommitsPractice  = "Signed commits"
+)
+
+// securityFiles maps entries (lowercased) of the repository root or .github directory to the
+// security practice they indicate
+var securityFiles = map[string]string{
+	"security.md":      securityPolicyPractice,
+	"dependabot.yml":   dependabotPractice,
+	"dependabot.yaml":  dependabotPractice,
+	"codeowners":       codeOwnersPractice,
+	"allowed_signers":  signedCommitsPractice,
+	".allowed_signers": signedCommitsPractice,
+	".gitsign.yaml":    signedCommitsPractice,
+	".gitsign.yml":     signedCommitsPractice,
+}
+
+// SetSecurityScan enables detecting security practices such as SECURITY.md and Dependabot,
+// at the cost of listing the .github directory of each repository
+func (a *Analyzer) SetSecurityScan(enabled bool) {
+	a.withSecurityScan = enabled
+}
+
+// analyzeSecurityPractices detects security practices from repository root contents, listing
+// the .github directory when present. It returns nil when none was found.
+func (a *Analyzer) analyzeSecurityPractices(ctx context.Context, owner, repo string, contents []github.RepositoryContentResponse) []string {
+	var practices []string
+	var hasGitHubDir bool
+
+	for _, item := range contents {
+		name := strings.ToLower(item.Name)
+		if practice, ok := securityFiles[name]; ok && item.Type == "file" {
+			practices = appendUnique(practices, practice)
+		}
+		if name == ".github" && item.Type == "dir" {
+			hasGitHubDir = true
+		}
+	}
+
+	if hasGitHubDir {
+		githubContents, err := a.client.FetchDirectoryContents(ctx, owner, repo, ".github")
+		if err != nil {
+			logging.Warnf("Failed to fetch .github contents for %s/%s: %v", owner, repo, err)
+		}
+		for _, item := range githubContents {
+			if practice, ok := securityFiles[strings.ToLower(item.Name)]; ok && item.Type == "file" {
+				practices = appendUnique(practices, practice)
+			}
+		}
+	}
+
+	return practices
+}
+
+// countSecurityPractices counts the repositories exhibiting each security practice
+func countSecurityPractices(repos []RepositoryProfile) map[string]int {
+	counts := make(map[string]int)
+	for _, repo := range repos {
+		for _, practice := range repo.SecurityPractices {
+			counts[practice]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
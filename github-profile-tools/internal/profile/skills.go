@@ -0,0 +1,114 @@
+package profile
+
+import "strings"
+
+// skillCategory identifies one of the TechnologySkill lists of a SkillProfile
+type skillCategory int
+
+// Categories in tie-break order: when a skill is equally present in several categories,
+// the earliest one wins
+const (
+	devOpsCategory skillCategory = iota
+	frameworkCategory
+	databaseCategory
+	cloudCategory
+	toolCategory
+	skillCategoryCount
+)
+
+// preferredSkillCategories pins technologies that are routinely detected in several categories
+var preferredSkillCategories = map[string]skillCategory{
+	"docker":         devOpsCategory,
+	"docker compose": devOpsCategory,
+	"docker buildx":  devOpsCategory,
+	"kubernetes":     devOpsCategory,
+	"terraform":      devOpsCategory,
+}
+
+// categoryLists returns the skill lists of skills indexed by category
+func categoryLists(skills *SkillProfile) [skillCategoryCount]*[]TechnologySkill {
+	return [skillCategoryCount]*[]TechnologySkill{
+		devOpsCategory:    &skills.DevOpsSkills,
+		frameworkCategory: &skills.Frameworks,
+		databaseCategory:  &skills.Databases,
+		cloudCategory:     &skills.CloudPlatforms,
+		toolCategory:      &skills.Tools,
+	}
+}
+
+// normalizeSkills merges duplicate technology skills by name, within and across categories,
+// and keeps each merged skill only in its best-fit category.
+//
+// Duplicates within a category come from different repositories, so their project counts are
+// summed. Duplicates across categories describe the same repositories, so the largest count wins.
+func normalizeSkills(skills *SkillProfile) {
+	type mergedSkill struct {
+		skill         TechnologySkill
+		categoryCount [skillCategoryCount]int // summed project counts per category
+		seen          [skillCategoryCount]bool
+		evidence      map[string]bool
+	}
+
+	lists := categoryLists(skills)
+	merged := make(map[string]*mergedSkill)
+	var order []string
+
+	for category, list := range lists {
+		for _, skill := range *list {
+			key := strings.ToLower(skill.Name)
+			m, ok := merged[key]
+			if !ok {
+				m = &mergedSkill{skill: skill, evidence: make(map[string]bool)}
+				m.skill.Evidence = []string{}
+				merged[key] = m
+				order = append(order, key)
+			} else {
+				if skill.Confidence > m.skill.Confidence {
+					m.skill.Name = skill.Name
+					m.skill.Confidence = skill.Confidence
+					m.skill.ProficiencyLevel = skill.ProficiencyLevel
+				}
+				if !skill.FirstUsed.IsZero() && (m.skill.FirstUsed.IsZero() || skill.FirstUsed.Before(m.skill.FirstUsed)) {
+					m.skill.FirstUsed = skill.FirstUsed
+				}
+				if skill.LastUsed.After(m.skill.LastUsed) {
+					m.skill.LastUsed = skill.LastUsed
+				}
+			}
+
+			m.categoryCount[category] += skill.ProjectCount
+			m.seen[category] = true
+			for _, evidence := range skill.Evidence {
+				if !m.evidence[evidence] {
+					m.evidence[evidence] = true
+					m.skill.Evidence = append(m.skill.Evidence, evidence)
+				}
+			}
+		}
+		*list = []TechnologySkill{}
+	}
+
+	for _, key := range order {
+		m := merged[key]
+
+		best := skillCategory(-1)
+		projectCount := 0
+		for category := skillCategory(0); category < skillCategoryCount; category++ {
+			if !m.seen[category] {
+				continue
+			}
+			if best < 0 || m.categoryCount[category] > m.categoryCount[best] {
+				best = category
+			}
+			if m.categoryCount[category] > projectCount {
+				projectCount = m.categoryCount[category]
+			}
+		}
+		if preferred, ok := preferredSkillCategories[key]; ok && m.seen[preferred] {
+			best = preferred
+		}
+
+		m.skill.ProjectCount = projectCount
+		*lists[best] = append(*lists[best], m.skill)
+	}
+}
@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNormalizeSkillsMergesDockerDuplicates verifies duplicate Docker entries collapse into one DevOps skill
+func TestNormalizeSkillsMergesDockerDuplicates(t *testing.T) {
+	older := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	skills := SkillProfile{
+		DevOpsSkills: []TechnologySkill{
+			{Name: "Docker", Confidence: 0.8, Evidence: []string{"user/api", "multi-stage builds"}, ProjectCount: 1, ProficiencyLevel: "intermediate", LastUsed: older},
+			{Name: "Docker", Confidence: 0.95, Evidence: []string{"user/web"}, ProjectCount: 1, ProficiencyLevel: "expert", LastUsed: newer},
+		},
+		CloudPlatforms: []TechnologySkill{
+			{Name: "Docker", Confidence: 0.95, Evidence: []string{"user/web"}, ProjectCount: 1, ProficiencyLevel: "expert"},
+			{Name: "docker", Confidence: 0.2, Evidence: []string{"user/api"}, ProjectCount: 1, ProficiencyLevel: "beginner", FirstUsed: older},
+			{Name: "AWS", Confidence: 0.4, Evidence: []string{"user/infra"}, ProjectCount: 1},
+		},
+	}
+
+	normalizeSkills(&skills)
+
+	if len(skills.DevOpsSkills) != 1 {
+		t.Fatalf("Expected a single DevOps skill, got %+v", skills.DevOpsSkills)
+	}
+	if len(skills.CloudPlatforms) != 1 || skills.CloudPlatforms[0].Name != "AWS" {
+		t.Fatalf("Expected only AWS to remain in cloud platforms, got %+v", skills.CloudPlatforms)
+	}
+
+	docker := skills.DevOpsSkills[0]
+	if docker.Name != "Docker" || docker.Confidence != 0.95 || docker.ProficiencyLevel != "expert" {
+		t.Errorf("Expected highest-confidence Docker entry to win, got %+v", docker)
+	}
+	if docker.ProjectCount != 2 {
+		t.Errorf("Expected project count 2, got %d", docker.ProjectCount)
+	}
+	if len(docker.Evidence) != 3 {
+		t.Errorf("Expected 3 unique evidence entries, got %v", docker.Evidence)
+	}
+	if !docker.FirstUsed.Equal(older) || !docker.LastUsed.Equal(newer) {
+		t.Errorf("Expected usage span %v to %v, got %v to %v", older, newer, docker.FirstUsed, docker.LastUsed)
+	}
+}
+
+// TestNormalizeSkillsBestFitCategory verifies an unpinned skill lands in the category with most projects
+func TestNormalizeSkillsBestFitCategory(t *testing.T) {
+	skills := SkillProfile{
+		Tools: []TechnologySkill{
+			{Name: "Jenkins", Confidence: 0.6, Evidence: []string{"user/a"}, ProjectCount: 1},
+			{Name: "Jenkins", Confidence: 0.6, Evidence: []string{"user/b"}, ProjectCount: 1},
+		},
+		DevOpsSkills: []TechnologySkill{
+			{Name: "Jenkins", Confidence: 0.4, Evidence: []string{"user/a"}, ProjectCount: 1},
+		},
+	}
+
+	normalizeSkills(&skills)
+
+	if len(skills.DevOpsSkills) != 0 {
+		t.Errorf("Expected Jenkins to be removed from DevOps skills, got %+v", skills.DevOpsSkills)
+	}
+	if len(skills.Tools) != 1 || skills.Tools[0].ProjectCount != 2 {
+		t.Errorf("Expected a single Jenkins tool with 2 projects, got %+v", skills.Tools)
+	}
+}
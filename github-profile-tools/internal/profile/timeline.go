@@ -0,0 +1,32 @@
+package profile
+
+import "strings"
+
+// dateOrganizations sets each organization's FirstContribution and LastContribution from the earliest
+// creation and latest push of the analyzed repositories it owns or lists, a rough employment history.
+// Organizations without analyzed repositories keep zero dates.
+func dateOrganizations(orgs []OrganizationProfile, repos []RepositoryProfile) {
+	for i := range orgs {
+		org := &orgs[i]
+
+		listed := make(map[string]bool, len(org.Repositories))
+		for _, name := range org.Repositories {
+			listed[strings.ToLower(name)] = true
+		}
+
+		for _, repo := range repos {
+			fullName := strings.ToLower(repo.FullName)
+			owner, _, _ := strings.Cut(fullName, "/")
+			if owner != strings.ToLower(org.Login) && !listed[fullName] {
+				continue
+			}
+
+			if !repo.CreatedAt.IsZero() && (org.FirstContribution.IsZero() || repo.CreatedAt.Before(org.FirstContribution)) {
+				org.FirstContribution = repo.CreatedAt
+			}
+			if repo.PushedAt.After(org.LastContribution) {
+				org.LastContribution = repo.PushedAt
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDateOrganizations verifies an organization's dates derive from the creation and push dates of its
+// repositories, matched by owner or by the organization's repository list
+func TestDateOrganizations(t *testing.T) {
+	day := func(year int, month time.Month) time.Time { return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC) }
+	repos := []RepositoryProfile{
+		{FullName: "jenkinsci/git-plugin", CreatedAt: day(2018, time.March), PushedAt: day(2021, time.June)},
+		{FullName: "JenkinsCI/docker", CreatedAt: day(2019, time.January), PushedAt: day(2024, time.May)},
+		{FullName: "kubernetes/website", CreatedAt: day(2016, time.July), PushedAt: day(2020, time.February)},
+		{FullName: "octocat/dotfiles", CreatedAt: day(2010, time.May), PushedAt: day(2025, time.January)},
+	}
+	orgs := []OrganizationProfile{
+		{Login: "jenkinsci"},
+		{Login: "cncf", Repositories: []string{"kubernetes/website"}},
+		{Login: "apache"},
+	}
+
+	dateOrganizations(orgs, repos)
+
+	expected := []struct{ first, last time.Time }{
+		{day(2018, time.March), day(2024, time.May)},
+		{day(2016, time.July), day(2020, time.February)},
+		{},
+	}
+	for i, org := range orgs {
+		if !org.FirstContribution.Equal(expected[i].first) || !org.LastContribution.Equal(expected[i].last) {
+			t.Errorf("%s: expected %v to %v, got %v to %v", org.Login, expected[i].first, expected[i].last,
+				org.FirstContribution, org.LastContribution)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package profile
+
+import (
+	"sort"
+	"strconv"
+)
+
+// calculateLanguageTrends buckets the primary language of each repository by the year it was
+// created, so recently adopted languages stand apart from long-standing ones. Forks are skipped
+// as their creation date says nothing about when the user picked the language up.
+func calculateLanguageTrends(repos []RepositoryProfile) []LanguageTrend {
+	trends := make(map[string]*LanguageTrend)
+	for _, repo := range repos {
+		if repo.Language == "" || repo.IsFork || repo.CreatedAt.IsZero() {
+			continue
+		}
+
+		year := repo.CreatedAt.Year()
+		trend, ok := trends[repo.Language]
+		if !ok {
+			trend = &LanguageTrend{
+				Language:           repo.Language,
+				FirstYear:          year,
+				LastYear:           year,
+				RepositoriesByYear: make(map[string]int),
+			}
+			trends[repo.Language] = trend
+		}
+		if year < trend.FirstYear {
+			trend.FirstYear = year
+		}
+		if year > trend.LastYear {
+			trend.LastYear = year
+		}
+		trend.RepositoriesByYear[strconv.Itoa(year)]++
+	}
+
+	result := make([]LanguageTrend, 0, len(trends))
+	for _, trend := range trends {
+		result = append(result, *trend)
+	}
+
+	// Newest adopted first, ties broken by name so the order does not depend on map order
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FirstYear != result[j].FirstYear {
+			return result[i].FirstYear > result[j].FirstYear
+		}
+		return result[i].Language < result[j].Language
+	})
+
+	return result
+}
@@ -0,0 +1,38 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCalculateLanguageTrends verifies repositories are bucketed by creation year and the most
+// recently adopted language comes first
+func TestCalculateLanguageTrends(t *testing.T) {
+	created := func(year int) time.Time {
+		return time.Date(year, time.June, 1, 0, 0, 0, 0, time.UTC)
+	}
+	repos := []RepositoryProfile{
+		{Name: "old-tool", Language: "Go", CreatedAt: created(2018)},
+		{Name: "service", Language: "Go", CreatedAt: created(2021)},
+		{Name: "scripts", Language: "Python", CreatedAt: created(2020)},
+		{Name: "engine", Language: "Rust", CreatedAt: created(2023)},
+		{Name: "forked", Language: "Zig", CreatedAt: created(2024), IsFork: true},
+		{Name: "notes", CreatedAt: created(2024)},
+	}
+
+	trends := calculateLanguageTrends(repos)
+	if len(trends) != 3 {
+		t.Fatalf("Expected 3 language trends, got %+v", trends)
+	}
+	if trends[0].Language != "Rust" || trends[0].FirstYear != 2023 {
+		t.Errorf("Expected Rust adopted in 2023 to come first, got %+v", trends[0])
+	}
+
+	goTrend := trends[2]
+	if goTrend.Language != "Go" || goTrend.FirstYear != 2018 || goTrend.LastYear != 2021 {
+		t.Errorf("Expected Go used from 2018 to 2021 to come last, got %+v", goTrend)
+	}
+	if goTrend.RepositoriesByYear["2018"] != 1 || goTrend.RepositoriesByYear["2021"] != 1 {
+		t.Errorf("Expected one Go repository in 2018 and 2021, got %v", goTrend.RepositoriesByYear)
+	}
+}
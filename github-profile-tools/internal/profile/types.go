@@ -4,10 +4,16 @@ import (
 	"time"
 
 	"github.com/jenkins/github-profile-tools/internal/discourse"
+	"github.com/jenkins/github-profile-tools/internal/stackoverflow"
 )
 
+// ProfileSchemaVersion identifies the layout of saved profiles. Bump it whenever UserProfile changes
+// in a way that older cache or progress files cannot represent; files without a version predate it.
+const ProfileSchemaVersion = "2"
+
 // UserProfile represents a comprehensive GitHub user profile analysis
 type UserProfile struct {
+	SchemaVersion     string                 `json:"schema_version"`
 	Username          string                 `json:"username"`
 	Name              string                 `json:"name"`
 	Bio               string                 `json:"bio"`
@@ -16,6 +22,7 @@ type UserProfile struct {
 	Email             string                 `json:"email"`
 	BlogURL           string                 `json:"blog_url"`
 	TwitterUsername   string                 `json:"twitter_username"`
+	AvatarURL         string                 `json:"avatar_url,omitempty"`
 	CreatedAt         time.Time              `json:"created_at"`
 	UpdatedAt         time.Time              `json:"updated_at"`
 	LastAnalyzed      time.Time              `json:"last_analyzed"`
@@ -26,13 +33,20 @@ type UserProfile struct {
 
 	Organizations     []OrganizationProfile  `json:"organizations"`
 	Repositories      []RepositoryProfile    `json:"repositories"`
+	PinnedRepositories []string              `json:"pinned_repositories,omitempty"` // owner/name, in the order pinned on GitHub
 	Contributions     ContributionSummary    `json:"contributions"`
 	Languages         []LanguageStats        `json:"languages"`
 	Skills            SkillProfile           `json:"skills"`
 	Collaborations    []CollaborationProfile `json:"collaborations"`
+	ReviewActivity    *ReviewActivity        `json:"review_activity,omitempty"` // -with-reviews
 	Insights          UserInsights           `json:"insights"`
 	DockerHubProfile  *DockerHubProfile      `json:"docker_hub_profile,omitempty"`
 	DiscourseProfile  *DiscourseProfile      `json:"discourse_profile,omitempty"`
+	StackOverflowProfile *StackOverflowProfile `json:"stackoverflow_profile,omitempty"`
+	Anonymized        bool                   `json:"anonymized,omitempty"` // personal information was stripped
+	Partial           bool                   `json:"partial,omitempty"`    // analysis was interrupted before completion
+	IsOrganization    bool                   `json:"is_organization,omitempty"` // Username is an organization login
+	MemberCount       int                    `json:"member_count,omitempty"`    // organization members
 }
 
 // OrganizationProfile represents user's involvement with organizations
@@ -75,7 +89,13 @@ type RepositoryProfile struct {
 	ContributionStats ContributionStats `json:"contribution_stats"`
 	Organization      string            `json:"organization,omitempty"`
 	CollaboratorCount int               `json:"collaborator_count"`
+	Contributors      []string          `json:"contributors,omitempty"` // other users active on an owned repository (-with-contributors)
+	IsMonorepo        bool              `json:"is_monorepo,omitempty"`
 	DockerConfig      *DockerConfig     `json:"docker_config,omitempty"`
+	CIConfig          *CIConfig         `json:"ci_config,omitempty"`
+	SecurityPractices []string          `json:"security_practices,omitempty"` // e.g. "Security policy" (-with-security-scan)
+	HasDocumentation  bool              `json:"has_documentation,omitempty"`  // docs directory or substantial README
+	ReadmeSummary     string            `json:"-"` // README excerpt standing in for an empty Description; never saved
 }
 
 // ContributionStats represents user's contribution statistics to a repository
@@ -108,6 +128,8 @@ type ContributionSummary struct {
 	WeeklyPattern           []int                  `json:"weekly_pattern"` // Sunday = 0
 	ContributionStreak      int                    `json:"current_streak"`
 	LongestStreak           int                    `json:"longest_streak"`
+	PeriodStart             time.Time              `json:"period_start"`
+	PeriodEnd               time.Time              `json:"period_end"`
 }
 
 // LanguageStats represents programming language statistics
@@ -117,7 +139,7 @@ type LanguageStats struct {
 	Percentage     float64 `json:"percentage"`
 	RepositoryCount int    `json:"repository_count"`
 	CommitCount    int     `json:"commit_count"`
-	LinesOfCode    int     `json:"lines_of_code"`
+	LinesOfCode    int     `json:"lines_of_code"` // estimated from Bytes
 	ProjectCount   int     `json:"project_count"`
 	FirstUsed      time.Time `json:"first_used"`
 	LastUsed       time.Time `json:"last_used"`
@@ -134,6 +156,15 @@ type SkillProfile struct {
 	CloudPlatforms     []TechnologySkill `json:"cloud_platforms"`
 	DevOpsSkills       []TechnologySkill `json:"devops_skills"`
 	TechnicalAreas     []TechnicalArea   `json:"technical_areas"`
+	LanguageTrends     []LanguageTrend   `json:"language_trends,omitempty"` // newest adopted first
+}
+
+// LanguageTrend represents when a language appeared across the user's repositories
+type LanguageTrend struct {
+	Language           string         `json:"language"`
+	FirstYear          int            `json:"first_year"`
+	LastYear           int            `json:"last_year"`
+	RepositoriesByYear map[string]int `json:"repositories_by_year"` // repositories created per year
 }
 
 // TechnologySkill represents proficiency in a specific technology
@@ -167,10 +198,20 @@ type CollaborationProfile struct {
 	EndDate           time.Time `json:"end_date"`
 }
 
+// ReviewActivity summarizes the pull requests the user reviewed in the past year
+type ReviewActivity struct {
+	TotalReviews          int      `json:"total_reviews"`
+	ReviewsOfOthers       int      `json:"reviews_of_others"`              // sampled reviews of pull requests by other users
+	FirstTimeContributors int      `json:"first_time_contributor_reviews"` // sampled reviews of a contributor's first pull requests
+	Organizations         []string `json:"organizations"`                  // owners of the reviewed repositories, the user excluded
+}
+
 // UserInsights represents AI-generated insights about the user
 type UserInsights struct {
 	CareerLevel         string                 `json:"career_level"` // junior, mid, senior, lead, principal
 	TechnicalFocus      []string               `json:"technical_focus"`
+	FocusAreas          []FocusArea            `json:"focus_areas,omitempty"` // most frequent repository topics
+	Licensing           *LicenseSummary        `json:"licensing,omitempty"`   // licenses of owned repositories; nil when none is owned
 	LeadershipIndicators []LeadershipIndicator `json:"leadership_indicators"`
 	MentorshipSigns     []string               `json:"mentorship_signs"`
 	InnovationMetrics   InnovationMetrics      `json:"innovation_metrics"`
@@ -209,6 +250,21 @@ type CommunityMetrics struct {
 	DocumentationContrib   int     `json:"documentation_contributions"`
 }
 
+// FocusArea represents a repository topic shared by several of the user's repositories
+type FocusArea struct {
+	Topic           string `json:"topic"`
+	RepositoryCount int    `json:"repository_count"`
+}
+
+// LicenseSummary counts the licenses of the repositories a user owns, forks excluded
+type LicenseSummary struct {
+	Permissive int    `json:"permissive"` // e.g. MIT, Apache 2.0, BSD
+	Copyleft   int    `json:"copyleft"`   // e.g. GPL, LGPL, MPL
+	Other      int    `json:"other"`      // licenses GitHub does not recognize, or neither of the above
+	Unlicensed int    `json:"unlicensed"` // repositories without a license, which others cannot legally reuse
+	MostUsed   string `json:"most_used,omitempty"`
+}
+
 // ArchitectureSignals represents architectural thinking patterns
 type ArchitectureSignals struct {
 	SystemDesignProjects []string `json:"system_design_projects"`
@@ -216,6 +272,7 @@ type ArchitectureSignals struct {
 	ScalabilityFocus     bool     `json:"scalability_focus"`
 	PerformanceOptimization bool  `json:"performance_optimization"`
 	SecurityMindedness   bool     `json:"security_mindedness"`
+	SecurityPractices    map[string]int `json:"security_practices,omitempty"` // practice -> repositories following it
 	ComplexityScore      float64  `json:"complexity_score"`
 }
 
@@ -227,11 +284,13 @@ type ProfileSnapshot struct {
 	LastEventID   string    `json:"last_event_id"`
 	LastCommitSHA string    `json:"last_commit_sha"`
 	Checkpoints   []Checkpoint `json:"checkpoints"`
+	Profile       *UserProfile `json:"profile,omitempty"` // completed analysis, kept in the history for -diff
 }
 
 // Checkpoint represents incremental update tracking
 type Checkpoint struct {
 	DataType      string    `json:"data_type"` // repos, orgs, events, etc.
+	Key           string    `json:"key,omitempty"` // record identifier, e.g. repository full name
 	LastUpdated   time.Time `json:"last_updated"`
 	LastCursor    string    `json:"last_cursor,omitempty"`
 	RecordCount   int       `json:"record_count"`
@@ -274,6 +333,23 @@ type DiscourseProfile struct {
 	CategoryActivity []discourse.CategoryEngagement              `json:"category_activity"`
 }
 
+// StackOverflowProfile represents Stack Overflow reputation and expertise (simplified for profile integration)
+type StackOverflowProfile struct {
+	UserID          int                    `json:"user_id"`
+	DisplayName     string                 `json:"display_name"`
+	ProfileURL      string                 `json:"profile_url"`
+	Reputation      int                    `json:"reputation"`
+	GoldBadges      int                    `json:"gold_badges"`
+	SilverBadges    int                    `json:"silver_badges"`
+	BronzeBadges    int                    `json:"bronze_badges"`
+	AnswerCount     int                    `json:"answer_count"`
+	AcceptedAnswers int                    `json:"accepted_answers"`
+	AnswersCapped   bool                   `json:"answers_capped,omitempty"` // answer counts are lower bounds
+	TopTags         []stackoverflow.TopTag `json:"top_tags"`
+	JoinedDate      time.Time              `json:"joined_date"`
+	LastActivity    time.Time              `json:"last_activity"`
+}
+
 // DockerConfig represents Docker-related configuration and complexity in a repository
 type DockerConfig struct {
 	HasDockerfile       bool                `json:"has_dockerfile"`
@@ -288,6 +364,13 @@ type DockerConfig struct {
 	ContainerExpertise  DockerExpertiseLevel `json:"container_expertise"`
 }
 
+// CIConfig represents the continuous integration and testing setup found in a repository
+type CIConfig struct {
+	CISystems      []string `json:"ci_systems"`      // e.g., "GitHub Actions", "Jenkins"
+	TestFrameworks []string `json:"test_frameworks"` // e.g., "Go test", "pytest"
+	HasCoverage    bool     `json:"has_coverage"`    // coverage reporting is configured
+}
+
 // DockerFile represents information about a specific Dockerfile
 type DockerFile struct {
 	Path                string   `json:"path"`
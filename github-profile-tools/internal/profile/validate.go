@@ -0,0 +1,48 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// CompletenessCheck is one entry of the checklist produced by CheckCompleteness
+type CompletenessCheck struct {
+	Section  string `json:"section"`
+	Complete bool   `json:"complete"`
+	Hint     string `json:"hint,omitempty"` // how to fill the section in, when it is missing
+}
+
+// LoadProfile reads a profile previously written as <username>_profile.json
+func LoadProfile(path string) (*UserProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	var profile UserProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("invalid profile %s: %w", path, err)
+	}
+	return &profile, nil
+}
+
+// CheckCompleteness reports which analyses of a profile are missing or empty, with a hint on
+// how a re-run could fill them in
+func CheckCompleteness(profile *UserProfile) []CompletenessCheck {
+	contributions := profile.Contributions
+	hasContributions := contributions.TotalCommits > 0 || contributions.TotalPullRequests > 0 ||
+		contributions.TotalIssues > 0 || contributions.TotalCodeReviews > 0
+
+	return []CompletenessCheck{
+		{"Analysis finished", !profile.Partial, "re-run to resume the interrupted analysis, with a longer -timeout if needed"},
+		{"Repositories", len(profile.Repositories) > 0, "check the token can read the user's repositories"},
+		{"Languages", len(profile.Languages) > 0, "re-run with -language-fallback to fetch languages over REST"},
+		{"Contributions", hasContributions, "widen -contrib-since/-contrib-until or check the token scopes"},
+		{"Skills", len(profile.Skills.TechnicalAreas) > 0, "add topics or descriptions to repositories so technologies can be inferred"},
+		{"Impact score", profile.Insights.OverallImpactScore > 0, "re-run the analysis, insights were not generated"},
+		{"Docker Hub profile", profile.DockerHubProfile != nil, "re-run with -docker-user if the Docker Hub username differs"},
+		{"Discourse profile", profile.DiscourseProfile != nil, "re-run with -discourse-user if the community.jenkins.io username differs"},
+		{"Stack Overflow profile", profile.StackOverflowProfile != nil, "re-run with -stackoverflow-user"},
+	}
+}
@@ -0,0 +1,40 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestCheckCompleteness verifies a sparse profile JSON has each of its empty analyses enumerated
+func TestCheckCompleteness(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "octocat_profile.json")
+	sparse := `{
+		"username": "octocat",
+		"repositories": [{"name": "hello-world"}],
+		"languages": [{"language": "Go", "percentage": 100}],
+		"contributions": {"total_commits": 0},
+		"docker_hub_profile": {"username": "octocat"}
+	}`
+	if err := os.WriteFile(path, []byte(sparse), 0644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+
+	profile, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("LoadProfile failed: %v", err)
+	}
+
+	var missing []string
+	for _, check := range CheckCompleteness(profile) {
+		if !check.Complete {
+			missing = append(missing, check.Section)
+		}
+	}
+
+	expected := []string{"Contributions", "Skills", "Impact score", "Discourse profile", "Stack Overflow profile"}
+	if !reflect.DeepEqual(missing, expected) {
+		t.Errorf("Expected missing sections %v, got %v", expected, missing)
+	}
+}
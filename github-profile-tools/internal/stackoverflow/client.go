@@ -0,0 +1,248 @@
+package stackoverflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the Stack Exchange API endpoint
+	DefaultBaseURL = "https://api.stackexchange.com/2.3"
+
+	// DefaultSite is the Stack Exchange site queried for profiles
+	DefaultSite = "stackoverflow"
+
+	answersPageSize = 100 // maximum page size allowed by the API
+	maxAnswerPages  = 10  // cap accepted-answer counting at 1000 answers
+	topTagsLimit    = 10
+)
+
+// profileURLPattern matches linked accounts such as https://stackoverflow.com/users/22656/jon-skeet
+var profileURLPattern = regexp.MustCompile(`/users/(\d+)`)
+
+// Client handles Stack Exchange API interactions
+type Client struct {
+	baseURL    string
+	site       string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Stack Overflow API client
+func NewClient() *Client {
+	return &Client{
+		baseURL: DefaultBaseURL,
+		site:    DefaultSite,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// AnalyzeStackOverflowProfile fetches reputation, top tags and accepted-answer count for a user.
+// The user may be a numeric user ID, a profile URL, or a display name.
+func (c *Client) AnalyzeStackOverflowProfile(ctx context.Context, user string) (*StackOverflowProfile, error) {
+	userID, err := c.resolveUserID(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Analyzing Stack Overflow profile for user ID: %d", userID)
+
+	info, err := c.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user info: %w", err)
+	}
+
+	profile := &StackOverflowProfile{
+		UserID:       info.UserID,
+		DisplayName:  info.DisplayName,
+		ProfileURL:   info.Link,
+		Reputation:   info.Reputation,
+		JoinedDate:   time.Unix(info.CreationDate, 0).UTC(),
+		LastActivity: time.Unix(info.LastAccessDate, 0).UTC(),
+		BadgeCounts:  info.BadgeCounts,
+	}
+
+	tags, err := c.fetchTopTags(ctx, userID)
+	if err != nil {
+		log.Printf("Warning: Failed to fetch Stack Overflow top tags: %v", err)
+	} else {
+		profile.TopTags = tags
+	}
+
+	answerCount, accepted, capped, err := c.fetchAnswerStats(ctx, userID)
+	if err != nil {
+		log.Printf("Warning: Failed to fetch Stack Overflow answers: %v", err)
+	}
+	if capped {
+		log.Printf("Stack Overflow answers counted up to the first %d only", maxAnswerPages*answersPageSize)
+	}
+	profile.AnswerCount = answerCount
+	profile.AcceptedAnswers = accepted
+	profile.AnswersCapped = capped
+
+	return profile, nil
+}
+
+// resolveUserID turns a user ID, profile URL or display name into a numeric user ID
+func (c *Client) resolveUserID(ctx context.Context, user string) (int, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return 0, fmt.Errorf("empty Stack Overflow user")
+	}
+
+	if id, err := strconv.Atoi(user); err == nil {
+		return id, nil
+	}
+
+	if match := profileURLPattern.FindStringSubmatch(user); match != nil {
+		id, _ := strconv.Atoi(match[1])
+		return id, nil
+	}
+
+	// Fall back to a display name search, preferring an exact (case-insensitive) match
+	params := url.Values{}
+	params.Set("inname", user)
+	params.Set("sort", "reputation")
+	params.Set("order", "desc")
+
+	var users []userResponse
+	if _, err := c.get(ctx, "/users", params, &users); err != nil {
+		return 0, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	for _, u := range users {
+		if strings.EqualFold(u.DisplayName, user) {
+			return u.UserID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no Stack Overflow user found with display name: %s", user)
+}
+
+// fetchUser fetches the public profile of a user
+func (c *Client) fetchUser(ctx context.Context, userID int) (*userResponse, error) {
+	var users []userResponse
+	if _, err := c.get(ctx, fmt.Sprintf("/users/%d", userID), nil, &users); err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user not found: %d", userID)
+	}
+
+	return &users[0], nil
+}
+
+// fetchTopTags fetches the tags the user is most active in
+func (c *Client) fetchTopTags(ctx context.Context, userID int) ([]TopTag, error) {
+	params := url.Values{}
+	params.Set("pagesize", strconv.Itoa(topTagsLimit))
+
+	var tags []TopTag
+	if _, err := c.get(ctx, fmt.Sprintf("/users/%d/top-tags", userID), params, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// fetchAnswerStats counts the user's answers and how many of them were accepted. capped reports
+// that more answers remained after maxAnswerPages pages, so both counts are lower bounds.
+func (c *Client) fetchAnswerStats(ctx context.Context, userID int) (total, accepted int, capped bool, err error) {
+
+	for page := 1; page <= maxAnswerPages; page++ {
+		params := url.Values{}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("pagesize", strconv.Itoa(answersPageSize))
+
+		var answers []answerResponse
+		hasMore, err := c.get(ctx, fmt.Sprintf("/users/%d/answers", userID), params, &answers)
+		if err != nil {
+			return total, accepted, false, err
+		}
+
+		for _, answer := range answers {
+			total++
+			if answer.IsAccepted {
+				accepted++
+			}
+		}
+
+		if !hasMore {
+			return total, accepted, false, nil
+		}
+	}
+
+	return total, accepted, true, nil
+}
+
+// get performs a GET request against the API and decodes the items of the response envelope
+func (c *Client) get(ctx context.Context, path string, params url.Values, items interface{}) (bool, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("site", c.site)
+
+	reqURL := fmt.Sprintf("%s%s?%s", c.baseURL, path, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "GitHub-Profile-Analyzer/1.0")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var envelope wrapper
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return false, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		}
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if envelope.ErrorID != 0 {
+		return false, fmt.Errorf("API error %d (%s): %s", envelope.ErrorID, envelope.ErrorName, envelope.ErrorMessage)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(envelope.Items, items); err != nil {
+		return false, fmt.Errorf("failed to decode items: %w", err)
+	}
+
+	// The API asks clients to pause before hitting the same method again
+	if envelope.Backoff > 0 {
+		log.Printf("Stack Exchange API requested backoff of %d seconds (quota remaining: %d)", envelope.Backoff, envelope.QuotaRemaining)
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(time.Duration(envelope.Backoff) * time.Second):
+		}
+	}
+
+	return envelope.HasMore, nil
+}
@@ -0,0 +1,164 @@
+package stackoverflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newFixtureServer serves recorded Stack Exchange API responses for a sample user
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	serveFixture := func(w http.ResponseWriter, name string) {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Errorf("failed to read fixture %s: %v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("site"); got != DefaultSite {
+			t.Errorf("Expected site=%s, got '%s'", DefaultSite, got)
+		}
+
+		switch r.URL.Path {
+		case "/users":
+			serveFixture(w, "users_search.json")
+		case "/users/1288478":
+			serveFixture(w, "user.json")
+		case "/users/1288478/top-tags":
+			serveFixture(w, "top_tags.json")
+		case "/users/1288478/answers":
+			if r.URL.Query().Get("page") == "2" {
+				serveFixture(w, "answers_page2.json")
+			} else {
+				serveFixture(w, "answers_page1.json")
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error_id": 400, "error_name": "bad_parameter", "error_message": "ids"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestClient creates a client pointed at the fixture server
+func newTestClient(server *httptest.Server) *Client {
+	client := NewClient()
+	client.baseURL = server.URL
+	return client
+}
+
+// TestAnalyzeStackOverflowProfile verifies reputation, top tags and accepted answers are collected
+func TestAnalyzeStackOverflowProfile(t *testing.T) {
+	client := newTestClient(newFixtureServer(t))
+
+	profile, err := client.AnalyzeStackOverflowProfile(context.Background(), "1288478")
+	if err != nil {
+		t.Fatalf("AnalyzeStackOverflowProfile failed: %v", err)
+	}
+
+	if profile.DisplayName != "Sample User" {
+		t.Errorf("Expected display name 'Sample User', got '%s'", profile.DisplayName)
+	}
+	if profile.Reputation != 48213 {
+		t.Errorf("Expected reputation 48213, got %d", profile.Reputation)
+	}
+	if profile.BadgeCounts.Gold != 17 {
+		t.Errorf("Expected 17 gold badges, got %d", profile.BadgeCounts.Gold)
+	}
+	if profile.JoinedDate.Year() != 2012 {
+		t.Errorf("Expected joined year 2012, got %d", profile.JoinedDate.Year())
+	}
+	if len(profile.TopTags) != 3 || profile.TopTags[0].TagName != "jenkins" {
+		t.Errorf("Expected 3 top tags led by 'jenkins', got %+v", profile.TopTags)
+	}
+	if profile.AnswerCount != 142 {
+		t.Errorf("Expected 142 answers across both pages, got %d", profile.AnswerCount)
+	}
+	if profile.AcceptedAnswers != 48 {
+		t.Errorf("Expected 48 accepted answers, got %d", profile.AcceptedAnswers)
+	}
+	if profile.AnswersCapped {
+		t.Error("Expected uncapped counts when every answer page was read")
+	}
+}
+
+// TestAnswerStatsCapped verifies counting stops after maxAnswerPages pages and the profile records
+// that the counts are lower bounds
+func TestAnswerStatsCapped(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		items := make([]string, answersPageSize)
+		for i := range items {
+			items[i] = fmt.Sprintf(`{"is_accepted": %t, "answer_id": %d}`, i%2 == 0, i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"items": [%s], "has_more": true}`, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	total, accepted, capped, err := newTestClient(server).fetchAnswerStats(context.Background(), 1288478)
+	if err != nil {
+		t.Fatalf("fetchAnswerStats failed: %v", err)
+	}
+	if pages != maxAnswerPages || total != maxAnswerPages*answersPageSize || accepted != total/2 {
+		t.Errorf("Expected %d pages of answers, got %d pages, %d answers, %d accepted", maxAnswerPages, pages, total, accepted)
+	}
+	if !capped {
+		t.Error("Expected the counts to be reported as capped")
+	}
+}
+
+// TestResolveUserID verifies that IDs, profile URLs and display names all resolve to the same user
+func TestResolveUserID(t *testing.T) {
+	client := newTestClient(newFixtureServer(t))
+
+	inputs := []string{
+		"1288478",
+		"https://stackoverflow.com/users/1288478/sample-user",
+		"sample user",
+	}
+
+	for _, input := range inputs {
+		id, err := client.resolveUserID(context.Background(), input)
+		if err != nil {
+			t.Errorf("resolveUserID(%q) failed: %v", input, err)
+			continue
+		}
+		if id != 1288478 {
+			t.Errorf("resolveUserID(%q) = %d, expected 1288478", input, id)
+		}
+	}
+}
+
+// TestResolveUserIDNoExactMatch verifies that a display name search without an exact match fails
+func TestResolveUserIDNoExactMatch(t *testing.T) {
+	client := newTestClient(newFixtureServer(t))
+
+	if _, err := client.resolveUserID(context.Background(), "Sample"); err == nil {
+		t.Error("Expected error for display name without exact match")
+	}
+}
+
+// TestAPIErrorSurfaced verifies that Stack Exchange error envelopes are returned as errors
+func TestAPIErrorSurfaced(t *testing.T) {
+	client := newTestClient(newFixtureServer(t))
+
+	if _, err := client.AnalyzeStackOverflowProfile(context.Background(), "42"); err == nil {
+		t.Error("Expected error for unknown user")
+	}
+}
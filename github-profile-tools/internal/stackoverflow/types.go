@@ -0,0 +1,66 @@
+package stackoverflow
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// StackOverflowProfile represents a user's Stack Overflow reputation and answering track record
+type StackOverflowProfile struct {
+	UserID          int         `json:"user_id"`
+	DisplayName     string      `json:"display_name"`
+	ProfileURL      string      `json:"profile_url"`
+	Reputation      int         `json:"reputation"`
+	JoinedDate      time.Time   `json:"joined_date"`
+	LastActivity    time.Time   `json:"last_activity"`
+	BadgeCounts     BadgeCounts `json:"badge_counts"`
+	AnswerCount     int         `json:"answer_count"`
+	AcceptedAnswers int         `json:"accepted_answers"`
+	AnswersCapped   bool        `json:"answers_capped,omitempty"` // answer counts stopped at maxAnswerPages pages
+	TopTags         []TopTag    `json:"top_tags"`
+}
+
+// BadgeCounts holds the number of gold, silver and bronze badges earned
+type BadgeCounts struct {
+	Gold   int `json:"gold"`
+	Silver int `json:"silver"`
+	Bronze int `json:"bronze"`
+}
+
+// TopTag represents a tag the user is most active in
+type TopTag struct {
+	TagName       string `json:"tag_name"`
+	AnswerCount   int    `json:"answer_count"`
+	AnswerScore   int    `json:"answer_score"`
+	QuestionCount int    `json:"question_count"`
+	QuestionScore int    `json:"question_score"`
+}
+
+// userResponse represents a user item from the Stack Exchange API
+type userResponse struct {
+	UserID         int         `json:"user_id"`
+	DisplayName    string      `json:"display_name"`
+	Link           string      `json:"link"`
+	Reputation     int         `json:"reputation"`
+	CreationDate   int64       `json:"creation_date"`
+	LastAccessDate int64       `json:"last_access_date"`
+	BadgeCounts    BadgeCounts `json:"badge_counts"`
+}
+
+// answerResponse represents an answer item from the Stack Exchange API
+type answerResponse struct {
+	AnswerID   int  `json:"answer_id"`
+	IsAccepted bool `json:"is_accepted"`
+	Score      int  `json:"score"`
+}
+
+// wrapper is the common envelope returned by every Stack Exchange API endpoint
+type wrapper struct {
+	Items          json.RawMessage `json:"items"`
+	HasMore        bool            `json:"has_more"`
+	QuotaRemaining int             `json:"quota_remaining"`
+	Backoff        int             `json:"backoff,omitempty"`
+	ErrorID        int             `json:"error_id,omitempty"`
+	ErrorName      string          `json:"error_name,omitempty"`
+	ErrorMessage   string          `json:"error_message,omitempty"`
+}
@@ -5,4 +5,7 @@ go 1.25.0
 require (
 	golang.org/x/oauth2 v0.36.0
 	golang.org/x/time v0.15.0
+	jenkins.io/alpha-omega-stats/ratelimit v0.0.0-00010101000000-000000000000
 )
+
+replace jenkins.io/alpha-omega-stats/ratelimit => ./ratelimit
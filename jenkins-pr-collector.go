@@ -3,35 +3,37 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
-	"math/rand"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/time/rate"
+	"jenkins.io/alpha-omega-stats/ratelimit"
 )
 
-func init() {
-	// Seed the random number generator for more unpredictable jitter
-	rand.Seed(time.Now().UnixNano())
-}
-
 // PullRequest represents a GitHub pull request
 type PullRequest struct {
-	Number     int       `json:"number"`
-	Title      string    `json:"title"`
-	State      string    `json:"state"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
-	URL        string    `json:"url"`
+	Number     int        `json:"number"`
+	Title      string     `json:"title"`
+	State      string     `json:"state"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	URL        string     `json:"url"`
+	Merged     bool       `json:"merged"`
+	MergedAt   *time.Time `json:"mergedAt"`
+	ClosedAt   *time.Time `json:"closedAt"`
 	Repository struct {
 		Name  string `json:"name"`
 		Owner struct {
@@ -68,12 +70,15 @@ type GraphQLSearchResponse struct {
 		} `json:"pageInfo"`
 		Nodes []struct {
 			// Remove the nested PullRequest struct and flatten the fields
-			Number     int       `json:"number"`
-			Title      string    `json:"title"`
-			State      string    `json:"state"`
-			CreatedAt  time.Time `json:"createdAt"`
-			UpdatedAt  time.Time `json:"updatedAt"`
-			URL        string    `json:"url"`
+			Number     int        `json:"number"`
+			Title      string     `json:"title"`
+			State      string     `json:"state"`
+			CreatedAt  time.Time  `json:"createdAt"`
+			UpdatedAt  time.Time  `json:"updatedAt"`
+			URL        string     `json:"url"`
+			Merged     bool       `json:"merged"`
+			MergedAt   *time.Time `json:"mergedAt"`
+			ClosedAt   *time.Time `json:"closedAt"`
 			Repository struct {
 				Name  string `json:"name"`
 				Owner struct {
@@ -107,18 +112,41 @@ type GraphQLSearchResponse struct {
 
 // PullRequestData represents the data we want to collect about PRs
 type PullRequestData struct {
-	Number      int       `json:"number"`
-	Title       string    `json:"title"`
-	State       string    `json:"state"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	User        string    `json:"user"`
-	Repository  string    `json:"repository"`
-	PluginName  string    `json:"pluginName"`
-	Labels      []string  `json:"labels"`
-	URL         string    `json:"url"`
-	Description string    `json:"description,omitempty"`
-	CheckStatus string    `json:"checkStatus,omitempty"`
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	State       string     `json:"state"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	User        string     `json:"user"`
+	Repository  string     `json:"repository"`
+	PluginName  string     `json:"pluginName"`
+	Labels      []string   `json:"labels"`
+	URL         string     `json:"url"`
+	Description string     `json:"description,omitempty"`
+	CheckStatus string     `json:"checkStatus,omitempty"`
+	Popularity  int        `json:"popularity,omitempty"`
+	MergedAt    *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt    *time.Time `json:"closedAt,omitempty"`
+	Outcome     string     `json:"outcome"` // one of outcomeMerged, outcomeClosedUnmerged or outcomeOpen
+}
+
+// Outcomes of a pull request, telling merged PRs apart from PRs closed without merging
+const (
+	outcomeMerged         = "merged"
+	outcomeClosedUnmerged = "closed-unmerged"
+	outcomeOpen           = "open"
+)
+
+// pullRequestOutcome derives how a PR ended from its merged flag and state
+func pullRequestOutcome(merged bool, state string) string {
+	switch {
+	case merged || strings.EqualFold(state, "MERGED"):
+		return outcomeMerged
+	case strings.EqualFold(state, "CLOSED"):
+		return outcomeClosedUnmerged
+	default:
+		return outcomeOpen
+	}
 }
 
 // PluginInfo represents the information we need from the plugins.json file
@@ -142,8 +170,124 @@ type Config struct {
 	EndDate               time.Time
 	OutputFile            string
 	FoundPullRequestsFile string
+	CSVOutputFile         string
+	JSONLOutputFile       string // streams each collected PR as one JSON object per line instead of writing OutputFile
+	ReportFile            string // aggregate statistics of the collected PRs
 	UpdateCenterURL       string
 	RateLimit             rate.Limit
+	Concurrency           int            // number of months fetched in parallel
+	PageSize              int            // PRs per search page, from 1 to maxPageSize
+	Orgs                  []string       // organizations whose PRs are searched; empty searches defaultOrgs
+	PopularityFile        string         // CSV of plugin name,popularity such as top-250-plugins.csv
+	MinPopularity         int            // drop collected PRs of plugins below this popularity
+	Popularity            map[string]int // loaded from PopularityFile before collection starts
+	Labels                LabelFilter
+	BodyTerms             []string // lowercase terms a PR body must contain; empty keeps all plugin PRs
+	CheckStatuses         []string // CI rollup statuses of collected PRs; empty keeps all statuses
+	States                []string // PR states of collected PRs; empty keeps all states
+	ExcludeAuthors        []string // lowercase logins whose PRs are dropped from all outputs
+}
+
+// defaultExcludedAuthors lists the dependency bots under both their plain and GitHub App logins
+const defaultExcludedAuthors = "dependabot,dependabot[bot],renovate,renovate[bot]"
+
+// isExcludedAuthor reports whether login is one of the excluded authors (case-insensitive)
+func isExcludedAuthor(login string, excluded []string) bool {
+	for _, author := range excluded {
+		if strings.EqualFold(login, author) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultBodyTerms targets the OpenRewrite plugin modernizer campaign
+const defaultBodyTerms = "modernizer,recipe"
+
+// bodyContainsAny reports whether bodyText contains any of terms (already lowercase).
+// An empty term list matches every body.
+func bodyContainsAny(bodyText string, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	body := strings.ToLower(bodyText)
+	for _, term := range terms {
+		if strings.Contains(body, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStatuses lists the values getCommitStatus can return
+var checkStatuses = []string{"SUCCESS", "FAILURE", "PENDING", "ERROR", "EXPECTED", "UNKNOWN"}
+
+// pullRequestStates lists the states of a GitHub pull request
+var pullRequestStates = []string{"OPEN", "MERGED", "CLOSED"}
+
+// matchesAny reports whether value equals any of allowed (case-insensitive). An empty list matches every value.
+func matchesAny(value string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(value, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateValues returns an error naming the first of values that is not in allowed
+func validateValues(name string, values, allowed []string) error {
+	for _, value := range values {
+		if !matchesAny(value, allowed) {
+			return fmt.Errorf("invalid %s %q, expected one of %s", name, value, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// LabelFilter selects pull requests by their GitHub labels, compared case-insensitively
+type LabelFilter struct {
+	Include []string // when set, only collect PRs carrying any of these labels
+	Exclude []string // never collect PRs carrying any of these labels
+}
+
+// parseCommaSeparated splits a comma-separated flag value into trimmed lowercase values
+func parseCommaSeparated(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// hasAnyLabel reports whether labels contains any of wanted (already lowercase)
+func hasAnyLabel(labels, wanted []string) bool {
+	for _, label := range labels {
+		for _, w := range wanted {
+			if strings.EqualFold(label, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectPullRequest reports whether a plugin PR belongs in the collected output. Every configured
+// filter must pass: its body contains one of bodyTerms, it carries an included label, and it
+// carries no excluded label.
+func selectPullRequest(bodyText string, labels []string, bodyTerms []string, filter LabelFilter) bool {
+	if hasAnyLabel(labels, filter.Exclude) {
+		return false
+	}
+	if len(filter.Include) > 0 && !hasAnyLabel(labels, filter.Include) {
+		return false
+	}
+	return bodyContainsAny(bodyText, bodyTerms)
 }
 
 // GraphQLClient represents a simple GitHub GraphQL API client
@@ -178,6 +322,17 @@ type PartialData struct {
 	Timestamp  time.Time     `json:"timestamp"`
 }
 
+// CollectionProgress is the resumable state of fetchPullRequestsGraphQL, saved after every page
+type CollectionProgress struct {
+	StartDate  time.Time         `json:"start_date"` // requested range, resumption requires the same range
+	EndDate    time.Time         `json:"end_date"`
+	MonthStart time.Time         `json:"month_start"` // first day of the month being fetched
+	LastCursor string            `json:"last_cursor"` // end cursor of the last completed page of that month
+	PRs        []PullRequestData `json:"prs"`
+	FoundPRs   []PullRequestData `json:"found_prs"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
 var allFoundPRs []PullRequestData
 
 // Add these new types and constants
@@ -196,15 +351,9 @@ func (e *RetryableError) Error() string {
 	return e.Err.Error()
 }
 
+// calculateBackoffDuration calculates exponential backoff with up to 10% jitter
 func calculateBackoffDuration(attempt int) time.Duration {
-	// Calculate exponential backoff with jitter
-	delay := baseDelay * time.Duration(1<<uint(attempt))
-	if delay > maxDelay {
-		delay = maxDelay
-	}
-	// Add jitter (±10%)
-	jitter := time.Duration(rand.Float64()*0.2-0.1) * delay
-	return delay + jitter
+	return ratelimit.Backoff(baseDelay, maxDelay, 0.1, attempt)
 }
 
 func isRateLimitError(err error) bool {
@@ -239,7 +388,21 @@ func main() {
 	endDateFlag := flag.String("end", "", "End date in YYYY-MM-DD format")
 	outputFileFlag := flag.String("output", "jenkins_prs.json", "Output file name")
 	foundPRsFileFlag := flag.String("found-prs", "found_prs.json", "File to write found PRs")
+	csvOutputFlag := flag.String("csv-output", "", "Also write the collected PRs as CSV to this file")
+	reportFlag := flag.String("report", "", "Also write aggregate statistics of the collected PRs (states, authors, top plugins) as JSON to this file")
+	jsonlOutputFlag := flag.String("jsonl-output", "", "Stream the collected PRs to this file as JSON Lines, one PR per line as each page is fetched, instead of keeping them in memory for -output. -found-prs is then streamed as JSON Lines too")
 	updateCenterURLFlag := flag.String("update-center", "https://updates.jenkins.io/current/update-center.actual.json", "Jenkins update center URL")
+	includeLabelsFlag := flag.String("include-labels", "", "Comma-separated labels; only PRs carrying one of them are collected, in addition to matching -body-contains")
+	excludeAuthorsFlag := flag.String("exclude-authors", defaultExcludedAuthors, "Comma-separated author logins whose PRs are left out of all output files")
+	excludeLabelsFlag := flag.String("exclude-labels", "", "Comma-separated labels; PRs carrying any of them are never collected")
+	pageSizeFlag := flag.Int("page-size", maxPageSize, "PRs fetched per search page (1-100); lower it when GitHub often answers \"Something went wrong\"")
+	orgsFlag := flag.String("orgs", defaultOrgs, "Comma-separated GitHub organizations whose PRs are searched")
+	concurrencyFlag := flag.Int("concurrency", 3, "Number of months fetched in parallel (the rate limit still applies globally)")
+	popularityCSVFlag := flag.String("popularity-csv", "", "CSV file of plugin name,popularity (e.g. top-250-plugins.csv) used to annotate PRs")
+	minPopularityFlag := flag.Int("min-popularity", 0, "Drop PRs of plugins whose popularity is below this value (requires -popularity-csv)")
+	checkStatusFlag := flag.String("check-status", "", "Comma-separated CI rollup statuses (SUCCESS,FAILURE,PENDING,ERROR,EXPECTED,UNKNOWN) of the PRs to collect; empty keeps all")
+	statesFlag := flag.String("states", "", "Comma-separated PR states (OPEN,MERGED,CLOSED) to collect; empty keeps all")
+	bodyContainsFlag := flag.String("body-contains", defaultBodyTerms, "Comma-separated terms; only PRs whose body contains one of them are collected (empty keeps all plugin PRs)")
 	flag.Parse()
 
 	// Validate required parameters
@@ -247,6 +410,25 @@ func main() {
 		log.Fatal("GitHub token is required. Set GITHUB_TOKEN environment variable or use -token flag.")
 	}
 
+	if *concurrencyFlag < 1 {
+		log.Fatal("Concurrency must be at least 1.")
+	}
+	if len(parseCommaSeparated(*orgsFlag)) == 0 {
+		log.Fatal("At least one organization is required.")
+	}
+	if *pageSizeFlag < 1 || *pageSizeFlag > maxPageSize {
+		log.Fatalf("Page size must be between 1 and %d.", maxPageSize)
+	}
+	if err := validateValues("check status", parseCommaSeparated(*checkStatusFlag), checkStatuses); err != nil {
+		log.Fatal(err)
+	}
+	if err := validateValues("state", parseCommaSeparated(*statesFlag), pullRequestStates); err != nil {
+		log.Fatal(err)
+	}
+	if *minPopularityFlag > 0 && *popularityCSVFlag == "" {
+		log.Fatal("-min-popularity requires -popularity-csv.")
+	}
+
 	// Parse dates
 	startDate, err := time.Parse("2006-01-02", *startDateFlag)
 	if err != nil {
@@ -270,8 +452,24 @@ func main() {
 		EndDate:               endDate,
 		OutputFile:            *outputFileFlag,
 		FoundPullRequestsFile: *foundPRsFileFlag,
+		CSVOutputFile:         *csvOutputFlag,
+		JSONLOutputFile:       *jsonlOutputFlag,
+		ReportFile:            *reportFlag,
 		UpdateCenterURL:       *updateCenterURLFlag,
 		RateLimit:             rate.Limit(1), // 1 request per second is conservative
+		Concurrency:           *concurrencyFlag,
+		PageSize:              *pageSizeFlag,
+		Orgs:                  parseCommaSeparated(*orgsFlag),
+		PopularityFile:        *popularityCSVFlag,
+		MinPopularity:         *minPopularityFlag,
+		Labels: LabelFilter{
+			Include: parseCommaSeparated(*includeLabelsFlag),
+			Exclude: parseCommaSeparated(*excludeLabelsFlag),
+		},
+		BodyTerms:      parseCommaSeparated(*bodyContainsFlag),
+		CheckStatuses:  parseCommaSeparated(*checkStatusFlag),
+		States:         parseCommaSeparated(*statesFlag),
+		ExcludeAuthors: parseCommaSeparated(*excludeAuthorsFlag),
 	}
 
 	// Initialize GraphQL client
@@ -280,6 +478,8 @@ func main() {
 		&oauth2.Token{AccessToken: config.GithubToken},
 	)
 	tc := oauth2.NewClient(ctx, ts)
+	// Hold requests back while GitHub reports the rate limit budget as exhausted or nearly so
+	tc.Transport = ratelimit.New(5000, "graphql").Transport(tc.Transport)
 	graphqlClient := &GraphQLClient{
 		httpClient: tc,
 		endpoint:   "https://api.github.com/graphql",
@@ -288,6 +488,15 @@ func main() {
 	// Create a rate limiter
 	limiter := rate.NewLimiter(config.RateLimit, 1)
 
+	// Load popularity before collecting, so that streamed PRs are annotated and filtered too
+	if config.PopularityFile != "" {
+		config.Popularity, err = loadPopularity(config.PopularityFile)
+		if err != nil {
+			log.Fatalf("Failed to load plugin popularity: %v", err)
+		}
+		log.Printf("Loaded popularity for %d plugins", len(config.Popularity))
+	}
+
 	// Fetch Jenkins plugin repositories from update center
 	log.Println("Fetching Jenkins plugin information from update center...")
 	pluginRepos, err := fetchJenkinsPluginInfo(config.UpdateCenterURL)
@@ -302,17 +511,50 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to fetch pull requests: %v", err)
 	}
-	log.Printf("Found %d pull requests", len(pullRequests))
 
-	// Write results to file
-	log.Printf("Writing results to %s...", config.OutputFile)
-	err = writeJSONFile(config.OutputFile, pullRequests)
-	if err != nil {
-		log.Fatalf("Failed to write output file: %v", err)
+	if config.PopularityFile != "" {
+		allFoundPRs = applyPopularity(allFoundPRs, config.Popularity, 0)
+		pullRequests = applyPopularity(pullRequests, config.Popularity, config.MinPopularity)
+	}
+
+	// Streamed PRs are only on disk, so the other outputs read them back one at a time
+	collected := slicePRs(pullRequests)
+	if config.JSONLOutputFile != "" {
+		log.Printf("Streamed the collected pull requests to %s", config.JSONLOutputFile)
+		collected = jsonlPRs(config.JSONLOutputFile)
+	} else {
+		log.Printf("Found %d pull requests", len(pullRequests))
+
+		// Write results to file
+		log.Printf("Writing results to %s...", config.OutputFile)
+		err = writeJSONFile(config.OutputFile, pullRequests)
+		if err != nil {
+			log.Fatalf("Failed to write output file: %v", err)
+		}
+	}
+
+	if config.CSVOutputFile != "" {
+		log.Printf("Writing results as CSV to %s...", config.CSVOutputFile)
+		if err := writeCSVFile(config.CSVOutputFile, collected); err != nil {
+			log.Fatalf("Failed to write CSV output file: %v", err)
+		}
+	}
+
+	if config.ReportFile != "" {
+		log.Printf("Writing summary report to %s...", config.ReportFile)
+		report, err := buildReport(collected, config.ExcludeAuthors)
+		if err == nil {
+			err = writeJSONFile(config.ReportFile, report)
+		}
+		if err != nil {
+			log.Fatalf("Failed to write report file: %v", err)
+		}
 	}
 
 	// Write found PRs to another file if any PRs were found
-	if len(allFoundPRs) > 0 {
+	if config.JSONLOutputFile != "" {
+		log.Printf("Streamed all found PRs to %s", config.FoundPullRequestsFile)
+	} else if len(allFoundPRs) > 0 {
 		log.Printf("Writing all found PRs to %s...", config.FoundPullRequestsFile)
 		err = writeJSONFile(config.FoundPullRequestsFile, allFoundPRs)
 		if err != nil {
@@ -514,7 +756,7 @@ func (c *GraphQLClient) executeGraphQLRequest(ctx context.Context, req *GraphQLR
 	if len(graphqlResp.Errors) > 0 {
 		// Check if any of the errors are rate limit related
 		for _, gqlErr := range graphqlResp.Errors {
-			if isRateLimitError(fmt.Errorf(gqlErr.Message)) {
+			if isRateLimitError(errors.New(gqlErr.Message)) {
 				return &RetryableError{
 					Err:       fmt.Errorf("graphql rate limit error: %q", gqlErr.Message),
 					ShouldLog: true,
@@ -610,6 +852,62 @@ func loadPartialData(outputFile string) (*PartialData, error) {
 	return &partial, nil
 }
 
+// saveCollectionProgress atomically writes the monthly loop state to outputFile.partial
+func saveCollectionProgress(progress CollectionProgress, outputFile string) error {
+	progress.Timestamp = time.Now()
+
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling collection progress: %v", err)
+	}
+
+	tmpFile := outputFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("error writing collection progress: %v", err)
+	}
+
+	if err := os.Rename(tmpFile, outputFile+".partial"); err != nil {
+		return fmt.Errorf("error saving collection progress: %v", err)
+	}
+
+	return nil
+}
+
+// loadCollectionProgress reads outputFile.partial, returning nil when there is nothing to resume:
+// no file, a file older than 24 hours, a different date range or an unreadable legacy format
+func loadCollectionProgress(outputFile string, startDate, endDate time.Time) (*CollectionProgress, error) {
+	partialFile := outputFile + ".partial"
+	data, err := os.ReadFile(partialFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading collection progress: %v", err)
+	}
+
+	var progress CollectionProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("error parsing collection progress: %v", err)
+	}
+
+	if time.Since(progress.Timestamp) > 24*time.Hour {
+		os.Remove(partialFile)
+		return nil, nil
+	}
+
+	if !progress.StartDate.Equal(startDate) || !progress.EndDate.Equal(endDate) {
+		log.Printf("Ignoring progress for a different date range (%s..%s)",
+			progress.StartDate.Format("2006-01-02"), progress.EndDate.Format("2006-01-02"))
+		return nil, nil
+	}
+
+	if progress.MonthStart.Before(startDate) || progress.MonthStart.After(endDate) {
+		return nil, nil
+	}
+
+	return &progress, nil
+}
+
 // Modify executeGraphQLQuery to use exponential backoff
 func executeGraphQLQuery(client *http.Client, query string, variables map[string]interface{}) (*GraphQLSearchResponse, error) {
 	var lastErr error
@@ -664,8 +962,9 @@ func fetchPullRequests(client *http.Client, startDate, endDate string, outputFil
 
 	for {
 		variables := map[string]interface{}{
-			"queryString": buildSearchQuery(startDate, endDate),
+			"queryString": buildSearchQuery(nil, startDate, endDate),
 			"cursor":      cursor,
+			"pageSize":    maxPageSize,
 		}
 
 		resp, err := executeGraphQLQuery(client, searchQuery, variables)
@@ -719,6 +1018,244 @@ func writeJSONFile(filename string, data interface{}) error {
 	return encoder.Encode(data)
 }
 
+// loadPopularity reads a name,popularity CSV such as top-250-plugins.csv into a map keyed by plugin name
+func loadPopularity(filename string) (map[string]int, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 2
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", filename, err)
+	}
+
+	popularity := make(map[string]int)
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "name") {
+			continue // header
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid popularity for plugin %s: %v", record[0], err)
+		}
+		popularity[strings.TrimSpace(record[0])] = value
+	}
+	return popularity, nil
+}
+
+// applyPopularity sets the popularity of each PR from its plugin name and, when minPopularity is
+// positive, drops PRs of plugins below it. Plugins missing from the CSV have a popularity of 0.
+func applyPopularity(prs []PullRequestData, popularity map[string]int, minPopularity int) []PullRequestData {
+	var result []PullRequestData
+	for _, pr := range prs {
+		pr.Popularity = popularity[pr.PluginName]
+		if minPopularity > 0 && pr.Popularity < minPopularity {
+			continue
+		}
+		result = append(result, pr)
+	}
+	return result
+}
+
+// csvHeader lists the columns written by writeCSVFile
+var csvHeader = []string{"Number", "Title", "State", "User", "Repository", "PluginName", "URL", "CheckStatus", "Labels", "CreatedAt"}
+
+// writeCSVFile writes pull requests to a CSV file, joining labels with ";"
+func writeCSVFile(filename string, prs eachPR) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	err = prs(func(pr PullRequestData) error {
+		record := []string{
+			strconv.Itoa(pr.Number),
+			pr.Title,
+			pr.State,
+			pr.User,
+			pr.Repository,
+			pr.PluginName,
+			pr.URL,
+			pr.CheckStatus,
+			strings.Join(pr.Labels, ";"),
+			pr.CreatedAt.Format(time.RFC3339),
+		}
+		return writer.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// reportTopPlugins is the number of plugins listed in a summary report
+const reportTopPlugins = 10
+
+// PluginCount is the number of collected PRs of one plugin
+type PluginCount struct {
+	Plugin string `json:"plugin"`
+	PRs    int    `json:"prs"`
+}
+
+// Report holds aggregate statistics of the collected PRs, for a campaign dashboard
+type Report struct {
+	TotalPRs    int            `json:"totalPRs"`
+	ByState     map[string]int `json:"byState"`
+	ByAuthor    map[string]int `json:"byAuthor"`
+	TopPlugins  []PluginCount  `json:"topPlugins"`
+	MergedRatio float64        `json:"mergedRatio"` // merged PRs over merged and open PRs, 0 when there are none
+}
+
+// buildReport aggregates prs by state, author and plugin. PRs of excluded authors are not
+// counted by author, and plugins with the same number of PRs are listed by name.
+func buildReport(prs eachPR, excludeAuthors []string) (Report, error) {
+	report := Report{
+		ByState:    make(map[string]int),
+		ByAuthor:   make(map[string]int),
+		TopPlugins: []PluginCount{},
+	}
+
+	byPlugin := make(map[string]int)
+	err := prs(func(pr PullRequestData) error {
+		report.TotalPRs++
+		report.ByState[pr.State]++
+		if !isExcludedAuthor(pr.User, excludeAuthors) {
+			report.ByAuthor[pr.User]++
+		}
+		byPlugin[pr.PluginName]++
+		return nil
+	})
+	if err != nil {
+		return Report{}, err
+	}
+
+	for plugin, count := range byPlugin {
+		report.TopPlugins = append(report.TopPlugins, PluginCount{Plugin: plugin, PRs: count})
+	}
+	sort.Slice(report.TopPlugins, func(i, j int) bool {
+		a, b := report.TopPlugins[i], report.TopPlugins[j]
+		if a.PRs != b.PRs {
+			return a.PRs > b.PRs
+		}
+		return a.Plugin < b.Plugin
+	})
+	if len(report.TopPlugins) > reportTopPlugins {
+		report.TopPlugins = report.TopPlugins[:reportTopPlugins]
+	}
+
+	merged, open := report.ByState["MERGED"], report.ByState["OPEN"]
+	if merged+open > 0 {
+		report.MergedRatio = float64(merged) / float64(merged+open)
+	}
+	return report, nil
+}
+
+// eachPR calls fn with every PR of a source in turn, stopping at the first error
+type eachPR func(fn func(PullRequestData) error) error
+
+// slicePRs is the eachPR of PRs held in memory
+func slicePRs(prs []PullRequestData) eachPR {
+	return func(fn func(PullRequestData) error) error {
+		for _, pr := range prs {
+			if err := fn(pr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// jsonlPRs is the eachPR of a JSON Lines file, decoding one PR at a time
+func jsonlPRs(filename string) eachPR {
+	return func(fn func(PullRequestData) error) error {
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		decoder := json.NewDecoder(file)
+		for {
+			var pr PullRequestData
+			if err := decoder.Decode(&pr); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("reading %s: %w", filename, err)
+			}
+			if err := fn(pr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// jsonlWriter streams pull requests to a JSON Lines file, one object per line. Only the
+// repo#number keys of written PRs are kept, to skip PRs returned for two months. Callers
+// serialize their calls.
+type jsonlWriter struct {
+	file    *os.File
+	encoder *json.Encoder
+	seen    map[string]bool
+	written int
+}
+
+// newJSONLWriter creates (or truncates) filename for streaming. When resuming, it appends to
+// filename instead, skipping the PRs the interrupted run already wrote there.
+func newJSONLWriter(filename string, resume bool) (*jsonlWriter, error) {
+	w := &jsonlWriter{seen: make(map[string]bool)}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		err := jsonlPRs(filename)(func(pr PullRequestData) error {
+			w.seen[pullRequestKey(pr)] = true
+			w.written++
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(filename, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	w.file, w.encoder = file, json.NewEncoder(file)
+	return w, nil
+}
+
+// Write appends each PR not yet written
+func (w *jsonlWriter) Write(prs []PullRequestData) error {
+	for _, pr := range prs {
+		key := pullRequestKey(pr)
+		if w.seen[key] {
+			continue
+		}
+		w.seen[key] = true
+		if err := w.encoder.Encode(pr); err != nil {
+			return err
+		}
+		w.written++
+	}
+	return nil
+}
+
+// Close closes the underlying file
+func (w *jsonlWriter) Close() error {
+	return w.file.Close()
+}
+
 // Add these helper functions
 func sendGraphQLRequest(client *http.Client, query string, variables map[string]interface{}) (*GraphQLSearchResponse, error) {
 	// Prepare the request body
@@ -764,14 +1301,30 @@ func sendGraphQLRequest(client *http.Client, query string, variables map[string]
 	return &response, nil
 }
 
-func buildSearchQuery(startDate, endDate string) string {
-	return fmt.Sprintf("org:jenkinsci is:pr created:%s..%s", startDate, endDate)
+// defaultOrgs is the organization searched when no other is configured
+const defaultOrgs = "jenkinsci"
+
+// buildSearchQuery builds the search query string for PRs of orgs created between two
+// YYYY-MM-DD dates, e.g. "org:a org:b is:pr created:2025-01-01..2025-01-31"
+func buildSearchQuery(orgs []string, startDate, endDate string) string {
+	if len(orgs) == 0 {
+		orgs = []string{defaultOrgs}
+	}
+	var query strings.Builder
+	for _, org := range orgs {
+		fmt.Fprintf(&query, "org:%s ", org)
+	}
+	fmt.Fprintf(&query, "is:pr created:%s..%s", startDate, endDate)
+	return query.String()
 }
 
-// GraphQL query for searching PRs
+// maxPageSize is the largest search page GitHub serves, and the default page size
+const maxPageSize = 100
+
+// GraphQL query for searching PRs, $pageSize PRs at a time
 const searchQuery = `
-query SearchPullRequests($queryString: String!, $cursor: String) {
-	search(query: $queryString, type: ISSUE, first: 100, after: $cursor) {
+query SearchPullRequests($queryString: String!, $cursor: String, $pageSize: Int!) {
+	search(query: $queryString, type: ISSUE, first: $pageSize, after: $cursor) {
                 pageInfo {
                     hasNextPage
                     endCursor
@@ -784,6 +1337,9 @@ query SearchPullRequests($queryString: String!, $cursor: String) {
                         createdAt
                         updatedAt
                         url
+                        merged
+                        mergedAt
+                        closedAt
                         repository {
                             name
                             owner {
@@ -814,12 +1370,15 @@ query SearchPullRequests($queryString: String!, $cursor: String) {
         }`
 
 func convertNodeToPR(node struct {
-	Number     int       `json:"number"`
-	Title      string    `json:"title"`
-	State      string    `json:"state"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
-	URL        string    `json:"url"`
+	Number     int        `json:"number"`
+	Title      string     `json:"title"`
+	State      string     `json:"state"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	URL        string     `json:"url"`
+	Merged     bool       `json:"merged"`
+	MergedAt   *time.Time `json:"mergedAt"`
+	ClosedAt   *time.Time `json:"closedAt"`
 	Repository struct {
 		Name  string `json:"name"`
 		Owner struct {
@@ -852,6 +1411,9 @@ func convertNodeToPR(node struct {
 		CreatedAt:  node.CreatedAt,
 		UpdatedAt:  node.UpdatedAt,
 		URL:        node.URL,
+		Merged:     node.Merged,
+		MergedAt:   node.MergedAt,
+		ClosedAt:   node.ClosedAt,
 		Repository: node.Repository,
 		Author:     node.Author,
 		BodyText:   node.BodyText,
@@ -861,38 +1423,174 @@ func convertNodeToPR(node struct {
 	return pr
 }
 
+// monthRange is one calendar-month chunk of the collection date range
+type monthRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// splitMonths splits the date range into monthly chunks, the last one ending at endDate
+func splitMonths(startDate, endDate time.Time) []monthRange {
+	var months []monthRange
+	for startDate.Before(endDate) {
+		// Calculate the end of the current month
+		currentEndDate := startDate.AddDate(0, 1, -startDate.Day())
+		if currentEndDate.After(endDate) {
+			currentEndDate = endDate
+		}
+		months = append(months, monthRange{Start: startDate, End: currentEndDate})
+
+		// Move to the next month
+		startDate = currentEndDate.AddDate(0, 0, 1)
+	}
+	return months
+}
+
+// monthResult holds the PRs fetched so far for one month
+type monthResult struct {
+	prs    []PullRequestData // PRs matching the selection criteria
+	found  []PullRequestData // all PRs, whatever their repository
+	cursor string            // end cursor of the last fetched page, while more pages remain
+	done   bool
+}
+
+// pullRequestKey identifies a PR as repo#number
+func pullRequestKey(pr PullRequestData) string {
+	return fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+}
+
+// removeDuplicates keeps the first occurrence of each PR, keyed by repo#number. PRs created on a
+// month boundary day can be returned by the queries of both months.
+func removeDuplicates(prs []PullRequestData) []PullRequestData {
+	seen := make(map[string]bool)
+	var result []PullRequestData
+
+	for _, pr := range prs {
+		key := pullRequestKey(pr)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, pr)
+		}
+	}
+
+	return result
+}
+
 // Add fetchPullRequestsGraphQL function
 func fetchPullRequestsGraphQL(ctx context.Context, client *GraphQLClient, limiter *rate.Limiter, config Config, pluginRepos map[string]PluginInfo) ([]PullRequestData, error) {
-	var allPRs []PullRequestData
 	// mutex protects concurrent access to shared data structures:
-	// - allPRs: The slice containing all collected PRs that match our criteria
-	// - allFoundPRs: The global slice containing all PRs found during collection
-	// This mutex is necessary because multiple goroutines may be processing PRs concurrently
-	// when handling paginated GraphQL responses, and we need to ensure thread-safe
-	// appending to these slices to prevent race conditions and data corruption.
+	// - results: The per-month PRs, filled in by one goroutine per month
+	// - lastError: The last query error reported by any month
+	// Up to config.Concurrency months are fetched at once, all sharing the rate limiter,
+	// and the months are merged in calendar order once every goroutine is done.
 	var mutex sync.Mutex
 	var lastError error
 
-	// Split the date range into monthly chunks
-	startDate := config.StartDate
-	endDate := config.EndDate
+	months := splitMonths(config.StartDate, config.EndDate)
+	results := make([]monthResult, len(months))
 
-	for startDate.Before(endDate) {
-		// Calculate the end of the current month
-		currentEndDate := startDate.AddDate(0, 1, -startDate.Day())
-		if currentEndDate.After(endDate) {
-			currentEndDate = endDate
+	// Resume from the month and cursor of a previous interrupted run. Earlier months count as
+	// done, and the PRs they collected are carried by the first remaining month.
+	first, resumeCursor := 0, ""
+	progress, err := loadCollectionProgress(config.OutputFile, config.StartDate, config.EndDate)
+	if err != nil {
+		log.Printf("Warning: Could not load collection progress: %v", err)
+	} else if progress != nil {
+		for i, month := range months {
+			if month.Start.Equal(progress.MonthStart) {
+				log.Printf("Resuming from %s with %d collected PRs", progress.MonthStart.Format("2006-01-02"), len(progress.PRs))
+				first, resumeCursor = i, progress.LastCursor
+				results[i] = monthResult{prs: progress.PRs, found: progress.FoundPRs, cursor: progress.LastCursor}
+				break
+			}
+		}
+	}
+	for i := 0; i < first; i++ {
+		results[i].done = true
+	}
+
+	// stream and foundStream receive each page of collected and found PRs as soon as it is
+	// fetched, starting with the PRs restored from a previous run, and then the months keep none
+	// of them. Callers must hold the mutex.
+	var stream, foundStream *jsonlWriter
+	var streamErr error
+	writeStream := func(w *jsonlWriter, prs []PullRequestData, minPopularity int) {
+		if w == nil || streamErr != nil {
+			return
+		}
+		if config.Popularity != nil {
+			prs = applyPopularity(prs, config.Popularity, minPopularity)
+		}
+		streamErr = w.Write(prs)
+	}
+	if config.JSONLOutputFile != "" {
+		stream, err = newJSONLWriter(config.JSONLOutputFile, progress != nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating JSON Lines output: %w", err)
+		}
+		defer stream.Close()
+		foundStream, err = newJSONLWriter(config.FoundPullRequestsFile, progress != nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating JSON Lines found PRs output: %w", err)
+		}
+		defer foundStream.Close()
+		if first < len(months) {
+			writeStream(stream, results[first].prs, config.MinPopularity)
+			writeStream(foundStream, results[first].found, 0)
+			results[first].prs, results[first].found = nil, nil
+		}
+	}
+
+	// saveProgress records the leading run of completed months, plus the completed pages of the
+	// month after it, so that a later run can resume from there. Callers must hold the mutex.
+	saveProgress := func() {
+		next := 0
+		for next < len(months) && results[next].done {
+			next++
+		}
+		if next == len(months) {
+			return
+		}
+
+		state := CollectionProgress{
+			StartDate:  config.StartDate,
+			EndDate:    config.EndDate,
+			MonthStart: months[next].Start,
+			LastCursor: results[next].cursor,
+			PRs:        []PullRequestData{},
+			FoundPRs:   []PullRequestData{},
 		}
+		for _, result := range results[:next+1] {
+			state.PRs = append(state.PRs, result.prs...)
+			state.FoundPRs = append(state.FoundPRs, result.found...)
+		}
+		if err := saveCollectionProgress(state, config.OutputFile); err != nil {
+			log.Printf("Warning: Failed to save collection progress: %v", err)
+		}
+	}
+
+	pageSize := config.PageSize
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// fetchMonth fetches every page of month i, starting after cursor when it is not empty
+	fetchMonth := func(i int, cursor string) {
+		month := months[i]
 
 		// GitHub search query format for PRs
-		queryString := fmt.Sprintf("org:jenkinsci is:pr created:%s..%s",
-			startDate.Format("2006-01-02"),
-			currentEndDate.Format("2006-01-02"))
+		queryString := buildSearchQuery(config.Orgs,
+			month.Start.Format("2006-01-02"),
+			month.End.Format("2006-01-02"))
 
 		// Variables for the GraphQL query
 		variables := map[string]interface{}{
 			"queryString": queryString,
 			"cursor":      nil,
+			"pageSize":    pageSize,
+		}
+		if cursor != "" {
+			variables["cursor"] = cursor
 		}
 
 		hasNextPage := true
@@ -911,22 +1609,30 @@ func fetchPullRequestsGraphQL(ctx context.Context, client *GraphQLClient, limite
 			}, &response)
 			if err != nil {
 				log.Printf("Warning: GraphQL query error: %v", err)
+				mutex.Lock()
 				lastError = err
-				// Save what we have so far before continuing
-				if len(allPRs) > 0 {
-					if err := writeJSONFile(config.OutputFile+".partial", allPRs); err != nil {
-						log.Printf("Warning: Failed to save partial results: %v", err)
-					}
-				}
+				mutex.Unlock()
 				time.Sleep(5 * time.Second)
 				continue
 			}
 
 			// Process search results
+			var pagePRs, pageFound []PullRequestData
 			for _, pr := range response.Search.Nodes {
+				// Filter out PRs created by bots such as Dependabot and Renovate
+				if isExcludedAuthor(pr.Author.Login, config.ExcludeAuthors) {
+					continue
+				}
+
 				repoName := pr.Repository.Name
 				pluginInfo, isPlugin := pluginRepos[repoName]
 
+				// Collect labels
+				labels := []string{}
+				for _, label := range pr.Labels.Nodes {
+					labels = append(labels, label.Name)
+				}
+
 				// Add all found PRs to the global array
 				prData := PullRequestData{
 					Number:      pr.Number,
@@ -937,37 +1643,29 @@ func fetchPullRequestsGraphQL(ctx context.Context, client *GraphQLClient, limite
 					User:        pr.Author.Login,
 					Repository:  fmt.Sprintf("%s/%s", pr.Repository.Owner.Login, pr.Repository.Name),
 					PluginName:  pluginInfo.Name,
-					Labels:      []string{},
+					Labels:      labels,
 					URL:         pr.URL,
 					Description: pr.BodyText,
 					CheckStatus: getCommitStatus(pr.Commits),
+					MergedAt:    pr.MergedAt,
+					ClosedAt:    pr.ClosedAt,
+					Outcome:     pullRequestOutcome(pr.Merged, pr.State),
 				}
-
-				mutex.Lock()
-				allFoundPRs = append(allFoundPRs, prData)
-				mutex.Unlock()
+				pageFound = append(pageFound, prData)
 
 				// Only process plugin repositories
 				if !isPlugin {
 					continue
 				}
 
-				// Filter out PRs created by Dependabot and Renovate
-				if pr.Author.Login == "dependabot" || pr.Author.Login == "renovate" {
+				// Keep PRs matching a body term and PRs with included labels, minus excluded labels
+				if !selectPullRequest(pr.BodyText, labels, config.BodyTerms, config.Labels) {
 					continue
 				}
 
-				// Check if "odernizer" can be found in the PR body
-				if strings.Contains(pr.BodyText, "odernizer") || strings.Contains(pr.BodyText, "recipe") {
-					// Collect labels
-					var labels []string
-					for _, label := range pr.Labels.Nodes {
-						labels = append(labels, label.Name)
-					}
-					prData.Labels = labels
-					mutex.Lock()
-					allPRs = append(allPRs, prData)
-					mutex.Unlock()
+				// Keep PRs whose state and CI rollup status were requested
+				if matchesAny(prData.State, config.States) && matchesAny(prData.CheckStatus, config.CheckStatuses) {
+					pagePRs = append(pagePRs, prData)
 				}
 			}
 
@@ -976,14 +1674,67 @@ func fetchPullRequestsGraphQL(ctx context.Context, client *GraphQLClient, limite
 			if hasNextPage {
 				variables["cursor"] = response.Search.PageInfo.EndCursor
 			}
+
+			mutex.Lock()
+			if stream != nil {
+				writeStream(stream, pagePRs, config.MinPopularity)
+				writeStream(foundStream, pageFound, 0)
+			} else {
+				results[i].prs = append(results[i].prs, pagePRs...)
+				results[i].found = append(results[i].found, pageFound...)
+			}
+			results[i].cursor = response.Search.PageInfo.EndCursor
+			results[i].done = !hasNextPage
+			saveProgress()
+			mutex.Unlock()
 		}
+	}
 
-		// Move to the next month
-		startDate = currentEndDate.AddDate(0, 0, 1)
+	concurrency := config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	for i := first; i < len(months); i++ {
+		cursor := ""
+		if i == first {
+			cursor = resumeCursor
+		}
+
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int, cursor string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			fetchMonth(i, cursor)
+		}(i, cursor)
+	}
+	wg.Wait()
+
+	// Merge the months in calendar order, dropping PRs returned by more than one query
+	var allPRs []PullRequestData
+	for _, result := range results {
+		allPRs = append(allPRs, result.prs...)
+		allFoundPRs = append(allFoundPRs, result.found...)
+	}
+	allPRs = removeDuplicates(allPRs)
+	allFoundPRs = removeDuplicates(allFoundPRs)
+
+	// The whole range is collected, nothing left to resume
+	os.Remove(config.OutputFile + ".partial")
+
+	if streamErr != nil {
+		return nil, fmt.Errorf("writing JSON Lines output: %w", streamErr)
 	}
 
 	// If we have any results but also had errors, return what we have
-	if len(allPRs) > 0 && lastError != nil {
+	collected := len(allPRs)
+	if stream != nil {
+		collected = stream.written
+	}
+	if collected > 0 && lastError != nil {
 		log.Printf("Warning: Completed with partial results due to errors: %v", lastError)
 		return allPRs, nil
 	}
@@ -0,0 +1,772 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// samplePR is a pull request reduced to the fields used for selection
+type samplePR struct {
+	name   string
+	body   string
+	labels []string
+}
+
+var samplePRs = []samplePR{
+	{name: "modernizer", body: "Applied with plugin-modernizer recipe", labels: []string{"chore"}},
+	{name: "modernizer-wontfix", body: "Generated by the Modernizer", labels: []string{"WontFix"}},
+	{name: "dependencies", body: "Bump jenkins-core", labels: []string{"Dependencies"}},
+	{name: "unrelated", body: "Fix typo in README", labels: []string{"documentation"}},
+}
+
+// selectedNames returns the names of the sample PRs selected with filter
+func selectedNames(filter LabelFilter) []string {
+	var names []string
+	for _, pr := range samplePRs {
+		if selectPullRequest(pr.body, pr.labels, parseCommaSeparated(defaultBodyTerms), filter) {
+			names = append(names, pr.name)
+		}
+	}
+	return names
+}
+
+// TestSelectPullRequestLabels verifies include/exclude label semantics over sample PRs
+func TestSelectPullRequestLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   LabelFilter
+		expected []string
+	}{
+		{"no filter keeps body matches", LabelFilter{}, []string{"modernizer", "modernizer-wontfix"}},
+		{"include requires a label", LabelFilter{Include: []string{"chore"}}, []string{"modernizer"}},
+		{"include still requires a body match", LabelFilter{Include: []string{"dependencies"}}, nil},
+		{"exclude drops labeled PRs", LabelFilter{Exclude: []string{"wontfix"}}, []string{"modernizer"}},
+		{"exclude wins over include", LabelFilter{Include: []string{"chore", "wontfix"}, Exclude: []string{"wontfix"}}, []string{"modernizer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectedNames(tt.filter); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestSelectPullRequestIncludeLabelsExcludesBodyMatches verifies a PR whose body matches is left
+// out when it lacks every included label, and that an empty term list selects by label alone
+func TestSelectPullRequestIncludeLabelsExcludesBodyMatches(t *testing.T) {
+	terms := parseCommaSeparated(defaultBodyTerms)
+	filter := LabelFilter{Include: []string{"dependencies"}}
+
+	if selectPullRequest("Applied with plugin-modernizer recipe", []string{"chore"}, terms, filter) {
+		t.Error("Expected a body-matching PR without an included label to be excluded")
+	}
+	if !selectPullRequest("Applied with plugin-modernizer recipe", []string{"Dependencies"}, terms, filter) {
+		t.Error("Expected a body-matching PR with an included label to be selected")
+	}
+	if !selectPullRequest("Bump jenkins-core", []string{"dependencies"}, nil, filter) {
+		t.Error("Expected an included label to suffice when no body terms are set")
+	}
+}
+
+// TestSelectPullRequestBodyTerms verifies a PR is selected only when its body contains a configured term
+func TestSelectPullRequestBodyTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		terms    string
+		expected []string
+	}{
+		{"default terms", defaultBodyTerms, []string{"modernizer", "modernizer-wontfix"}},
+		{"custom term is case-insensitive", "JENKINS-CORE", []string{"dependencies"}},
+		{"any of several terms", "typo,bump", []string{"dependencies", "unrelated"}},
+		{"empty keeps all PRs", "", []string{"modernizer", "modernizer-wontfix", "dependencies", "unrelated"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := parseCommaSeparated(tt.terms)
+			var got []string
+			for _, pr := range samplePRs {
+				if selectPullRequest(pr.body, pr.labels, terms, LabelFilter{}) {
+					got = append(got, pr.name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestParseCommaSeparated verifies comma-separated flag values are trimmed and lowercased
+func TestParseCommaSeparated(t *testing.T) {
+	got := parseCommaSeparated(" Dependencies, wontfix,,Chore ")
+	expected := []string{"dependencies", "wontfix", "chore"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if values := parseCommaSeparated(""); values != nil {
+		t.Errorf("Expected no values for empty flag, got %v", values)
+	}
+}
+
+// pullRequestNode builds a modernizer PR search node in a plugin repository
+func pullRequestNode(number int, login string) map[string]interface{} {
+	return map[string]interface{}{
+		"number":     number,
+		"title":      "Apply modernizer recipe",
+		"state":      "OPEN",
+		"url":        fmt.Sprintf("https://github.com/jenkinsci/example-plugin/pull/%d", number),
+		"repository": map[string]interface{}{"name": "example-plugin", "owner": map[string]string{"login": "jenkinsci"}},
+		"author":     map[string]string{"login": login},
+		"bodyText":   "Generated by the plugin modernizer recipe",
+	}
+}
+
+// searchResponse builds a one-page search response with one modernizer PR per author in a plugin repository
+func searchResponse(authors ...string) map[string]interface{} {
+	var nodes []map[string]interface{}
+	for i, login := range authors {
+		nodes = append(nodes, pullRequestNode(i+1, login))
+	}
+	return pageResponse(nodes)
+}
+
+// pageResponse wraps search nodes in a last-page search response
+func pageResponse(nodes []map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"search": map[string]interface{}{
+				"pageInfo": map[string]interface{}{"hasNextPage": false},
+				"nodes":    nodes,
+			},
+		},
+	}
+}
+
+// TestFetchPullRequestsExcludesBotAuthors verifies bot PRs are left out of both the collected and found PRs
+func TestFetchPullRequestsExcludesBotAuthors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(searchResponse("renovate[bot]", "Dependabot[bot]", "human"))
+	}))
+	defer server.Close()
+
+	allFoundPRs = nil
+	defer func() { allFoundPRs = nil }()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := Config{
+		StartDate:      start,
+		EndDate:        start.AddDate(0, 0, 10),
+		OutputFile:     filepath.Join(t.TempDir(), "prs.json"),
+		ExcludeAuthors: parseCommaSeparated(defaultExcludedAuthors),
+	}
+	client := &GraphQLClient{httpClient: server.Client(), endpoint: server.URL}
+	plugins := map[string]PluginInfo{"example-plugin": {Name: "example"}}
+
+	prs, err := fetchPullRequestsGraphQL(context.Background(), client, rate.NewLimiter(rate.Inf, 1), config, plugins)
+	if err != nil {
+		t.Fatalf("fetchPullRequestsGraphQL failed: %v", err)
+	}
+
+	for name, found := range map[string][]PullRequestData{"collected": prs, "found": allFoundPRs} {
+		if len(found) != 1 || found[0].User != "human" {
+			t.Errorf("Expected only the human PR in %s PRs, got %+v", name, found)
+		}
+	}
+}
+
+// monthNumber extracts the month of a search query string, e.g. 2 for "created:2025-02-01..2025-02-28"
+func monthNumber(queryString string) int {
+	month, _ := strconv.Atoi(queryString[strings.Index(queryString, "created:")+len("created:2025-"):][:2])
+	return month
+}
+
+// TestFetchPullRequestsResumesFromProgress verifies months recorded in a partial file are not fetched again
+func TestFetchPullRequestsResumesFromProgress(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		queryString := req.Variables["queryString"].(string)
+		queries = append(queries, queryString)
+		if cursor, _ := req.Variables["cursor"].(string); len(queries) == 1 && cursor != "feb-page-1" {
+			t.Errorf("Expected the first query to resume from the saved cursor, got %q", cursor)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pageResponse([]map[string]interface{}{pullRequestNode(monthNumber(queryString), "human")}))
+	}))
+	defer server.Close()
+
+	allFoundPRs = nil
+	defer func() { allFoundPRs = nil }()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC)
+	config := Config{
+		StartDate:  start,
+		EndDate:    end,
+		OutputFile: filepath.Join(t.TempDir(), "prs.json"),
+	}
+
+	january := PullRequestData{Number: 100, Repository: "jenkinsci/example-plugin", User: "human"}
+	err := saveCollectionProgress(CollectionProgress{
+		StartDate:  start,
+		EndDate:    end,
+		MonthStart: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		LastCursor: "feb-page-1",
+		PRs:        []PullRequestData{january},
+		FoundPRs:   []PullRequestData{january},
+	}, config.OutputFile)
+	if err != nil {
+		t.Fatalf("saveCollectionProgress failed: %v", err)
+	}
+
+	client := &GraphQLClient{httpClient: server.Client(), endpoint: server.URL}
+	plugins := map[string]PluginInfo{"example-plugin": {Name: "example"}}
+
+	prs, err := fetchPullRequestsGraphQL(context.Background(), client, rate.NewLimiter(rate.Inf, 1), config, plugins)
+	if err != nil {
+		t.Fatalf("fetchPullRequestsGraphQL failed: %v", err)
+	}
+
+	expectedQueries := []string{
+		"org:jenkinsci is:pr created:2025-02-01..2025-02-28",
+		"org:jenkinsci is:pr created:2025-03-01..2025-03-31",
+	}
+	if !reflect.DeepEqual(queries, expectedQueries) {
+		t.Errorf("Expected queries %v, got %v", expectedQueries, queries)
+	}
+	if len(prs) != 3 || prs[0].Number != january.Number {
+		t.Errorf("Expected the resumed PR followed by one PR per fetched month, got %+v", prs)
+	}
+	if len(allFoundPRs) != 3 {
+		t.Errorf("Expected 3 found PRs, got %d", len(allFoundPRs))
+	}
+	if _, err := os.Stat(config.OutputFile + ".partial"); !os.IsNotExist(err) {
+		t.Errorf("Expected the partial file to be removed after a complete collection")
+	}
+}
+
+// collectMonths runs fetchPullRequestsGraphQL over the first half of 2025 with the given concurrency.
+// Every month returns its own PR plus a PR shared by all months.
+func collectMonths(t *testing.T, concurrency int) (prs, found []PullRequestData) {
+	var mutex sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		queryString := req.Variables["queryString"].(string)
+		mutex.Lock()
+		queries = append(queries, queryString)
+		mutex.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pageResponse([]map[string]interface{}{
+			pullRequestNode(monthNumber(queryString), "human"),
+			pullRequestNode(999, "human"),
+		}))
+	}))
+	defer server.Close()
+
+	allFoundPRs = nil
+	defer func() { allFoundPRs = nil }()
+
+	config := Config{
+		StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC),
+		OutputFile:  filepath.Join(t.TempDir(), "prs.json"),
+		Concurrency: concurrency,
+	}
+	client := &GraphQLClient{httpClient: server.Client(), endpoint: server.URL}
+	plugins := map[string]PluginInfo{"example-plugin": {Name: "example"}}
+
+	prs, err := fetchPullRequestsGraphQL(context.Background(), client, rate.NewLimiter(rate.Inf, 1), config, plugins)
+	if err != nil {
+		t.Fatalf("fetchPullRequestsGraphQL failed: %v", err)
+	}
+	if len(queries) != 6 {
+		t.Errorf("Expected one query per month, got %d", len(queries))
+	}
+	return prs, allFoundPRs
+}
+
+// TestFetchPullRequestsConcurrentMatchesSerial verifies concurrent months produce the same deduplicated PRs as the serial path
+func TestFetchPullRequestsConcurrentMatchesSerial(t *testing.T) {
+	serialPRs, serialFound := collectMonths(t, 1)
+	concurrentPRs, concurrentFound := collectMonths(t, 3)
+
+	var numbers []int
+	for _, pr := range serialPRs {
+		numbers = append(numbers, pr.Number)
+	}
+	if expected := []int{1, 999, 2, 3, 4, 5, 6}; !reflect.DeepEqual(numbers, expected) {
+		t.Errorf("Expected PRs %v in month order without duplicates, got %v", expected, numbers)
+	}
+
+	if !reflect.DeepEqual(concurrentPRs, serialPRs) {
+		t.Errorf("Expected concurrent PRs to match serial PRs\nserial:     %+v\nconcurrent: %+v", serialPRs, concurrentPRs)
+	}
+	if !reflect.DeepEqual(concurrentFound, serialFound) {
+		t.Errorf("Expected concurrent found PRs to match serial found PRs")
+	}
+}
+
+// TestRemoveDuplicates verifies a PR returned by two overlapping month chunks is kept once
+func TestRemoveDuplicates(t *testing.T) {
+	january := []PullRequestData{
+		{Number: 1, Repository: "jenkinsci/example-plugin"},
+		{Number: 7, Repository: "jenkinsci/example-plugin", Title: "created on the boundary day"},
+	}
+	february := []PullRequestData{
+		{Number: 7, Repository: "jenkinsci/example-plugin", Title: "created on the boundary day"},
+		{Number: 7, Repository: "jenkinsci/other-plugin"},
+	}
+
+	got := removeDuplicates(append(january, february...))
+
+	var keys []string
+	for _, pr := range got {
+		keys = append(keys, fmt.Sprintf("%s#%d", pr.Repository, pr.Number))
+	}
+	expected := []string{"jenkinsci/example-plugin#1", "jenkinsci/example-plugin#7", "jenkinsci/other-plugin#7"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, got %v", expected, keys)
+	}
+}
+
+// TestApplyPopularity verifies popularity is joined on the plugin name and niche plugins are filtered out
+func TestApplyPopularity(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "plugins.csv")
+	csvData := "name,popularity\ngit,250000\nexample,1200\n"
+	if err := os.WriteFile(filename, []byte(csvData), 0644); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	popularity, err := loadPopularity(filename)
+	if err != nil {
+		t.Fatalf("loadPopularity failed: %v", err)
+	}
+
+	prs := []PullRequestData{
+		{Number: 1, PluginName: "git"},
+		{Number: 2, PluginName: "example"},
+		{Number: 3, PluginName: "unlisted"},
+	}
+
+	annotated := applyPopularity(prs, popularity, 0)
+	if len(annotated) != 3 {
+		t.Fatalf("Expected all PRs without a minimum, got %d", len(annotated))
+	}
+	for i, expected := range []int{250000, 1200, 0} {
+		if annotated[i].Popularity != expected {
+			t.Errorf("Expected popularity %d for %s, got %d", expected, annotated[i].PluginName, annotated[i].Popularity)
+		}
+	}
+
+	filtered := applyPopularity(prs, popularity, 5000)
+	if len(filtered) != 1 || filtered[0].PluginName != "git" {
+		t.Errorf("Expected only the git PR above the minimum, got %+v", filtered)
+	}
+}
+
+// fetchNodes runs fetchPullRequestsGraphQL over a single month whose search returns nodes
+func fetchNodes(t *testing.T, config Config, nodes []map[string]interface{}) []PullRequestData {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pageResponse(nodes))
+	}))
+	defer server.Close()
+
+	allFoundPRs = nil
+	defer func() { allFoundPRs = nil }()
+
+	config.StartDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	config.EndDate = time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+	config.OutputFile = filepath.Join(t.TempDir(), "prs.json")
+	client := &GraphQLClient{httpClient: server.Client(), endpoint: server.URL}
+	plugins := map[string]PluginInfo{"example-plugin": {Name: "example"}}
+
+	prs, err := fetchPullRequestsGraphQL(context.Background(), client, rate.NewLimiter(rate.Inf, 1), config, plugins)
+	if err != nil {
+		t.Fatalf("fetchPullRequestsGraphQL failed: %v", err)
+	}
+	return prs
+}
+
+// TestFetchPullRequestsCheckStatus verifies only PRs with a requested CI rollup status are collected
+func TestFetchPullRequestsCheckStatus(t *testing.T) {
+	var nodes []map[string]interface{}
+	for i, status := range []string{"SUCCESS", "FAILURE", "PENDING", ""} {
+		node := pullRequestNode(i+1, "human")
+		node["commits"] = map[string]interface{}{
+			"nodes": []map[string]interface{}{
+				{"commit": map[string]interface{}{"statusCheckRollup": map[string]string{"state": status}}},
+			},
+		}
+		nodes = append(nodes, node)
+	}
+
+	tests := []struct {
+		statuses string
+		expected []string
+	}{
+		{"", []string{"SUCCESS", "FAILURE", "PENDING", "UNKNOWN"}},
+		{"SUCCESS", []string{"SUCCESS"}},
+		{"failure,unknown", []string{"FAILURE", "UNKNOWN"}},
+	}
+
+	for _, tt := range tests {
+		prs := fetchNodes(t, Config{CheckStatuses: parseCommaSeparated(tt.statuses)}, nodes)
+		var got []string
+		for _, pr := range prs {
+			got = append(got, pr.CheckStatus)
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("-check-status %q: expected %v, got %v", tt.statuses, tt.expected, got)
+		}
+	}
+
+	if err := validateValues("check status", []string{"success", "green"}, checkStatuses); err == nil {
+		t.Error("Expected an error for an unknown check status")
+	}
+}
+
+// TestFetchPullRequestsStates verifies only OPEN PRs remain when -states OPEN is passed
+func TestFetchPullRequestsStates(t *testing.T) {
+	var nodes []map[string]interface{}
+	for i, state := range []string{"OPEN", "MERGED", "CLOSED", "OPEN"} {
+		node := pullRequestNode(i+1, "human")
+		node["state"] = state
+		nodes = append(nodes, node)
+	}
+
+	prs := fetchNodes(t, Config{States: parseCommaSeparated("OPEN")}, nodes)
+	if len(prs) != 2 {
+		t.Fatalf("Expected 2 open PRs, got %d", len(prs))
+	}
+	for _, pr := range prs {
+		if pr.State != "OPEN" {
+			t.Errorf("Expected only OPEN PRs, got PR #%d in state %s", pr.Number, pr.State)
+		}
+	}
+
+	if prs := fetchNodes(t, Config{}, nodes); len(prs) != 4 {
+		t.Errorf("Expected all 4 PRs without a state filter, got %d", len(prs))
+	}
+	if err := validateValues("state", parseCommaSeparated("open,mergd"), pullRequestStates); err == nil {
+		t.Error("Expected an error for a misspelled state")
+	}
+}
+
+// TestWriteCSVFile verifies records round-trip through encoding/csv, including titles with commas
+func TestWriteCSVFile(t *testing.T) {
+	created := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	prs := []PullRequestData{
+		{Number: 1, Title: "Migrate tests to JUnit 5, remove JUnit 4", State: "MERGED", User: "alice",
+			Repository: "jenkinsci/example-plugin", PluginName: "example", URL: "https://github.com/jenkinsci/example-plugin/pull/1",
+			CheckStatus: "SUCCESS", Labels: []string{"chore", "tests"}, CreatedAt: created},
+		{Number: 2, Title: `Quote "recipe" output`, State: "OPEN", User: "bob",
+			Repository: "jenkinsci/other-plugin", PluginName: "other", URL: "https://github.com/jenkinsci/other-plugin/pull/2",
+			CreatedAt: created},
+	}
+
+	filename := filepath.Join(t.TempDir(), "prs.csv")
+	if err := writeCSVFile(filename, slicePRs(prs)); err != nil {
+		t.Fatalf("writeCSVFile failed: %v", err)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to open CSV: %v", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 records, got %d rows", len(records))
+	}
+	if !reflect.DeepEqual(records[0], csvHeader) {
+		t.Errorf("Expected header %v, got %v", csvHeader, records[0])
+	}
+
+	expected := []string{"1", "Migrate tests to JUnit 5, remove JUnit 4", "MERGED", "alice", "jenkinsci/example-plugin",
+		"example", "https://github.com/jenkinsci/example-plugin/pull/1", "SUCCESS", "chore;tests", "2025-01-15T10:30:00Z"}
+	if !reflect.DeepEqual(records[1], expected) {
+		t.Errorf("Expected record %v, got %v", expected, records[1])
+	}
+	if records[2][1] != `Quote "recipe" output` {
+		t.Errorf("Expected quoted title to round-trip, got %q", records[2][1])
+	}
+}
+
+// TestFetchPullRequestsJSONLOutput verifies each line of the streamed file unmarshals on its own
+// into a PullRequestData, one line per collected PR, that streamed PRs are not kept in memory, and
+// that the found PRs are streamed alongside
+func TestFetchPullRequestsJSONLOutput(t *testing.T) {
+	nodes := []map[string]interface{}{pullRequestNode(1, "alice"), pullRequestNode(2, "bob"), pullRequestNode(2, "bob")}
+	other := pullRequestNode(3, "carol")
+	other["repository"] = map[string]interface{}{"name": "not-a-plugin", "owner": map[string]string{"login": "jenkinsci"}}
+	nodes = append(nodes, other)
+	filename := filepath.Join(t.TempDir(), "prs.jsonl")
+	foundFilename := filepath.Join(t.TempDir(), "found_prs.jsonl")
+	config := Config{JSONLOutputFile: filename, FoundPullRequestsFile: foundFilename}
+	if prs := fetchNodes(t, config, nodes); len(prs) != 0 {
+		t.Errorf("Expected streamed PRs not to be returned, got %d", len(prs))
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read JSON Lines output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	expected := []PullRequestData{{Number: 1, User: "alice"}, {Number: 2, User: "bob"}}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, one per collected PR, got %d", len(expected), len(lines))
+	}
+	for i, line := range lines {
+		var pr PullRequestData
+		if err := json.Unmarshal([]byte(line), &pr); err != nil {
+			t.Fatalf("Line %d does not unmarshal into a PullRequestData: %v", i+1, err)
+		}
+		if pr.Number != expected[i].Number || pr.User != expected[i].User || pr.PluginName != "example" {
+			t.Errorf("Line %d: expected PR #%d by %s, got %+v", i+1, expected[i].Number, expected[i].User, pr)
+		}
+	}
+
+	report, err := buildReport(jsonlPRs(filename), nil)
+	if err != nil {
+		t.Fatalf("buildReport failed on the streamed file: %v", err)
+	}
+	if report.TotalPRs != 2 || report.ByAuthor["alice"] != 1 || report.ByAuthor["bob"] != 1 {
+		t.Errorf("Expected the report to read both streamed PRs back, got %+v", report)
+	}
+
+	var found []int
+	err = jsonlPRs(foundFilename)(func(pr PullRequestData) error {
+		found = append(found, pr.Number)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to read the streamed found PRs: %v", err)
+	}
+	if !reflect.DeepEqual(found, []int{1, 2, 3}) {
+		t.Errorf("Expected every found PR to be streamed once, got %v", found)
+	}
+}
+
+// TestJSONLWriterResume verifies a resumed stream appends to the file of the interrupted run
+// without writing its PRs twice
+func TestJSONLWriterResume(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "prs.jsonl")
+	first := PullRequestData{Number: 1, Repository: "jenkinsci/example-plugin"}
+	second := PullRequestData{Number: 2, Repository: "jenkinsci/example-plugin"}
+
+	w, err := newJSONLWriter(filename, false)
+	if err != nil {
+		t.Fatalf("newJSONLWriter failed: %v", err)
+	}
+	if err := w.Write([]PullRequestData{first}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	w.Close()
+
+	w, err = newJSONLWriter(filename, true)
+	if err != nil {
+		t.Fatalf("newJSONLWriter failed to resume: %v", err)
+	}
+	if err := w.Write([]PullRequestData{first, second}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	w.Close()
+
+	var numbers []int
+	jsonlPRs(filename)(func(pr PullRequestData) error {
+		numbers = append(numbers, pr.Number)
+		return nil
+	})
+	if !reflect.DeepEqual(numbers, []int{1, 2}) {
+		t.Errorf("Expected PRs 1 and 2 once each, got %v", numbers)
+	}
+}
+
+// TestBuildReport verifies the aggregate counts of a summary report, leaving bots out of the authors
+func TestBuildReport(t *testing.T) {
+	prs := []PullRequestData{
+		{Number: 1, State: "MERGED", User: "alice", PluginName: "git"},
+		{Number: 2, State: "MERGED", User: "bob", PluginName: "git"},
+		{Number: 3, State: "OPEN", User: "alice", PluginName: "credentials"},
+		{Number: 4, State: "CLOSED", User: "renovate[bot]", PluginName: "mailer"},
+		{Number: 5, State: "MERGED", User: "alice", PluginName: "credentials"},
+		{Number: 6, State: "OPEN", User: "carol", PluginName: "git"},
+	}
+
+	report, err := buildReport(slicePRs(prs), parseCommaSeparated(defaultExcludedAuthors))
+	if err != nil {
+		t.Fatalf("buildReport failed: %v", err)
+	}
+
+	if report.TotalPRs != 6 {
+		t.Errorf("Expected 6 PRs, got %d", report.TotalPRs)
+	}
+	if expected := map[string]int{"MERGED": 3, "OPEN": 2, "CLOSED": 1}; !reflect.DeepEqual(report.ByState, expected) {
+		t.Errorf("Expected states %v, got %v", expected, report.ByState)
+	}
+	if expected := map[string]int{"alice": 3, "bob": 1, "carol": 1}; !reflect.DeepEqual(report.ByAuthor, expected) {
+		t.Errorf("Expected authors %v, got %v", expected, report.ByAuthor)
+	}
+	expectedPlugins := []PluginCount{{"git", 3}, {"credentials", 2}, {"mailer", 1}}
+	if !reflect.DeepEqual(report.TopPlugins, expectedPlugins) {
+		t.Errorf("Expected top plugins %v, got %v", expectedPlugins, report.TopPlugins)
+	}
+	if report.MergedRatio != 0.6 {
+		t.Errorf("Expected a merged ratio of 0.6, got %v", report.MergedRatio)
+	}
+
+	if empty, _ := buildReport(slicePRs(nil), nil); empty.TotalPRs != 0 || empty.MergedRatio != 0 || len(empty.TopPlugins) != 0 {
+		t.Errorf("Expected an empty report, got %+v", empty)
+	}
+}
+
+// TestFetchPullRequestsPageSize verifies the page size reaches the query variables, defaulting to
+// the largest page, and that the search query takes it from them
+func TestFetchPullRequestsPageSize(t *testing.T) {
+	if !strings.Contains(searchQuery, "first: $pageSize") {
+		t.Error("Expected the search query to take its page size from $pageSize")
+	}
+
+	allFoundPRs = nil
+	defer func() { allFoundPRs = nil }()
+
+	for _, tt := range []struct{ pageSize, expected int }{{25, 25}, {0, maxPageSize}} {
+		var got []float64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			pageSize, _ := req.Variables["pageSize"].(float64)
+			got = append(got, pageSize)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(searchResponse("human"))
+		}))
+
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		config := Config{
+			StartDate:  start,
+			EndDate:    start.AddDate(0, 0, 10),
+			OutputFile: filepath.Join(t.TempDir(), "prs.json"),
+			PageSize:   tt.pageSize,
+		}
+		client := &GraphQLClient{httpClient: server.Client(), endpoint: server.URL}
+		if _, err := fetchPullRequestsGraphQL(context.Background(), client, rate.NewLimiter(rate.Inf, 1), config, nil); err != nil {
+			t.Fatalf("fetchPullRequestsGraphQL failed: %v", err)
+		}
+		server.Close()
+
+		if len(got) != 1 || int(got[0]) != tt.expected {
+			t.Errorf("Page size %d: expected the query variable %d, got %v", tt.pageSize, tt.expected, got)
+		}
+	}
+}
+
+// TestBuildSearchQueryOrgs verifies the search query names every organization, defaulting to jenkinsci
+func TestBuildSearchQueryOrgs(t *testing.T) {
+	tests := []struct {
+		orgs     string
+		expected string
+	}{
+		{"", "org:jenkinsci is:pr created:2025-01-01..2025-01-31"},
+		{"jenkinsci", "org:jenkinsci is:pr created:2025-01-01..2025-01-31"},
+		{"jenkinsci, jenkins-infra,cloudbees", "org:jenkinsci org:jenkins-infra org:cloudbees is:pr created:2025-01-01..2025-01-31"},
+	}
+
+	for _, tt := range tests {
+		if got := buildSearchQuery(parseCommaSeparated(tt.orgs), "2025-01-01", "2025-01-31"); got != tt.expected {
+			t.Errorf("-orgs %q: expected %q, got %q", tt.orgs, tt.expected, got)
+		}
+	}
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		queries = append(queries, req.Variables["queryString"].(string))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(searchResponse("human"))
+	}))
+	defer server.Close()
+
+	allFoundPRs = nil
+	defer func() { allFoundPRs = nil }()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := Config{
+		StartDate:  start,
+		EndDate:    start.AddDate(0, 0, 10),
+		OutputFile: filepath.Join(t.TempDir(), "prs.json"),
+		Orgs:       []string{"jenkinsci", "jenkins-infra"},
+	}
+	client := &GraphQLClient{httpClient: server.Client(), endpoint: server.URL}
+	if _, err := fetchPullRequestsGraphQL(context.Background(), client, rate.NewLimiter(rate.Inf, 1), config, nil); err != nil {
+		t.Fatalf("fetchPullRequestsGraphQL failed: %v", err)
+	}
+	if len(queries) != 1 || !strings.HasPrefix(queries[0], "org:jenkinsci org:jenkins-infra is:pr ") {
+		t.Errorf("Expected the collector to search both organizations, got %v", queries)
+	}
+}
+
+// TestFetchPullRequestsOutcome verifies a merged PR is told apart from one closed without merging
+func TestFetchPullRequestsOutcome(t *testing.T) {
+	merged := pullRequestNode(1, "human")
+	merged["state"] = "MERGED"
+	merged["merged"] = true
+	merged["mergedAt"] = "2025-01-20T10:00:00Z"
+	merged["closedAt"] = "2025-01-20T10:00:00Z"
+
+	closed := pullRequestNode(2, "human")
+	closed["state"] = "CLOSED"
+	closed["merged"] = false
+	closed["closedAt"] = "2025-01-21T10:00:00Z"
+
+	prs := fetchNodes(t, Config{}, []map[string]interface{}{merged, closed, pullRequestNode(3, "human")})
+	if len(prs) != 3 {
+		t.Fatalf("Expected 3 PRs, got %d", len(prs))
+	}
+
+	expected := []string{outcomeMerged, outcomeClosedUnmerged, outcomeOpen}
+	for i, pr := range prs {
+		if pr.Outcome != expected[i] {
+			t.Errorf("PR #%d: expected outcome %q, got %q", pr.Number, expected[i], pr.Outcome)
+		}
+	}
+	if prs[0].MergedAt == nil || !prs[0].MergedAt.Equal(time.Date(2025, 1, 20, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the merged PR to carry its merge time, got %v", prs[0].MergedAt)
+	}
+	if prs[1].MergedAt != nil || prs[1].ClosedAt == nil {
+		t.Errorf("Expected the closed PR to carry a close time and no merge time, got %v and %v", prs[1].MergedAt, prs[1].ClosedAt)
+	}
+	if prs[2].MergedAt != nil || prs[2].ClosedAt != nil {
+		t.Errorf("Expected the open PR to carry neither time, got %v and %v", prs[2].MergedAt, prs[2].ClosedAt)
+	}
+}
@@ -0,0 +1,16 @@
+package ratelimit
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff returns the delay before retry attempt, counting from 0: base doubled on every
+// attempt up to max, plus a random extra delay of up to jitter times that delay
+func Backoff(base, max time.Duration, jitter float64, attempt int) time.Duration {
+	delay := max
+	if attempt < 62 && base < max>>uint(attempt) {
+		delay = base << uint(attempt)
+	}
+	return delay + time.Duration(rand.Float64()*jitter*float64(delay))
+}
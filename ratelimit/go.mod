@@ -0,0 +1,3 @@
+module jenkins.io/alpha-omega-stats/ratelimit
+
+go 1.23.0
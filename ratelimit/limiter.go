@@ -0,0 +1,233 @@
+// Package ratelimit tracks GitHub's rate limit headers and holds callers back when the
+// remaining budget runs low, so every API consumer shares the same waiting policy. It is its
+// own module so that jenkins-pr-collector, find-junit5-prs and github-profile-tools can all
+// import it.
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Logger receives the limiter's messages
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
+
+// stdLogger writes information and warnings with the standard log package and drops debug messages
+type stdLogger struct{}
+
+func (stdLogger) Debugf(format string, args ...interface{}) {}
+func (stdLogger) Infof(format string, args ...interface{})  { log.Printf(format, args...) }
+func (stdLogger) Warnf(format string, args ...interface{})  { log.Printf(format, args...) }
+
+// Status is a snapshot of the GitHub rate limit
+type Status struct {
+	Limit     int       // Maximum number of requests per hour
+	Remaining int       // Number of requests remaining in current window
+	ResetTime time.Time // When the rate limit window resets
+	Used      int       // Number of requests used in current window
+	Resource  string    // Rate limit resource (graphql, core, etc.)
+	Updated   bool      // Whether this info has been updated from API response
+}
+
+// Limiter tracks the rate limit reported by GitHub and blocks callers while it is exhausted
+type Limiter struct {
+	status   Status
+	mutex    sync.RWMutex
+	logger   Logger
+	observer func(Status) // called with the status after each update
+}
+
+// New creates a limiter assuming limit requests are available for resource until GitHub reports otherwise
+func New(limit int, resource string) *Limiter {
+	return &Limiter{
+		status: Status{
+			Limit:     limit,
+			Remaining: limit,
+			ResetTime: time.Now().Add(time.Hour),
+			Resource:  resource,
+		},
+		logger: stdLogger{},
+	}
+}
+
+// WithLogger sends the limiter's messages to logger instead of the standard log package
+func (l *Limiter) WithLogger(logger Logger) *Limiter {
+	l.logger = logger
+	return l
+}
+
+// WithObserver calls observe with the new status after each update, e.g. to export metrics
+func (l *Limiter) WithObserver(observe func(Status)) *Limiter {
+	l.observer = observe
+	return l
+}
+
+// Status returns a copy of the current rate limit information
+func (l *Limiter) Status() Status {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.status
+}
+
+// Update parses GitHub rate limit headers and updates the tracked status
+func (l *Limiter) Update(headers http.Header) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// GitHub APIs use these headers:
+	// X-RateLimit-Limit: Maximum number of requests per hour
+	// X-RateLimit-Remaining: Number of requests remaining
+	// X-RateLimit-Reset: Unix timestamp when the rate limit resets
+	// X-RateLimit-Used: Number of requests used
+	// X-RateLimit-Resource: The rate limit resource (graphql, core, etc.)
+
+	if limitStr := headers.Get("X-RateLimit-Limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			l.status.Limit = limit
+		}
+	}
+
+	if remainingStr := headers.Get("X-RateLimit-Remaining"); remainingStr != "" {
+		if remaining, err := strconv.Atoi(remainingStr); err == nil {
+			l.status.Remaining = remaining
+		}
+	}
+
+	if resetStr := headers.Get("X-RateLimit-Reset"); resetStr != "" {
+		if resetUnix, err := strconv.ParseInt(resetStr, 10, 64); err == nil {
+			l.status.ResetTime = time.Unix(resetUnix, 0)
+		}
+	}
+
+	if usedStr := headers.Get("X-RateLimit-Used"); usedStr != "" {
+		if used, err := strconv.Atoi(usedStr); err == nil {
+			l.status.Used = used
+		}
+	}
+
+	if resource := headers.Get("X-RateLimit-Resource"); resource != "" {
+		l.status.Resource = resource
+	}
+
+	// Mark that we've received actual rate limit data from API
+	l.status.Updated = true
+
+	if l.observer != nil {
+		l.observer(l.status)
+	}
+
+	// Log rate limit status for monitoring
+	l.logger.Debugf("GitHub API Rate Limit - Resource: %s, Used: %d/%d, Remaining: %d, Resets: %v",
+		l.status.Resource, l.status.Used, l.status.Limit,
+		l.status.Remaining, l.status.ResetTime.Format(time.RFC3339))
+
+	// Warn if getting close to rate limit
+	if l.status.Limit > 0 && l.status.Remaining < l.status.Limit/10 { // Less than 10%
+		percentRemaining := float64(l.status.Remaining) / float64(l.status.Limit) * 100
+		l.logger.Warnf("⚠️  GitHub API rate limit warning: Only %.1f%% (%d) requests remaining until %v",
+			percentRemaining, l.status.Remaining, l.status.ResetTime.Format("15:04:05"))
+	}
+}
+
+// Wait blocks until a request can be made without exceeding the rate limit: until the reset when
+// nothing remains, or for a share of the remaining window when less than 5% is left.
+func (l *Limiter) Wait(ctx context.Context) error {
+	status := l.Status()
+	now := time.Now()
+
+	// If we haven't received actual rate limit data yet, proceed with caution
+	if !status.Updated {
+		l.logger.Debugf("Rate limit check: No API data yet, proceeding cautiously")
+		return nil
+	}
+
+	// If rate limit window has reset, we're good to go
+	if now.After(status.ResetTime) {
+		l.logger.Debugf("Rate limit window has reset, proceeding with request")
+		return nil
+	}
+
+	// If we have plenty of requests remaining (>10% of limit), proceed
+	if status.Remaining > status.Limit/10 {
+		l.logger.Debugf("Rate limit check: %d/%d requests remaining", status.Remaining, status.Limit)
+		return nil
+	}
+
+	// If we're out of requests, wait for the window to reset
+	if status.Remaining <= 0 {
+		waitDuration := status.ResetTime.Sub(now)
+		l.logger.Warnf("Rate limit exceeded, waiting %v until reset (%v)", waitDuration, status.ResetTime.Format(time.RFC3339))
+
+		if err := sleep(ctx, waitDuration); err != nil {
+			return err
+		}
+		l.logger.Infof("Rate limit window reset, proceeding")
+		return nil
+	}
+
+	// If we're running low but not empty, implement intelligent throttling
+	if status.Remaining < status.Limit/20 { // Less than 5% remaining
+		// Space out remaining requests evenly across remaining time
+		waitTime := status.ResetTime.Sub(now) / time.Duration(status.Remaining+1)
+
+		// Don't wait more than 30 seconds for a single request
+		if waitTime > 30*time.Second {
+			waitTime = 30 * time.Second
+		}
+
+		l.logger.Infof("Rate limit low (%d/%d), throttling request by %v", status.Remaining, status.Limit, waitTime)
+		return sleep(ctx, waitTime)
+	}
+
+	return nil
+}
+
+// sleep waits for d, returning early with the context error when ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Transport returns an http.RoundTripper sending requests through base (http.DefaultTransport
+// when nil) that waits for the limiter before each request and updates it from each response
+// carrying rate limit headers
+func (l *Limiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{limiter: l, base: base}
+}
+
+// transport is the http.RoundTripper returned by Limiter.Transport
+type transport struct {
+	limiter *Limiter
+	base    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.Header.Get("X-RateLimit-Remaining") != "" {
+		t.limiter.Update(resp.Header)
+	}
+	return resp, err
+}
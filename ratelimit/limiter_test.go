@@ -0,0 +1,145 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// rateLimitHeaders builds the headers GitHub sends with remaining requests left until reset
+func rateLimitHeaders(limit, remaining int, reset time.Time) http.Header {
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	headers.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	headers.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	headers.Set("X-RateLimit-Resource", "core")
+	return headers
+}
+
+// TestUpdate verifies the limiter tracks the rate limit headers
+func TestUpdate(t *testing.T) {
+	limiter := New(5000, "graphql")
+	if limiter.Status().Updated {
+		t.Fatal("Expected no API data before the first response")
+	}
+
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	limiter.Update(rateLimitHeaders(60, 12, reset))
+
+	status := limiter.Status()
+	if !status.Updated || status.Limit != 60 || status.Remaining != 12 || status.Resource != "core" {
+		t.Errorf("Unexpected status after update: %+v", status)
+	}
+	if !status.ResetTime.Equal(reset) {
+		t.Errorf("Expected reset at %v, got %v", reset, status.ResetTime)
+	}
+}
+
+// TestWaitUntilReset verifies Wait blocks until the reset once no requests remain
+func TestWaitUntilReset(t *testing.T) {
+	limiter := New(5000, "graphql")
+	// Reset times are whole seconds, so the wait lasts up to two seconds
+	reset := time.Now().Add(time.Second).Truncate(time.Second).Add(time.Second)
+	limiter.Update(rateLimitHeaders(5000, 0, reset))
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if now := time.Now(); now.Before(reset) {
+		t.Errorf("Expected Wait to block until %v, returned at %v", reset, now)
+	}
+}
+
+// TestWaitCancelled verifies a cancelled context interrupts the wait for the reset
+func TestWaitCancelled(t *testing.T) {
+	limiter := New(5000, "graphql")
+	limiter.Update(rateLimitHeaders(5000, 0, time.Now().Add(time.Hour)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected Wait to return on cancellation, took %v", elapsed)
+	}
+}
+
+// TestWaitWithBudget verifies Wait does not block while plenty of requests remain or after the reset
+func TestWaitWithBudget(t *testing.T) {
+	for name, headers := range map[string]http.Header{
+		"plenty remaining": rateLimitHeaders(5000, 4000, time.Now().Add(time.Hour)),
+		"window reset":     rateLimitHeaders(5000, 0, time.Now().Add(-time.Minute)),
+	} {
+		limiter := New(5000, "graphql")
+		limiter.Update(headers)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		if err := limiter.Wait(ctx); err != nil {
+			t.Errorf("%s: expected no wait, got %v", name, err)
+		}
+		cancel()
+	}
+}
+
+// TestTransport verifies the transport updates the limiter from responses and waits for it
+// before the next request
+func TestTransport(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for key, values := range rateLimitHeaders(5000, 0, reset) {
+			w.Header()[key] = values
+		}
+	}))
+	defer server.Close()
+
+	limiter := New(5000, "graphql")
+	client := &http.Client{Transport: limiter.Transport(nil)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+	resp.Body.Close()
+	if status := limiter.Status(); !status.Updated || status.Remaining != 0 {
+		t.Fatalf("Expected the response headers in the limiter, got %+v", status)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the exhausted limiter to hold the request until the deadline, got %v", err)
+	}
+}
+
+// TestBackoff verifies the delay doubles per attempt, stays below max and adds at most the jitter
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{6, time.Minute},
+		{100, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := Backoff(time.Second, time.Minute, 0, tt.attempt); got != tt.want {
+			t.Errorf("Attempt %d: expected %v, got %v", tt.attempt, tt.want, got)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		if got := Backoff(time.Second, time.Minute, 0.5, 1); got < 2*time.Second || got > 3*time.Second {
+			t.Fatalf("Expected 2s plus up to 50%% jitter, got %v", got)
+		}
+	}
+}